@@ -4,11 +4,13 @@ import (
     "bufio"
     "fmt"
     "os"
+    "strconv"
 
     "nepa/desarrollo/interno/sintaxis"
     "nepa/desarrollo/interno/parser"
     "nepa/desarrollo/interno/evaluador"
     "nepa/desarrollo/interno/core"
+    "nepa/desarrollo/comandos/avisos"
 
     // 🔑 El Mago Nepu: Al importar matemáticas, su init() registra todo solo
     _ "nepa/desarrollo/interno/matematicas"
@@ -57,8 +59,10 @@ func EjecutarPrograma(archivo string, args map[string]interface{}) (map[string]i
 
     ast := parser.Parse(lineas)
 
-    // Evaluador con entorno global
+    // Evaluador con entorno global; un generador aleatorio por programa
+    evaluador.IniciarAleatorio()
     resultados, err := evaluador.EjecutarConContexto(ast, args, _GLOBALES, _CONSTANTES, archivo)
+    avisos.Mostrar() // los de las llamadas directas, que no pasan por un comando
     if err != nil {
         // Manejo de recursión de archivos (Ejecutar otros programas)
         if solicitud, ok := err.(evaluador.SolicitudEjecutar); ok {
@@ -105,6 +109,7 @@ func main() {
         fmt.Println("  --ayuda, -a             Muestra esta ayuda detallada")
         fmt.Println("  --v, --vv, --vvv, --vvvv Control de detalle (1 a 4 niveles)")
         fmt.Println("  --c, --configuracion <archivo.conf> Carga configuración (default: nepa.conf)")
+        fmt.Println("  --semilla <n>           Fija la semilla aleatoria (resultados reproducibles)")
         os.Exit(0)
     case "--v":
         _DETALLE = 1
@@ -119,6 +124,15 @@ func main() {
             _CONFIGURACION = os.Args[2]
         }
         // TODO: cargar archivo de configuración si existe
+    case "--semilla":
+        if len(os.Args) > 2 {
+            semilla, err := strconv.ParseInt(os.Args[2], 10, 64)
+            if err != nil {
+                core.EmitirError(core.FATAL, "main", 0, 9000, "La semilla debe ser un número entero: "+os.Args[2])
+                os.Exit(1)
+            }
+            evaluador.EstablecerSemillaInicial(semilla)
+        }
     }
 
     archivo := os.Args[len(os.Args)-1]
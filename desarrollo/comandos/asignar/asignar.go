@@ -5,6 +5,7 @@ import (
     "fmt"
     "strings"
 
+    "nepa/desarrollo/comandos/avisos"
     "nepa/desarrollo/interno/administrador"
     "nepa/desarrollo/interno/evaluador"
     "nepa/desarrollo/interno/parser"
@@ -76,6 +77,13 @@ func Ejecutar(linea string) error {
     // Verificar existencia
     v, err := administrador.ObtenerVariable(nombre)
     if err != nil {
+        // Una función (f := funcion(x) => x^2) declara su variable al asignarse
+        if resultado, errEval := evaluador.Eval(expr); errEval == nil {
+            if creada, ok := declararFuncion(nombre, resultado); ok {
+                fmt.Printf("✔ FUNCION '%s' ← %s\n", nombre, creada.ValorComoInterface())
+                return nil
+            }
+        }
         return fmt.Errorf("❌ %w: '%s'", ErrVariableNoExiste, nombre)
     }
 
@@ -104,6 +112,22 @@ func Ejecutar(linea string) error {
     return nil
 }
 
+// declararFuncion crea la variable de tipo funcion si el valor es una función
+// y el nombre aún no existe.
+func declararFuncion(nombre string, valor interface{}) (administrador.Variable, bool) {
+    if _, esFuncion := valor.(evaluador.Funcion); !esFuncion {
+        return nil, false
+    }
+    if _, err := administrador.ObtenerVariable(nombre); err == nil {
+        return nil, false
+    }
+    v, err := administrador.CrearVariableUniversal("funcion", nombre, valor)
+    if err != nil {
+        return nil, false
+    }
+    return v, true
+}
+
 func tern(cond bool, a, b string) string {
     if cond {
         return a
@@ -135,6 +159,7 @@ func toFloat64(v interface{}) (float64, error) {
 // init registra este comando en el ejecutor universal
 func init() {
     evaluador.Registrar("asignar", func(n parser.Nodo, ctx *evaluador.Contexto) {
+        defer avisos.Mostrar()
         // Separar nombres por si viene "a, b"
         nombres := strings.Split(n.Nombre, ",")
 
@@ -144,6 +169,15 @@ func init() {
                 continue
             }
 
+            // Una función declara su variable al asignarse
+            if creada, ok := declararFuncion(nombre, n.Valor); ok {
+                if ctx != nil && ctx.Variables != nil {
+                    ctx.Variables[nombre] = creada
+                }
+                fmt.Printf("✔ FUNCION '%s' ← %v\n", nombre, n.Valor)
+                continue
+            }
+
             // Verificar que la variable destino exista
             vDestino, err := administrador.ObtenerVariable(nombre)
             if err != nil {
@@ -0,0 +1,15 @@
+package avisos
+
+import (
+    "fmt"
+
+    "nepa/desarrollo/interno/evaluador"
+)
+
+// Mostrar imprime los avisos que dejaron las funciones durante la última
+// instrucción (ver evaluador.Avisar).
+func Mostrar() {
+    for _, aviso := range evaluador.TomarAvisos() {
+        fmt.Printf("⚠️ AVISO: %s\n", aviso)
+    }
+}
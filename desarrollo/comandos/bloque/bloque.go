@@ -2,32 +2,89 @@ package bloque
 
 import (
     "fmt"
+    "strings"
+    "unicode"
 
     "nepa/desarrollo/interno/evaluador"
     "nepa/desarrollo/interno/parser"
 )
 
+// ejecutarHijos ejecuta los subnodos de n.Args y devuelve cuántos se ejecutaron.
+func ejecutarHijos(n parser.Nodo, ctx *evaluador.Contexto) (int, error) {
+    count := 0
+    for i, arg := range n.Args {
+        // Verificamos si el argumento es un Nodo
+        if hijo, ok := arg.(parser.Nodo); ok {
+            _, err := evaluador.EjecutarConContexto(
+                []parser.Nodo{hijo},
+                ctx.Variables,
+                ctx.Globales,
+                ctx.Constantes,
+                fmt.Sprintf("bloque:%d", i+1), // identificador de archivo/posición
+            )
+            if err != nil {
+                return count, fmt.Errorf("bloque:%d: %v", i+1, err)
+            }
+            count++
+        }
+    }
+    return count, nil
+}
+
+// ejecutarUsar maneja `usar nombre = expr:` (o `usar expr:`). El recurso queda
+// disponible dentro del bloque y se cierra al terminar, aunque haya errores:
+//
+//     usar f = abrir("datos.txt"):
+//         imprimir f.leer_linea()
+func ejecutarUsar(n parser.Nodo, ctx *evaluador.Contexto) error {
+    cabecera := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(n.Nombre), "usar"))
+    nombre, expr := "", cabecera
+    if i := strings.Index(cabecera, "="); i > 0 && !strings.HasPrefix(cabecera[i:], "==") &&
+        esIdentificador(strings.TrimSpace(cabecera[:i])) {
+        nombre = strings.ToLower(strings.TrimSpace(cabecera[:i]))
+        expr = strings.TrimSpace(cabecera[i+1:])
+    }
+    if expr == "" {
+        return fmt.Errorf("sintaxis inválida: use 'usar nombre = expresion:'")
+    }
+
+    recurso, err := evaluador.EvalConContexto(expr, ctx)
+    if err != nil {
+        return err
+    }
+    if nombre != "" {
+        ctx.Variables[nombre] = recurso
+    }
+    return evaluador.Usar(recurso, func() error {
+        _, err := ejecutarHijos(n, ctx)
+        return err
+    })
+}
+
+func esIdentificador(s string) bool {
+    for i, r := range s {
+        if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+            return false
+        }
+    }
+    return s != ""
+}
+
 // init registra el handler para nodos tipo "bloque"
 func init() {
     evaluador.Registrar("bloque", func(n parser.Nodo, ctx *evaluador.Contexto) {
-        // Un bloque normalmente contiene subnodos en n.Args
-        count := 0
-        for i, arg := range n.Args {
-            // Verificamos si el argumento es un Nodo
-            if hijo, ok := arg.(parser.Nodo); ok {
-                _, err := evaluador.EjecutarConContexto(
-                    []parser.Nodo{hijo},
-                    ctx.Variables,
-                    ctx.Globales,
-                    ctx.Constantes,
-                    fmt.Sprintf("bloque:%d", i+1), // identificador de archivo/posición
-                )
-                if err != nil {
-                    fmt.Printf("Error: bloque:%d: %v\n", i+1, err)
-                    return // detener ejecución del bloque en caso de error fatal
-                }
-                count++
+        if cabecera := strings.Fields(n.Nombre); len(cabecera) > 0 && cabecera[0] == "usar" {
+            if err := ejecutarUsar(n, ctx); err != nil {
+                fmt.Printf("⚠️ Error en usar: %v\n", err)
             }
+            return
+        }
+
+        // Un bloque normalmente contiene subnodos en n.Args
+        count, err := ejecutarHijos(n, ctx)
+        if err != nil {
+            fmt.Printf("Error: %v\n", err)
+            return // detener ejecución del bloque en caso de error fatal
         }
         fmt.Printf("✔ Bloque ejecutado (%d nodos)\n", count)
     })
@@ -11,6 +11,7 @@ import (
     _ "nepa/desarrollo/comandos/bloque"
     _ "nepa/desarrollo/comandos/expresion"
     _ "nepa/desarrollo/comandos/llamada"
+    _ "nepa/desarrollo/comandos/funcion"
     _ "nepa/desarrollo/comandos/inyectar_todas_variables" // inyecta todos los tipos de variables
     _ "nepa/desarrollo/comandos/conversiones"             // 👈 nuevo: dispara init() de conversiones
     _ "nepa/desarrollo/interno/matematicas"               // 🔥 dispara init() matematicas
@@ -7,6 +7,7 @@ func RegistrarConversionesBasicas(ctx *evaluador.Contexto) {
     // Conversión básica de tipos primarios
     RegistrarConvertirEntero(ctx)
     RegistrarConvertirReal(ctx)
+    RegistrarConvertirDecimal(ctx)
     RegistrarConvertirCadena(ctx)
     RegistrarConvertirBooleano(ctx)
 
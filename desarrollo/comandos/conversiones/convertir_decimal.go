@@ -0,0 +1,43 @@
+package conversiones
+
+import (
+    "nepa/desarrollo/interno/evaluador"
+)
+
+// Ayuda integrada para el comando convertir_decimal
+const ayudaConvertirDecimal = `
+convertir_decimal(valor) → decimal
+Alias: a_decimal(valor), convertir.decimal(valor)
+
+Se espera:
+- cadena numérica ("19.99") → decimal exacto 19.99
+- entero (123) → decimal 123
+- real (0.1) → decimal 0.1 (su representación decimal más corta)
+- decimal (ya es decimal) → se devuelve tal cual
+
+Devuelve un decimal exacto en base 10. Si el valor no puede convertirse, se muestra este mensaje de ayuda.
+`
+
+// fnConvertirDecimal realiza la conversión a decimal exacto
+func fnConvertirDecimal(args ...interface{}) interface{} {
+    if len(args) < 1 {
+        return evaluador.NuevaErrorConversion("convertir_decimal", ayudaConvertirDecimal, nil)
+    }
+    if d, ok := evaluador.ComoDecimal(args[0]); ok {
+        return d
+    }
+    return evaluador.NuevaErrorConversion("convertir_decimal", ayudaConvertirDecimal, args[0])
+}
+
+// RegistrarConvertirDecimal registra el comando con sus tres alias
+func RegistrarConvertirDecimal(ctx *evaluador.Contexto) {
+    evaluador.Funciones["convertir_decimal"] = func(args ...interface{}) (interface{}, error) {
+        r := fnConvertirDecimal(args...)
+        if err, ok := r.(error); ok {
+            return nil, err
+        }
+        return r, nil
+    }
+    evaluador.Funciones["a_decimal"] = evaluador.Funciones["convertir_decimal"]
+    evaluador.Funciones["convertir.decimal"] = evaluador.Funciones["convertir_decimal"]
+}
@@ -2,6 +2,7 @@ package conversiones
 
 import (
     "fmt"
+    "math/big"
     "strconv"
     "nepa/desarrollo/interno/evaluador"
     "nepa/desarrollo/interno/administrador"
@@ -44,9 +45,17 @@ func fnConvertirEntero(args ...interface{}) interface{} {
         return int(v)
     case float64:
         return int(v)
+    case *big.Int:
+        // Los enteros grandes se conservan tal cual
+        return v
+    case *big.Rat:
+        return evaluador.NormalizarEntero(new(big.Int).Quo(v.Num(), v.Denom()))
     case string:
         n, err := strconv.Atoi(v)
         if err != nil {
+            if grande, ok := new(big.Int).SetString(v, 10); ok {
+                return grande
+            }
             return evaluador.NuevaErrorConversion("convertir_entero", ayudaConvertirEntero, v)
         }
         return n
@@ -1,7 +1,6 @@
 package conversiones
 
 import (
-    "time"
     "nepa/desarrollo/interno/evaluador"
 )
 
@@ -14,6 +13,8 @@ Convierte una cadena en formato de fecha a un objeto fecha.
 Formatos aceptados:
 - "AAAA-MM-DD" → fecha
 - "AAAA-MM-DD HH:MM:SS" → fecha y hora
+- "AAAA-MM-DDTHH:MM:SS-06:00" → fecha y hora con desfase (RFC 3339)
+- "AAAA-MM-DD HH:MM:SS America/Mexico_City" → fecha y hora en esa zona
 
 Ejemplo:
     convertir_fecha("2026-01-05") → 5 de enero de 2026
@@ -31,13 +32,7 @@ func fnConvertirFecha(args ...interface{}) interface{} {
         return evaluador.NuevaErrorConversion("convertir_fecha", ayudaConvertirFecha, args[0])
     }
 
-    // Intentar formato completo con hora
-    if t, err := time.Parse("2006-01-02 15:04:05", s); err == nil {
-        return t
-    }
-
-    // Intentar solo fecha
-    if t, err := time.Parse("2006-01-02", s); err == nil {
+    if t, err := evaluador.ParsearFecha(s); err == nil {
         return t
     }
 
@@ -1,6 +1,7 @@
 package conversiones
 
 import (
+    "math/big"
     "strconv"
     "nepa/desarrollo/interno/evaluador"
 )
@@ -14,6 +15,7 @@ Se espera:
 - cadena numérica ("3.14") → real 3.14
 - entero (123) → real 123.0
 - booleano (verdadero/falso) → 1.0/0.0
+- decimal (19.99) → real 19.99 (aproximado)
 - real (ya es real) → se devuelve tal cual
 
 Devuelve un número real (float64). Si el valor no puede convertirse, se muestra este mensaje de ayuda.
@@ -36,6 +38,18 @@ func fnConvertirReal(args ...interface{}) interface{} {
     case int64:
         return float64(v)
 
+    case evaluador.Decimal:
+        // Decimal exacto → real más cercano
+        return v.Float64()
+
+    case *big.Int:
+        f, _ := new(big.Float).SetInt(v).Float64()
+        return f
+
+    case *big.Rat:
+        f, _ := v.Float64()
+        return f
+
     case bool:
         if v {
             return 1.0
@@ -3,6 +3,7 @@ package expresion
 import (
     "fmt"
 
+    "nepa/desarrollo/comandos/avisos"
     "nepa/desarrollo/interno/evaluador"
     "nepa/desarrollo/interno/parser"
 )
@@ -10,6 +11,7 @@ import (
 // init registra el handler para nodos tipo "expresion"
 func init() {
     evaluador.Registrar("expresion", func(n parser.Nodo, ctx *evaluador.Contexto) {
+        defer avisos.Mostrar()
         switch v := n.Valor.(type) {
         case string:
             // Si es un literal de texto, imprimir directo
@@ -0,0 +1,82 @@
+package funcion
+
+import (
+    "errors"
+    "fmt"
+    "strings"
+
+    "nepa/desarrollo/interno/evaluador"
+    "nepa/desarrollo/interno/parser"
+)
+
+var ErrPanicoFuncion = errors.New("❌ ERROR FATAL: la función terminó con 'romper'")
+
+// init registra el handler para nodos tipo "funcion".
+// La función queda disponible en expresiones (f(3)) y como valor para
+// derivar(f, 1), integrar(f, 0, 1), mapear(lista, f)...
+func init() {
+    evaluador.Registrar("funcion", func(n parser.Nodo, ctx *evaluador.Contexto) {
+        nombre := strings.ToLower(strings.TrimSpace(n.Nombre))
+        if nombre == "" {
+            fmt.Println("⚠️ Función sin nombre")
+            return
+        }
+
+        // Valor = parámetros + cuerpo (ver parser.parseFuncion)
+        nodos, _ := n.Valor.([]parser.Nodo)
+        var parametros []string
+        var cuerpo []parser.Nodo
+        retorno := ""
+        for _, hijo := range nodos {
+            if hijo.Tipo == "parametro" {
+                parametros = append(parametros, strings.ToLower(hijo.Nombre))
+                continue
+            }
+            if retorno != "" {
+                break // lo que sigue a 'regresa' no se ejecuta
+            }
+            switch hijo.Tipo {
+            case "regresa":
+                retorno = fmt.Sprintf("%v", hijo.Valor)
+            case "regresa_valor":
+                retorno = hijo.Nombre
+            case "romper":
+                retorno = "romper"
+            default:
+                cuerpo = append(cuerpo, hijo)
+            }
+        }
+        if parametros == nil {
+            parametros = []string{}
+        }
+
+        llamar := func(args ...interface{}) (interface{}, error) {
+            // Los parámetros son locales a cada llamada
+            locales := make(map[string]interface{}, len(parametros))
+            for i, p := range parametros {
+                locales[p] = args[i]
+            }
+            if len(cuerpo) > 0 {
+                if _, err := evaluador.EjecutarConContexto(cuerpo, locales, ctx.Globales, ctx.Constantes, "funcion "+nombre); err != nil {
+                    return nil, err
+                }
+            }
+            switch retorno {
+            case "":
+                return 0, nil // sin 'regresa': 0 = éxito
+            case "romper":
+                return nil, ErrPanicoFuncion
+            }
+            local := &evaluador.Contexto{
+                Variables:  locales,
+                Globales:   ctx.Globales,
+                Constantes: ctx.Constantes,
+                Funciones:  ctx.Funciones,
+            }
+            return evaluador.EvalConContexto(retorno, local)
+        }
+
+        evaluador.Funciones[nombre] = evaluador.NuevaFuncion(nombre, parametros, llamar).Llamar
+        fmt.Printf("✔ FUNCION '%s(%s)' definida\n", nombre, strings.Join(parametros, ", "))
+    })
+}
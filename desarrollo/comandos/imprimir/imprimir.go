@@ -6,8 +6,11 @@ import (
 	"reflect"
 	"strings"
 
+	"nepa/desarrollo/comandos/avisos"
 	"nepa/desarrollo/interno/administrador" // Importante: acceso a las variables
 	"nepa/desarrollo/interno/evaluador"
+	"nepa/desarrollo/interno/parser"
+	"nepa/desarrollo/interno/valores"
 )
 
 var (
@@ -18,6 +21,12 @@ func imprimirValor(v interface{}) string {
 	if v == nil {
 		return "nulo"
 	}
+	// configurar_tabla(estilo, verdadero): matrices, registros y diccionarios como tabla
+	if evaluador.ConfigTabla.Automatico && evaluador.EsTabulable(v) {
+		if tabla, err := evaluador.FormatearTabla(v); err == nil {
+			return tabla
+		}
+	}
 
 	rv := reflect.ValueOf(v)
 	switch rv.Kind() {
@@ -37,6 +46,8 @@ func imprimirValor(v interface{}) string {
 	case reflect.Float32, reflect.Float64:
 		// Usamos %g para que no imprima ceros innecesarios (3.14 en vez de 3.140000)
 		return fmt.Sprintf("%g", rv.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return valores.FormatearComplejo(rv.Complex())
 	case reflect.Slice, reflect.Array:
 		var partes []string
 		for i := 0; i < rv.Len(); i++ {
@@ -53,9 +64,7 @@ func Ejecutar(linea string) error {
 
 	if strings.HasPrefix(strings.ToLower(linea), "imprimir") {
 		// Manejo de paréntesis: imprimir(edad) -> edad
-		linea = strings.TrimSpace(linea[len("imprimir"):])
-		linea = strings.TrimPrefix(linea, "(")
-		linea = strings.TrimSuffix(linea, ")")
+		linea = quitarParentesisExternos(strings.TrimSpace(linea[len("imprimir"):]))
 	}
 
 	if linea == "" || linea == `""` || linea == `''` {
@@ -63,7 +72,16 @@ func Ejecutar(linea string) error {
 		return nil
 	}
 
-	// 1. Caso: Literal entre comillas (texto puro)
+	// 1. Caso: Literal entre comillas (texto puro). Las comillas dobles con
+	// llaves pueden llevar interpolación: "Total: {total:.2f}"
+	if strings.HasPrefix(linea, "\"") && strings.HasSuffix(linea, "\"") && strings.Contains(linea, "{") {
+		texto, err := evaluador.Eval(linea)
+		if err != nil {
+			return err
+		}
+		fmt.Println(imprimirValor(texto))
+		return nil
+	}
 	if (strings.HasPrefix(linea, "\"") && strings.HasSuffix(linea, "\"")) ||
 		(strings.HasPrefix(linea, "'") && strings.HasSuffix(linea, "'")) {
 		fmt.Println(strings.Trim(linea, "\"'"))
@@ -76,9 +94,13 @@ func Ejecutar(linea string) error {
 		return nil
 	}
 
-	// 3. Caso: Evaluar como expresión (matemática, etc.)
+	// 3. Caso: Evaluar como expresión (matemática, etc.), con pipes incluidos:
+	// imprimir datos | ordenar() | primeros(5)
 	resultado, err := evaluador.Eval(linea)
 	if err != nil {
+		if strings.Contains(linea, "|") {
+			return err
+		}
 		// Si no es variable ni expresión válida, imprimimos el texto original
 		fmt.Println(linea)
 		return nil
@@ -88,7 +110,64 @@ func Ejecutar(linea string) error {
 	return nil
 }
 
+// quitarParentesisExternos convierte "(x)" en "x" solo si ambos paréntesis se
+// corresponden: "(a) | f(b)" queda igual.
+func quitarParentesisExternos(s string) string {
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return s
+	}
+	nivel := 0
+	comilla := rune(0)
+	for i, r := range s {
+		switch {
+		case comilla != 0:
+			if r == comilla {
+				comilla = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			comilla = r
+		case r == '(':
+			nivel++
+		case r == ')':
+			nivel--
+			if nivel == 0 && i < len(s)-1 {
+				return s
+			}
+		}
+	}
+	return strings.TrimSpace(s[1 : len(s)-1])
+}
+
+// ejecutarNodo imprime un nodo del parser. Con pipes, la expresión completa
+// se evalúa de una vez (cada etapa recibe el valor anterior como primer
+// argumento); sin ellos, cada argumento se resuelve por separado.
+func ejecutarNodo(n parser.Nodo, ctx *evaluador.Contexto) error {
+	var valores []interface{}
+	if _, hayPipes := n.Extra["etapas"]; hayPipes {
+		expresion, _ := n.Extra["expresion"].(string)
+		v, err := evaluador.EvalConContexto(expresion, ctx)
+		if err != nil {
+			return err
+		}
+		valores = append(valores, v)
+	} else {
+		textos, _ := n.Extra["argumentos"].([]interface{})
+		for _, t := range textos {
+			valores = append(valores, evaluador.ResolverEstructuraRecursiva(t, ctx))
+		}
+	}
+	_, err := evaluador.Funciones["imprimir"](valores...)
+	return err
+}
+
 func init() {
+	evaluador.Registrar("imprimir", func(n parser.Nodo, ctx *evaluador.Contexto) {
+		defer avisos.Mostrar()
+		if err := ejecutarNodo(n, ctx); err != nil {
+			fmt.Printf("⚠️ Error en imprimir: %v\n", err)
+		}
+	})
+
 	evaluador.Funciones["imprimir"] = func(args ...interface{}) (interface{}, error) {
 		for i, arg := range args {
 			fmt.Print(imprimirValor(arg))
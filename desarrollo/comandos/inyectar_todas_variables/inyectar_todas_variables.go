@@ -2,6 +2,7 @@ package inyectar_todas_variables
 
 import (
 	"nepa/desarrollo/interno/administrador"
+	"nepa/desarrollo/interno/variables/archivo"
 	"nepa/desarrollo/interno/variables/bit"
 	"nepa/desarrollo/interno/variables/booleano"
 	"nepa/desarrollo/interno/variables/cadena"
@@ -9,20 +10,26 @@ import (
 	"nepa/desarrollo/interno/variables/complejo"
 	"nepa/desarrollo/interno/variables/decimal"
 	"nepa/desarrollo/interno/variables/diccionario"
+	"nepa/desarrollo/interno/variables/duracion"
 	"nepa/desarrollo/interno/variables/entero"
 	"nepa/desarrollo/interno/variables/fecha"
+	"nepa/desarrollo/interno/variables/funcion"
 	"nepa/desarrollo/interno/variables/hora"
 	"nepa/desarrollo/interno/variables/lista"
 	"nepa/desarrollo/interno/variables/matriz"
 	"nepa/desarrollo/interno/variables/objeto"
+	"nepa/desarrollo/interno/variables/polinomio"
 	"nepa/desarrollo/interno/variables/puntero"
+	"nepa/desarrollo/interno/variables/racional"
 	"nepa/desarrollo/interno/variables/real"
+	"nepa/desarrollo/interno/variables/simbolico"
 	"nepa/desarrollo/interno/variables/texto"
 	"nepa/desarrollo/interno/variables/tiempo"
 )
 
 func init() {
 	administrador.RegistrarConstructores(map[string]func(string, interface{}) (administrador.Variable, error){
+		"archivo":     archivo.CrearArchivo,
 		"bit":         bit.CrearBit,
 		"booleano":    booleano.CrearBooleano,
 		"cadena":      cadena.CrearCadena,
@@ -30,14 +37,19 @@ func init() {
 		"complejo":    complejo.CrearComplejo,
 		"decimal":     decimal.CrearDecimal,
 		"diccionario": diccionario.CrearDiccionario,
+		"duracion":    duracion.CrearDuracion,
 		"entero":      entero.CrearEntero,
 		"fecha":       fecha.CrearFecha,
+		"funcion":     funcion.CrearFuncion,
 		"hora":        hora.CrearHora,
 		"lista":       lista.CrearLista,
 		"matriz":      matriz.CrearMatriz,
 		"objeto":      objeto.CrearObjeto,
+		"polinomio":   polinomio.CrearPolinomio,
 		"puntero":     puntero.CrearPuntero,
+		"racional":    racional.CrearRacional,
 		"real":        real.CrearReal,
+		"simbolico":   simbolico.CrearSimbolico,
 		"texto":       texto.CrearTexto,
 		"tiempo":      tiempo.CrearTiempo,
 	})
@@ -3,6 +3,7 @@ package llamada
 import (
     "fmt"
 
+    "nepa/desarrollo/comandos/avisos"
     "nepa/desarrollo/interno/evaluador"
     "nepa/desarrollo/interno/parser"
 )
@@ -10,6 +11,7 @@ import (
 // init registra el handler para nodos tipo "llamada"
 func init() {
     evaluador.Registrar("llamada", func(n parser.Nodo, ctx *evaluador.Contexto) {
+        defer avisos.Mostrar()
         // Buscar la función en el contexto
         fn, ok := ctx.Funciones[n.Nombre]
         if !ok {
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	"nepa/desarrollo/comandos/avisos"
 	"nepa/desarrollo/interno/administrador"
 	"nepa/desarrollo/interno/bloque"
 	"nepa/desarrollo/interno/evaluador"
@@ -27,6 +28,7 @@ func esNombreValido(nombre string) bool {
 
 func init() {
 	evaluador.Registrar("variable", func(n parser.Nodo, ctx *evaluador.Contexto) {
+		defer avisos.Mostrar()
 		// 1. Obtener tipo desde Args
 		var tipo string
 		if len(n.Args) > 0 {
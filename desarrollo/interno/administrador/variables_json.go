@@ -0,0 +1,46 @@
+package administrador
+
+import (
+    "bytes"
+    "encoding/json"
+    "strings"
+)
+
+// CodificadorJSON escribe el valor de una variable en JSON. El evaluador
+// registra el de json_escribir para que decimales, racionales, cantidades,
+// etc. tengan la misma forma en todas partes; mientras no haya uno
+// registrado se usa encoding/json.
+var CodificadorJSON func(valor interface{}) (string, error)
+
+// RegistrarCodificadorJSON cambia el codificador que usa JSONVariable.
+func RegistrarCodificadorJSON(f func(valor interface{}) (string, error)) {
+    CodificadorJSON = f
+}
+
+// JSONVariable es la forma común de Variable.JSON() para todos los tipos:
+// {"tipo": ..., "nombre": ..., "valor": ...}. Si el valor no tiene
+// representación en JSON se escribe null.
+func JSONVariable(tipo, nombre string, valor interface{}) string {
+    codificar := CodificadorJSON
+    if codificar == nil {
+        codificar = codificarEstandar
+    }
+    texto, err := codificar(valor)
+    if err != nil {
+        texto = "null"
+    }
+    tipoJSON, _ := codificarEstandar(tipo)
+    nombreJSON, _ := codificarEstandar(nombre)
+    return `{"tipo":` + tipoJSON + `,"nombre":` + nombreJSON + `,"valor":` + texto + `}`
+}
+
+// codificarEstandar usa encoding/json sin escapar <, > ni &.
+func codificarEstandar(valor interface{}) (string, error) {
+    var b bytes.Buffer
+    enc := json.NewEncoder(&b)
+    enc.SetEscapeHTML(false)
+    if err := enc.Encode(valor); err != nil {
+        return "", err
+    }
+    return strings.TrimSuffix(b.String(), "\n"), nil
+}
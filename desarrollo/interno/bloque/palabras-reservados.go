@@ -31,10 +31,10 @@ var PalabrasReservadas = []string{
     "bit_desplazar_izq", "bit_desplazar_der",
 
     // --- Tipos de variables ---
-    "entero", "decimal", "binario", "cadena",
+    "entero", "decimal", "racional", "binario", "cadena",
     "booleano", "lista", "mapa", "objeto",
 
-    // --- Manejo de cadenas (futuro) ---
+    // --- Manejo de cadenas ---
     "sin_espacio", "espacios_izquierda", "espacios_derecha",
     "mayusculas", "minusculas", "longitud",
     "subcadena", "reemplazar", "dividir", "unir",
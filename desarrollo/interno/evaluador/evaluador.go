@@ -32,19 +32,21 @@ func EvalConContexto(expr string, ctx *Contexto) (interface{}, error) {
         return nil, ErrExpresionInvalida
     }
 
-    node, err := parser.ParseExpr(expr)
+    node, err := parser.ParseExpr(prepararExpresion(expr))
     if err != nil {
         return nil, fmt.Errorf("%w: %v", ErrExpresionInvalida, err)
     }
 
-    return evaluarNodo(node, ctx)
+    // Ajustamos la prioridad de '^' (potencia) a las reglas de Nepa
+    return evaluarNodo(reasociar(node), ctx)
 }
 
 // evaluarNodo es el despachador interno que ya conoce el contexto.
 func evaluarNodo(node ast.Expr, ctx *Contexto) (interface{}, error) {
     switch n := node.(type) {
     case *ast.BasicLit:
-        return evaluarLiteral(n)
+        // "Total: {total}" se interpola con las variables del contexto
+        return evaluarLiteralInterpolado(n, ctx)
     case *ast.Ident:
         return evaluarIdentificador(n, ctx)
     case *ast.UnaryExpr:
@@ -53,6 +55,13 @@ func evaluarNodo(node ast.Expr, ctx *Contexto) (interface{}, error) {
         return evaluarBinario(n, ctx)
     case *ast.CallExpr:
         return evaluarLlamada(n, ctx)
+    case *ast.CompositeLit:
+        // Diccionario literal: {"x": 2}
+        return evaluarDiccionario(n, ctx)
+    case *ast.IndexExpr:
+        return evaluarIndice(n, ctx)
+    case *ast.SliceExpr:
+        return evaluarRebanada(n, ctx)
     case *ast.ParenExpr:
         // Soporte crítico para la expresión de la presa: ( ... )
         return evaluarNodo(n.X, ctx)
@@ -0,0 +1,132 @@
+package evaluador
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Aleatorio es el generador de números aleatorios de un programa. Con una
+// semilla fija (semilla(n) o --semilla n) la secuencia es reproducible; la
+// fuente segura usa crypto/rand y no admite semilla.
+//
+// Hay un solo generador activo por proceso (ver GeneradorAleatorio): las
+// funciones registradas no reciben el Contexto, así que no se guarda en él.
+// IniciarAleatorio lo reemplaza al empezar cada programa.
+type Aleatorio struct {
+	*rand.Rand
+	Seguro bool
+
+	normal *rand.Rand // la fuente con semilla mientras se usa la segura
+	fallo  error      // primer fallo de crypto/rand desde la última consulta
+}
+
+// fuenteSegura adapta crypto/rand a rand.Source64. rand.Source no puede
+// devolver errores: si crypto/rand falla entrega 0 y guarda el error para
+// que la función que pidió el número lo informe (ver Fallo).
+type fuenteSegura struct {
+	fallo *error
+}
+
+func (fuenteSegura) Seed(int64) {}
+
+func (f fuenteSegura) Uint64() uint64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		if *f.fallo == nil {
+			*f.fallo = err
+		}
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+func (f fuenteSegura) Int63() int64 {
+	return int64(f.Uint64() >> 1)
+}
+
+var (
+	semillaInicial  *int64
+	aleatorioActivo *Aleatorio
+	aleatorioMutex  sync.Mutex
+)
+
+// NuevoAleatorio crea un generador con la semilla indicada por --semilla o,
+// si no la hay, con una semilla basada en la hora.
+func NuevoAleatorio() *Aleatorio {
+	semilla := time.Now().UnixNano()
+	if semillaInicial != nil {
+		semilla = *semillaInicial
+	}
+	return &Aleatorio{Rand: rand.New(rand.NewSource(semilla))}
+}
+
+// EstablecerSemillaInicial fija la semilla de los programas que se ejecuten
+// a partir de ahora (opción --semilla de la línea de comandos).
+func EstablecerSemillaInicial(semilla int64) {
+	aleatorioMutex.Lock()
+	defer aleatorioMutex.Unlock()
+	semillaInicial = &semilla
+	aleatorioActivo = nil
+}
+
+// Sembrar reinicia el generador con una semilla fija.
+func (a *Aleatorio) Sembrar(semilla int64) {
+	a.Rand = rand.New(rand.NewSource(semilla))
+	a.Seguro = false
+	a.normal = nil
+}
+
+// UsarFuenteSegura cambia a crypto/rand o vuelve a la fuente normal, que
+// sigue su secuencia donde la dejó.
+func (a *Aleatorio) UsarFuenteSegura(seguro bool) error {
+	if seguro == a.Seguro {
+		return nil
+	}
+	if seguro {
+		var b [1]byte
+		if _, err := crand.Read(b[:]); err != nil {
+			return fmt.Errorf("❌ ERROR FATAL: la fuente aleatoria segura no está disponible: %v", err)
+		}
+		a.normal, a.Rand = a.Rand, rand.New(fuenteSegura{fallo: &a.fallo})
+	} else {
+		a.Rand, a.normal = a.normal, nil
+	}
+	a.Seguro = seguro
+	return nil
+}
+
+// Fallo devuelve el error de la fuente segura ocurrido desde la última
+// consulta (y lo olvida); con la fuente normal siempre es nil.
+func (a *Aleatorio) Fallo() error {
+	err := a.fallo
+	a.fallo = nil
+	if err != nil {
+		return fmt.Errorf("❌ ERROR FATAL: la fuente aleatoria segura no está disponible: %v", err)
+	}
+	return nil
+}
+
+// GeneradorAleatorio devuelve el generador del programa en curso, que
+// comparten todos sus bloques, funciones y lambdas.
+func GeneradorAleatorio() *Aleatorio {
+	aleatorioMutex.Lock()
+	defer aleatorioMutex.Unlock()
+	if aleatorioActivo == nil {
+		aleatorioActivo = NuevoAleatorio()
+	}
+	return aleatorioActivo
+}
+
+// IniciarAleatorio instala un generador nuevo para el programa que empieza
+// (con la semilla de --semilla si se dio). Los bloques y funciones que se
+// ejecutan dentro del programa heredan ese mismo generador.
+func IniciarAleatorio() *Aleatorio {
+	aleatorioMutex.Lock()
+	defer aleatorioMutex.Unlock()
+	aleatorioActivo = NuevoAleatorio()
+	return aleatorioActivo
+}
@@ -0,0 +1,77 @@
+package evaluador
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Los bloques y las funciones de usuario ejecutan su cuerpo con
+// EjecutarConContexto: no deben reiniciar el generador del programa.
+func TestAleatorioSobreviveAEjecucionesAnidadas(t *testing.T) {
+	gen := IniciarAleatorio()
+	gen.Sembrar(42)
+	esperado := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 5; i++ {
+		if _, err := EjecutarConContexto(nil, nil, map[string]interface{}{}, map[string]interface{}{}, "prueba"); err != nil {
+			t.Fatal(err)
+		}
+		if GeneradorAleatorio() != gen {
+			t.Fatalf("paso %d: la ejecución anidada cambió el generador", i)
+		}
+		if got, want := GeneradorAleatorio().Float64(), esperado.Float64(); got != want {
+			t.Fatalf("paso %d: %v, se esperaba %v (la semilla se perdió)", i, got, want)
+		}
+	}
+}
+
+func TestSemillaInicialNoSeRepiteEnCadaInstruccion(t *testing.T) {
+	EstablecerSemillaInicial(7)
+	defer func() {
+		aleatorioMutex.Lock()
+		semillaInicial, aleatorioActivo = nil, nil
+		aleatorioMutex.Unlock()
+	}()
+
+	IniciarAleatorio()
+	esperado := rand.New(rand.NewSource(7))
+	for i := 0; i < 3; i++ {
+		if _, err := EjecutarConContexto(nil, nil, map[string]interface{}{}, map[string]interface{}{}, "prueba"); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := GeneradorAleatorio().Intn(1_000_000), esperado.Intn(1_000_000); got != want {
+			t.Fatalf("paso %d: %d, se esperaba %d", i, got, want)
+		}
+	}
+
+	// Un programa nuevo vuelve a empezar la secuencia
+	IniciarAleatorio()
+	if got, want := GeneradorAleatorio().Intn(1_000_000), rand.New(rand.NewSource(7)).Intn(1_000_000); got != want {
+		t.Errorf("un programa nuevo con --semilla 7 empezó con %d, se esperaba %d", got, want)
+	}
+}
+
+func TestFuenteSeguraConservaLaSecuencia(t *testing.T) {
+	gen := IniciarAleatorio()
+	gen.Sembrar(3)
+	esperado := rand.New(rand.NewSource(3))
+
+	if got, want := gen.Int63(), esperado.Int63(); got != want {
+		t.Fatalf("%d, se esperaba %d", got, want)
+	}
+	if err := gen.UsarFuenteSegura(true); err != nil {
+		t.Skipf("crypto/rand no disponible: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		gen.Int63()
+	}
+	if err := gen.Fallo(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.UsarFuenteSegura(false); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := gen.Int63(), esperado.Int63(); got != want {
+		t.Errorf("al salir de la fuente segura: %d, se esperaba %d (la secuencia se reinició)", got, want)
+	}
+}
@@ -0,0 +1,505 @@
+package evaluador
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// Archivos y directorios:
+//   texto = leer_archivo("datos.txt")
+//   escribir_archivo("salida.txt", "hola\n")      agregar_archivo("log.txt", linea)
+//   lineas = leer_lineas("datos.txt")             existe_archivo("datos.txt")
+//   listar_directorio("."), crear_directorio("a/b"), borrar_archivo("tmp.txt")
+//   copiar("a.txt", "b.txt"), mover("b.txt", "respaldo/")
+//
+// Lectura y escritura por partes con un archivo abierto:
+//   usar f = abrir("datos.txt"):
+//       linea = f.leer_linea()        → nulo al llegar al final
+//   usar g = abrir("salida.txt", "escribir"):
+//       g.escribir("total: ", total, "\n")
+// Al terminar el bloque usar el archivo se cierra solo; fuera de un bloque
+// se cierra con cerrar(f).
+
+// Cerrable es un recurso que se libera al salir de un bloque usar.
+type Cerrable interface {
+	Cerrar() error
+}
+
+// Archivo es un archivo abierto para leer, escribir o agregar.
+type Archivo struct {
+	mu       sync.Mutex
+	ruta     string
+	modo     string
+	f        *os.File
+	lector   *bufio.Reader
+	escritor *bufio.Writer
+	cerrado  bool
+}
+
+// modosArchivo traduce los modos de abrir() a las banderas de os.OpenFile.
+var modosArchivo = map[string]int{
+	"leer":     os.O_RDONLY,
+	"escribir": os.O_WRONLY | os.O_CREATE | os.O_TRUNC,
+	"agregar":  os.O_WRONLY | os.O_CREATE | os.O_APPEND,
+}
+
+// AbrirArchivo abre la ruta en modo "leer", "escribir" o "agregar".
+func AbrirArchivo(ruta, modo string) (*Archivo, error) {
+	return abrirArchivo("abrir", ruta, modo)
+}
+
+// abrirArchivo es AbrirArchivo con el nombre de la función que lo pide, para
+// los mensajes de error.
+func abrirArchivo(nombre, ruta, modo string) (*Archivo, error) {
+	modo = strings.ToLower(strings.TrimSpace(modo))
+	banderas, ok := modosArchivo[modo]
+	if !ok {
+		return nil, fmt.Errorf("❌ ERROR FATAL: modo de archivo desconocido '%s' en '%s' (use leer, escribir o agregar)", modo, nombre)
+	}
+	f, err := os.OpenFile(ruta, banderas, 0o644)
+	if err != nil {
+		return nil, errorArchivo(nombre, ruta, err)
+	}
+	a := &Archivo{ruta: ruta, modo: modo, f: f}
+	if modo == "leer" {
+		a.lector = bufio.NewReader(f)
+	} else {
+		a.escritor = bufio.NewWriter(f)
+	}
+	return a, nil
+}
+
+func (a *Archivo) Ruta() string { return a.ruta }
+func (a *Archivo) Modo() string { return a.modo }
+
+func (a *Archivo) String() string {
+	estado := ""
+	if a.Cerrado() {
+		estado = ", cerrado"
+	}
+	return fmt.Sprintf("archivo(%s, %s%s)", a.ruta, a.modo, estado)
+}
+
+// Cerrado indica si ya se llamó a Cerrar.
+func (a *Archivo) Cerrado() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.cerrado
+}
+
+// LeerLinea devuelve la siguiente línea sin el salto; ok es falso al final.
+func (a *Archivo) LeerLinea() (string, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.disponible("leer_linea", "leer"); err != nil {
+		return "", false, err
+	}
+	linea, err := a.lector.ReadString('\n')
+	if err == io.EOF {
+		if linea == "" {
+			return "", false, nil
+		}
+	} else if err != nil {
+		return "", false, errorArchivo("leer_linea", a.ruta, err)
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(linea, "\n"), "\r"), true, nil
+}
+
+// Escribir agrega el texto al archivo (sin salto de línea).
+func (a *Archivo) Escribir(texto string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.disponible("escribir", "escribir o agregar"); err != nil {
+		return err
+	}
+	if _, err := a.escritor.WriteString(texto); err != nil {
+		return errorArchivo("escribir", a.ruta, err)
+	}
+	return nil
+}
+
+// Cerrar vacía lo pendiente y cierra el archivo; cerrar dos veces no es error.
+func (a *Archivo) Cerrar() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.cerrado {
+		return nil
+	}
+	a.cerrado = true
+	var errEscritura error
+	if a.escritor != nil {
+		errEscritura = a.escritor.Flush()
+	}
+	if err := a.f.Close(); err != nil && errEscritura == nil {
+		errEscritura = err
+	}
+	if errEscritura != nil {
+		return errorArchivo("cerrar", a.ruta, errEscritura)
+	}
+	return nil
+}
+
+// disponible valida que el archivo siga abierto y en el modo correcto.
+func (a *Archivo) disponible(operacion, modos string) error {
+	if a.cerrado {
+		return fmt.Errorf("❌ ERROR FATAL: '%s' sobre un archivo cerrado (%s)", operacion, a.ruta)
+	}
+	if (operacion == "leer_linea") != (a.lector != nil) {
+		return fmt.Errorf("❌ ERROR FATAL: '%s' requiere un archivo abierto para %s, '%s' está abierto para %s", operacion, modos, a.ruta, a.modo)
+	}
+	return nil
+}
+
+// Usar ejecuta el cuerpo de un bloque usar y libera el recurso al salir,
+// aunque el cuerpo falle.
+func Usar(recurso interface{}, cuerpo func() error) (err error) {
+	if c, ok := recurso.(Cerrable); ok {
+		defer func() {
+			if errCerrar := c.Cerrar(); errCerrar != nil && err == nil {
+				err = errCerrar
+			}
+		}()
+	}
+	return cuerpo()
+}
+
+// errorArchivo traduce los errores más comunes del sistema de archivos.
+func errorArchivo(nombre, ruta string, err error) error {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("❌ ERROR FATAL: '%s' no encontró '%s'", nombre, ruta)
+	case errors.Is(err, os.ErrPermission):
+		return fmt.Errorf("❌ ERROR FATAL: '%s' no tiene permiso sobre '%s'", nombre, ruta)
+	case errors.Is(err, syscall.ENOTEMPTY) || esDirectorioConContenido(ruta) && errors.Is(err, os.ErrExist):
+		// ENOTEMPTY también cuenta como os.ErrExist: se revisa antes
+		return fmt.Errorf("❌ ERROR FATAL: '%s': el directorio '%s' no está vacío", nombre, ruta)
+	case errors.Is(err, os.ErrExist):
+		return fmt.Errorf("❌ ERROR FATAL: '%s': '%s' ya existe", nombre, ruta)
+	}
+	return fmt.Errorf("❌ ERROR FATAL: '%s' falló con '%s': %v", nombre, ruta, err)
+}
+
+// esDirectorioConContenido indica si la ruta es un directorio con al menos
+// una entrada.
+func esDirectorioConContenido(ruta string) bool {
+	d, err := os.Open(ruta)
+	if err != nil {
+		return false
+	}
+	defer d.Close()
+	entradas, err := d.Readdirnames(1)
+	return err == nil && len(entradas) > 0
+}
+
+// argumentosRuta valida (ruta, extras...) y devuelve la ruta.
+func argumentosRuta(nombre string, args []interface{}, minimo, maximo int) (string, error) {
+	if len(args) < minimo || len(args) > maximo {
+		if minimo == maximo {
+			return "", fmt.Errorf("❌ ERROR FATAL: '%s' requiere %d argumento(s)", nombre, minimo)
+		}
+		return "", fmt.Errorf("❌ ERROR FATAL: '%s' requiere entre %d y %d argumentos", nombre, minimo, maximo)
+	}
+	if len(args) == 0 {
+		return ".", nil
+	}
+	return comoCadena(nombre, args[0])
+}
+
+// comoArchivo valida que el argumento sea un archivo abierto con abrir().
+func comoArchivo(nombre string, args []interface{}) (*Archivo, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("❌ ERROR FATAL: '%s' requiere un archivo abierto con abrir()", nombre)
+	}
+	a, ok := args[0].(*Archivo)
+	if !ok {
+		return nil, fmt.Errorf("❌ ERROR FATAL: '%s' requiere un archivo abierto con abrir(), recibió %s", nombre, obtenerTipoEnEspañol(args[0]))
+	}
+	return a, nil
+}
+
+// textoParaArchivo escribe los textos tal cual y el resto como en imprimir.
+func textoParaArchivo(valores []interface{}) string {
+	var b strings.Builder
+	for _, v := range valores {
+		if s, err := comoCadena("", v); err == nil {
+			b.WriteString(s)
+		} else {
+			b.WriteString(FormatearValor(v))
+		}
+	}
+	return b.String()
+}
+
+func escribirArchivo(nombre string, args []interface{}, banderas int) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("❌ ERROR FATAL: '%s' requiere una ruta y el contenido", nombre)
+	}
+	ruta, err := comoCadena(nombre, args[0])
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(ruta, banderas, 0o644)
+	if err != nil {
+		return nil, errorArchivo(nombre, ruta, err)
+	}
+	_, err = f.WriteString(textoParaArchivo(args[1:]))
+	if errCerrar := f.Close(); err == nil {
+		err = errCerrar
+	}
+	if err != nil {
+		return nil, errorArchivo(nombre, ruta, err)
+	}
+	return true, nil
+}
+
+// copiarArchivo copia el contenido y los permisos; si el destino es un
+// directorio, el archivo conserva su nombre dentro de él.
+func copiarArchivo(nombre, origen, destino string) (string, error) {
+	info, err := os.Stat(origen)
+	if err != nil {
+		return "", errorArchivo(nombre, origen, err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("❌ ERROR FATAL: '%s' solo copia archivos, '%s' es un directorio", nombre, origen)
+	}
+	if d, err := os.Stat(destino); err == nil && d.IsDir() {
+		destino = filepath.Join(destino, filepath.Base(origen))
+	}
+	entrada, err := os.Open(origen)
+	if err != nil {
+		return "", errorArchivo(nombre, origen, err)
+	}
+	defer entrada.Close()
+	salida, err := os.OpenFile(destino, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return "", errorArchivo(nombre, destino, err)
+	}
+	_, err = io.Copy(salida, entrada)
+	if errCerrar := salida.Close(); err == nil {
+		err = errCerrar
+	}
+	if err != nil {
+		return "", errorArchivo(nombre, destino, err)
+	}
+	return destino, nil
+}
+
+func init() {
+	// leer_archivo(ruta) -> todo el contenido como texto
+	Funciones["leer_archivo"] = func(args ...interface{}) (interface{}, error) {
+		ruta, err := argumentosRuta("leer_archivo", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		datos, err := os.ReadFile(ruta)
+		if err != nil {
+			return nil, errorArchivo("leer_archivo", ruta, err)
+		}
+		return string(datos), nil
+	}
+
+	// escribir_archivo(ruta, contenido...) -> crea o reemplaza el archivo
+	Funciones["escribir_archivo"] = func(args ...interface{}) (interface{}, error) {
+		return escribirArchivo("escribir_archivo", args, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	}
+
+	// agregar_archivo(ruta, contenido...) -> escribe al final (crea el archivo si no existe)
+	Funciones["agregar_archivo"] = func(args ...interface{}) (interface{}, error) {
+		return escribirArchivo("agregar_archivo", args, os.O_WRONLY|os.O_CREATE|os.O_APPEND)
+	}
+
+	// leer_lineas(ruta) -> lista de líneas sin el salto (acepta \n y \r\n)
+	Funciones["leer_lineas"] = func(args ...interface{}) (interface{}, error) {
+		ruta, err := argumentosRuta("leer_lineas", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		a, err := abrirArchivo("leer_lineas", ruta, "leer")
+		if err != nil {
+			return nil, err
+		}
+		lineas := []interface{}{}
+		err = Usar(a, func() error {
+			for {
+				linea, ok, err := a.LeerLinea()
+				if err != nil || !ok {
+					return err
+				}
+				lineas = append(lineas, linea)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		return lineas, nil
+	}
+
+	// existe_archivo(ruta) -> verdadero si existe el archivo o directorio
+	Funciones["existe_archivo"] = func(args ...interface{}) (interface{}, error) {
+		ruta, err := argumentosRuta("existe_archivo", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		_, err = os.Stat(ruta)
+		return err == nil, nil
+	}
+
+	// listar_directorio([ruta]) -> nombres ordenados; los directorios terminan en "/"
+	Funciones["listar_directorio"] = func(args ...interface{}) (interface{}, error) {
+		ruta, err := argumentosRuta("listar_directorio", args, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		entradas, err := os.ReadDir(ruta)
+		if err != nil {
+			return nil, errorArchivo("listar_directorio", ruta, err)
+		}
+		nombres := make([]interface{}, len(entradas))
+		for i, e := range entradas {
+			nombre := e.Name()
+			if e.IsDir() {
+				nombre += "/"
+			}
+			nombres[i] = nombre
+		}
+		return nombres, nil
+	}
+
+	// crear_directorio(ruta) -> crea también los directorios intermedios
+	Funciones["crear_directorio"] = func(args ...interface{}) (interface{}, error) {
+		ruta, err := argumentosRuta("crear_directorio", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(ruta, 0o755); err != nil {
+			return nil, errorArchivo("crear_directorio", ruta, err)
+		}
+		return true, nil
+	}
+
+	// borrar_archivo(ruta) -> borra un archivo o un directorio vacío
+	Funciones["borrar_archivo"] = func(args ...interface{}) (interface{}, error) {
+		ruta, err := argumentosRuta("borrar_archivo", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Remove(ruta); err != nil {
+			return nil, errorArchivo("borrar_archivo", ruta, err)
+		}
+		return true, nil
+	}
+
+	// copiar(origen, destino) -> ruta del archivo copiado
+	Funciones["copiar"] = func(args ...interface{}) (interface{}, error) {
+		origen, err := argumentosRuta("copiar", args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		destino, err := comoCadena("copiar", args[1])
+		if err != nil {
+			return nil, err
+		}
+		return copiarArchivo("copiar", origen, destino)
+	}
+
+	// mover(origen, destino) -> ruta nueva; entre discos distintos copia y borra
+	Funciones["mover"] = func(args ...interface{}) (interface{}, error) {
+		origen, err := argumentosRuta("mover", args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		destino, err := comoCadena("mover", args[1])
+		if err != nil {
+			return nil, err
+		}
+		if d, err := os.Stat(destino); err == nil && d.IsDir() {
+			destino = filepath.Join(destino, filepath.Base(origen))
+		}
+		errRenombrar := os.Rename(origen, destino)
+		if errRenombrar == nil {
+			return destino, nil
+		}
+		var errEnlace *os.LinkError
+		if !errors.As(errRenombrar, &errEnlace) || errors.Is(errRenombrar, os.ErrNotExist) {
+			return nil, errorArchivo("mover", origen, errRenombrar)
+		}
+		if _, err := copiarArchivo("mover", origen, destino); err != nil {
+			return nil, err
+		}
+		if err := os.Remove(origen); err != nil {
+			return nil, errorArchivo("mover", origen, err)
+		}
+		return destino, nil
+	}
+
+	// abrir(ruta, [modo]) -> archivo abierto; modo "leer" (por defecto), "escribir" o "agregar"
+	Funciones["abrir"] = func(args ...interface{}) (interface{}, error) {
+		ruta, err := argumentosRuta("abrir", args, 1, 2)
+		if err != nil {
+			return nil, err
+		}
+		modo := "leer"
+		if len(args) == 2 {
+			if modo, err = comoCadena("abrir", args[1]); err != nil {
+				return nil, err
+			}
+		}
+		a, err := AbrirArchivo(ruta, modo)
+		if err != nil {
+			return nil, err
+		}
+		return a, nil
+	}
+
+	// leer_linea(archivo) -> la siguiente línea, o nulo al llegar al final
+	Funciones["leer_linea"] = func(args ...interface{}) (interface{}, error) {
+		a, err := comoArchivo("leer_linea", args)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) != 1 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'leer_linea' requiere solo el archivo")
+		}
+		linea, ok, err := a.LeerLinea()
+		if err != nil || !ok {
+			return nil, err
+		}
+		return linea, nil
+	}
+
+	// escribir(archivo, valores...) -> escribe los valores seguidos, sin salto de línea
+	Funciones["escribir"] = func(args ...interface{}) (interface{}, error) {
+		a, err := comoArchivo("escribir", args)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.Escribir(textoParaArchivo(args[1:])); err != nil {
+			return nil, err
+		}
+		return true, nil
+	}
+
+	// cerrar(archivo) -> guarda lo pendiente y libera el archivo
+	Funciones["cerrar"] = func(args ...interface{}) (interface{}, error) {
+		a, err := comoArchivo("cerrar", args)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.Cerrar(); err != nil {
+			return nil, err
+		}
+		return true, nil
+	}
+
+	// Métodos: f.leer_linea(), f.escribir("x"), f.cerrar()
+	for _, nombre := range []string{"leer_linea", "escribir", "cerrar"} {
+		Funciones["archivo."+nombre] = Funciones[nombre]
+	}
+}
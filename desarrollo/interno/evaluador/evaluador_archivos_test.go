@@ -0,0 +1,136 @@
+package evaluador
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFuncionesDeArchivos(t *testing.T) {
+	dir := t.TempDir()
+	ctx := PrepararContextoEvaluador()
+	ctx.Variables["a"] = filepath.Join(dir, "a.txt")
+	ctx.Variables["sub"] = filepath.Join(dir, "sub")
+	ctx.Variables["b"] = filepath.Join(dir, "sub", "x", "b.txt")
+	ctx.Variables["dir"] = dir
+
+	casos := []struct {
+		expresion string
+		esperado  string
+	}{
+		{`escribir_archivo(a, "uno\ndos\r\ntres")`, "verdadero"},
+		{`agregar_archivo(a, "\ncuatro ", 5)`, "verdadero"},
+		{"leer_archivo(a)", "uno\ndos\r\ntres\ncuatro 5"},
+		// leer_lineas acepta fin de línea de Windows
+		{"leer_lineas(a)", "[uno, dos, tres, cuatro 5]"},
+		{"existe_archivo(a)", "verdadero"},
+		{`existe_archivo(dir + "/nada.txt")`, "falso"},
+		{`crear_directorio(sub + "/x")`, "verdadero"},
+		{"copiar(a, sub) == sub + \"/a.txt\"", "verdadero"},
+		{`mover(sub + "/a.txt", b) == b`, "verdadero"},
+		{"listar_directorio(dir)", "[a.txt, sub/]"},
+		{`listar_directorio(sub + "/x")`, "[b.txt]"},
+		{"borrar_archivo(b)", "verdadero"},
+	}
+	for _, c := range casos {
+		r, err := EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if s := FormatearValor(r); s != c.esperado {
+			t.Errorf("%s = %q, se esperaba %q", c.expresion, s, c.esperado)
+		}
+	}
+
+	for expresion, mensaje := range map[string]string{
+		`leer_archivo(dir + "/nada.txt")`: "no encontró",
+		`abrir(a, "rara")`:                "modo de archivo desconocido 'rara'",
+		"leer_linea(5)":                   "requiere un archivo abierto con abrir()",
+	} {
+		if _, err := EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
+
+func TestArchivoAbierto(t *testing.T) {
+	ruta := filepath.Join(t.TempDir(), "a.txt")
+	if _, err := Funciones["escribir_archivo"](ruta, "uno\ndos\n"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := PrepararContextoEvaluador()
+	f, err := Funciones["abrir"](ruta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.Variables["f"] = f
+
+	for _, c := range []struct{ expresion, esperado string }{
+		{"f.leer_linea()", "uno"},
+		{"leer_linea(f)", "dos"},
+		{"f.cerrar()", "verdadero"},
+		{"f.cerrar()", "verdadero"}, // cerrar dos veces no es un error
+	} {
+		r, err := EvalConContexto(c.expresion, ctx)
+		if err != nil || FormatearValor(r) != c.esperado {
+			t.Errorf("%s = %v, %v; se esperaba %s", c.expresion, r, err, c.esperado)
+		}
+	}
+	if _, err := EvalConContexto("f.leer_linea()", ctx); err == nil || !strings.Contains(err.Error(), "archivo cerrado") {
+		t.Errorf("leer de un archivo cerrado: %v", err)
+	}
+	f, _ = Funciones["abrir"](ruta)
+	ctx.Variables["f"] = f
+	if _, err := EvalConContexto(`f.escribir("x")`, ctx); err == nil || !strings.Contains(err.Error(), "abierto para leer") {
+		t.Errorf("escribir en un archivo abierto para leer: %v", err)
+	}
+}
+
+// Usar cierra el recurso aunque el cuerpo falle, y el error del cuerpo es el
+// que se informa.
+func TestUsarCierraElArchivo(t *testing.T) {
+	ruta := filepath.Join(t.TempDir(), "c.txt")
+	g, err := Funciones["abrir"](ruta, "escribir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archivo := g.(*Archivo)
+	errCuerpo := errors.New("falla en el cuerpo")
+	err = Usar(archivo, func() error {
+		if err := archivo.Escribir("hola"); err != nil {
+			return err
+		}
+		return errCuerpo
+	})
+	if err != errCuerpo {
+		t.Errorf("Usar devolvió %v, se esperaba el error del cuerpo", err)
+	}
+	if !archivo.Cerrado() {
+		t.Error("el archivo debía quedar cerrado")
+	}
+	if texto, err := Funciones["leer_archivo"](ruta); err != nil || texto != "hola" {
+		t.Errorf("leer_archivo = %q, %v", texto, err)
+	}
+}
+
+func TestBorrarDirectorioNoVacio(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "datos")
+	if _, err := Funciones["crear_directorio"](dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Funciones["escribir_archivo"](filepath.Join(dir, "a.txt"), "hola"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Funciones["borrar_archivo"](dir)
+	if err == nil || !strings.Contains(err.Error(), "no está vacío") {
+		t.Errorf("borrar un directorio con contenido: %v", err)
+	}
+	if _, err := Funciones["borrar_archivo"](filepath.Join(dir, "a.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Funciones["borrar_archivo"](dir); err != nil {
+		t.Errorf("borrar el directorio ya vacío: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package evaluador
 
 import (
 	"fmt"
+	"time"
 	"nepa/desarrollo/interno/administrador"
 	"nepa/desarrollo/interno/parser"
 )
@@ -43,6 +44,16 @@ func ManejarAsignacion(nodo parser.Nodo, ctx *Contexto) {
 		// Si falla, usamos el campo 'Tipo' del nodo como plan B
 		v, err = administrador.CrearVariableUniversal(nodo.Tipo, nodo.Nombre, valorResuelto)
 	}
+	if err != nil {
+		// Lambdas, polinomios, expresiones simbólicas, archivos, fechas y
+		// duraciones tienen su propio tipo
+		switch x := valorResuelto.(type) {
+		case Funcion, Polinomio, Simbolico, *Archivo, Duracion:
+			v, err = administrador.CrearVariableUniversal(obtenerTipoEnEspañol(valorResuelto), nodo.Nombre, valorResuelto)
+		case time.Time:
+			v, err = administrador.CrearVariableUniversal(tipoDeFecha(x), nodo.Nombre, valorResuelto)
+		}
+	}
 
 	if err != nil {
 		// Mantenemos tu formato de error original
@@ -54,6 +65,11 @@ func ManejarAsignacion(nodo parser.Nodo, ctx *Contexto) {
 	// Guardamos en el administrador global y en el contexto actual
 	administrador.RegistrarVariable(nodo.Nombre, v)
 	if ctx != nil && ctx.Variables != nil {
-		ctx.Variables[nodo.Nombre] = v.Mostrar()
+		switch valorResuelto.(type) {
+		case Funcion, Polinomio, Simbolico, *Archivo, Duracion, time.Time:
+			ctx.Variables[nodo.Nombre] = v
+		default:
+			ctx.Variables[nodo.Nombre] = v.Mostrar()
+		}
 	}
 }
@@ -0,0 +1,32 @@
+package evaluador
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Avisos: las funciones registradas no imprimen. Cuando un resultado es
+// válido pero dudoso (una matriz mal condicionada, una integral que no
+// alcanzó la tolerancia) lo anotan con Avisar y el comando que ejecutó la
+// instrucción los muestra al terminarla.
+
+var (
+	avisos      []string
+	avisosMutex sync.Mutex
+)
+
+// Avisar anota un aviso para el usuario.
+func Avisar(formato string, args ...interface{}) {
+	avisosMutex.Lock()
+	defer avisosMutex.Unlock()
+	avisos = append(avisos, fmt.Sprintf(formato, args...))
+}
+
+// TomarAvisos devuelve los avisos pendientes y los olvida.
+func TomarAvisos() []string {
+	avisosMutex.Lock()
+	defer avisosMutex.Unlock()
+	res := avisos
+	avisos = nil
+	return res
+}
@@ -2,7 +2,10 @@ package evaluador
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
+
+	"nepa/desarrollo/interno/valores"
 )
 
 // ConvertirAReal convierte cualquier valor a float64 para cálculos universales.
@@ -37,6 +40,26 @@ func ConvertirAReal(v interface{}) (float64, error) {
 			return 1.0, nil
 		}
 		return 0.0, nil
+	case Decimal:
+		return x.Float64(), nil
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(x).Float64()
+		return f, nil
+	case *big.Rat:
+		f, _ := x.Float64()
+		return f, nil
+	case Cantidad:
+		// Solo las cantidades adimensionales (ángulos) pasan a real sin más
+		if !x.Dimension().esAdimensional() {
+			return 0, fmt.Errorf("❌ ERROR FATAL: la cantidad %s tiene unidades; use .valor() o .a(\"unidad\")", x)
+		}
+		return x.EnSI(), nil
+	case complex128:
+		// Solo los complejos sin parte imaginaria tienen equivalente real
+		if imag(x) != 0 {
+			return 0, fmt.Errorf("❌ ERROR FATAL: el número complejo %s no tiene equivalente real", valores.FormatearComplejo(x))
+		}
+		return real(x), nil
 	default:
 		return 0, fmt.Errorf("tipo no soportado")
 	}
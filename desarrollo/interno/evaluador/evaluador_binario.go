@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"math"
 )
 
 // evaluarBinario maneja operaciones entre dos valores (+, -, *, /, %, etc.).
@@ -16,15 +17,62 @@ func evaluarBinario(n *ast.BinaryExpr, ctx *Contexto) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	// datos | ordenar() | primeros(5): tubería cuando la derecha es una función
+	if n.Op == token.OR {
+		if res, esTuberia, err := aplicarTuberia(izquierda, n.Y, ctx); esTuberia {
+			return res, err
+		}
+	}
 	derecha, err := evaluarNodo(n.Y, ctx)
 	if err != nil {
 		return nil, err
 	}
+	if n.Op == token.OR {
+		return nil, fmt.Errorf("❌ ERROR FATAL: el lado derecho de | debe ser una función, recibió %s (para el o de bits use bit_o)", obtenerTipoEnEspañol(derecha))
+	}
 	return aplicarOperacion(n.Op, izquierda, derecha)
 }
 
 // aplicarOperacion ejecuta la lógica matemática o lógica según el operador.
 func aplicarOperacion(op token.Token, izquierda, derecha interface{}) (interface{}, error) {
+	// Matrices y listas (vectores) tienen su propia aritmética
+	if res, manejado, err := operarColecciones(op, izquierda, derecha); manejado {
+		return res, err
+	}
+	if res, manejado, err := operarFunciones(op, izquierda, derecha); manejado {
+		return res, err
+	}
+	// Expresiones simbólicas: se construye el árbol en lugar de calcular
+	if res, manejado, err := operarSimbolicos(op, izquierda, derecha); manejado {
+		return res, err
+	}
+	if res, manejado, err := operarPolinomios(op, izquierda, derecha); manejado {
+		return res, err
+	}
+	// Fechas y duraciones (antes que las cantidades: fecha + 1 dia)
+	if res, manejado, err := operarFechas(op, izquierda, derecha); manejado {
+		return res, err
+	}
+	// Magnitudes físicas: las unidades viajan con el valor
+	if res, manejado, err := operarCantidades(op, izquierda, derecha); manejado {
+		return res, err
+	}
+	// Si alguno es complejo, la operación se hace en el plano complejo
+	if res, manejado, err := operarComplejos(op, izquierda, derecha); manejado {
+		return res, err
+	}
+	// Los decimales exactos conservan su tipo (no pasan por float64)
+	if res, manejado, err := operarDecimales(op, izquierda, derecha); manejado {
+		return res, err
+	}
+	// Racionales y enteros se mantienen exactos (con promoción a entero grande)
+	if res, manejado, err := operarRacionales(op, izquierda, derecha); manejado {
+		return res, err
+	}
+	if res, manejado, err := operarEnteros(op, izquierda, derecha); manejado {
+		return res, err
+	}
+
 	switch op {
 	case token.ADD:
 		// MEJORA DE INTEROPERABILIDAD:
@@ -43,9 +91,18 @@ func aplicarOperacion(op token.Token, izquierda, derecha interface{}) (interface
 	case token.SUB:
 		return operarNumeros(izquierda, derecha, func(a, b float64) float64 { return a - b })
 
-	case token.MUL:
+	case token.MUL, token.AND_NOT: // '.*' entre escalares es el producto normal
 		return operarNumeros(izquierda, derecha, func(a, b float64) float64 { return a * b })
 
+	case token.XOR: // '^' es potencia en Nepa
+		return operarNumerosConValidacion(izquierda, derecha, func(a, b float64) (float64, error) {
+			res := math.Pow(a, b)
+			if math.IsNaN(res) {
+				return 0, fmt.Errorf("❌ ERROR FATAL: potencia indefinida → %v^%v", a, b)
+			}
+			return res, nil
+		})
+
 	case token.QUO:
 		return operarNumerosConValidacion(izquierda, derecha, func(a, b float64) (float64, error) {
 			if b == 0 {
@@ -0,0 +1,461 @@
+package evaluador
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Funciones de texto. Todas trabajan con caracteres (runas), no con bytes,
+// así que "año" mide 3 y sus posiciones son 0, 1 y 2. Cada función existe
+// también como método:
+//   mayusculas("ñandú")         → "ÑANDÚ"
+//   "acción".longitud()         → 6
+//   "a,b,c".dividir(",")        → ["a", "b", "c"]
+//   subcadena("camión", -3)     → "ión"
+
+// maxLargoCadena limita lo que pueden construir repetir y rellenar (64 MiB).
+const maxLargoCadena = 64 << 20
+
+// comoCadena acepta cadenas y caracteres sueltos ('ñ').
+func comoCadena(nombre string, v interface{}) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case rune:
+		return string(x), nil
+	}
+	return "", fmt.Errorf("❌ ERROR FATAL: '%s' requiere una cadena → %v", nombre, FormatearValor(v))
+}
+
+// comoEnteroPequeño lee posiciones, anchos y repeticiones.
+func comoEnteroPequeño(nombre string, v interface{}) (int, error) {
+	if n, ok := enteroPequeño(v); ok {
+		return int(n), nil
+	}
+	if f, ok := v.(float64); ok && f == float64(int(f)) {
+		return int(f), nil
+	}
+	return 0, fmt.Errorf("❌ ERROR FATAL: '%s' requiere un entero → %v", nombre, FormatearValor(v))
+}
+
+// argumentosCadena valida la cadena inicial y la cantidad de argumentos.
+func argumentosCadena(nombre string, args []interface{}, minimo, maximo int) (string, error) {
+	if len(args) < minimo || len(args) > maximo {
+		if minimo == maximo {
+			return "", fmt.Errorf("❌ ERROR FATAL: '%s' requiere %d argumento(s), recibió %d", nombre, minimo, len(args))
+		}
+		return "", fmt.Errorf("❌ ERROR FATAL: '%s' requiere entre %d y %d argumentos, recibió %d", nombre, minimo, maximo, len(args))
+	}
+	return comoCadena(nombre, args[0])
+}
+
+// posicionRelativa convierte una posición (negativa cuenta desde el final)
+// en un índice de 0 a n.
+func posicionRelativa(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
+
+// invertirRunas da la vuelta al texto sin separar los acentos combinados
+// (una "e" seguida de U+0301 sigue siendo "é").
+func invertirRunas(s string) string {
+	var grupos [][]rune
+	for _, r := range s {
+		if len(grupos) > 0 && unicode.Is(unicode.Mn, r) {
+			grupos[len(grupos)-1] = append(grupos[len(grupos)-1], r)
+			continue
+		}
+		grupos = append(grupos, []rune{r})
+	}
+	var b strings.Builder
+	for i := len(grupos) - 1; i >= 0; i-- {
+		b.WriteString(string(grupos[i]))
+	}
+	return b.String()
+}
+
+// capitalizar pone en mayúscula la primera letra del texto o, con
+// "palabras", la primera de cada palabra.
+func capitalizar(s string, palabras bool) string {
+	var b strings.Builder
+	inicio := true
+	for _, r := range s {
+		if inicio && unicode.IsLetter(r) {
+			b.WriteRune(unicode.ToUpper(r))
+			inicio = false
+			continue
+		}
+		if palabras && (unicode.IsSpace(r) || r == '-') {
+			inicio = true
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// rellenar completa el texto hasta ancho caracteres por la izquierda, la
+// derecha o ambos lados ("centro").
+func rellenar(s string, ancho int, relleno string, lado string) (string, error) {
+	faltan := ancho - utf8.RuneCountInString(s)
+	if faltan <= 0 {
+		return s, nil
+	}
+	if faltan > maxLargoCadena {
+		return "", fmt.Errorf("❌ ERROR FATAL: 'rellenar' daría una cadena de más de %d caracteres", maxLargoCadena)
+	}
+	patron := []rune(relleno)
+	if len(patron) == 0 {
+		return "", fmt.Errorf("❌ ERROR FATAL: 'rellenar' requiere un texto de relleno no vacío")
+	}
+	hacer := func(n int) string {
+		r := make([]rune, n)
+		for i := range r {
+			r[i] = patron[i%len(patron)]
+		}
+		return string(r)
+	}
+	switch strings.ToLower(strings.TrimSpace(lado)) {
+	case "izquierda", "":
+		return hacer(faltan) + s, nil
+	case "derecha":
+		return s + hacer(faltan), nil
+	case "centro", "ambos":
+		izq := faltan / 2
+		return hacer(izq) + s + hacer(faltan-izq), nil
+	}
+	return "", fmt.Errorf("❌ ERROR FATAL: 'rellenar' admite los lados \"izquierda\", \"derecha\" o \"centro\" → %s", lado)
+}
+
+func init() {
+	// transformaciones de una sola cadena
+	simples := map[string]func(string) string{
+		"mayusculas":         strings.ToUpper,
+		"minusculas":         strings.ToLower,
+		"sin_espacio":        strings.TrimSpace,
+		"espacios_izquierda": func(s string) string { return strings.TrimLeftFunc(s, unicode.IsSpace) },
+		"espacios_derecha":   func(s string) string { return strings.TrimRightFunc(s, unicode.IsSpace) },
+	}
+	for nombre, f := range simples {
+		nombre, f := nombre, f
+		Funciones[nombre] = func(args ...interface{}) (interface{}, error) {
+			s, err := argumentosCadena(nombre, args, 1, 1)
+			if err != nil {
+				return nil, err
+			}
+			return f(s), nil
+		}
+	}
+
+	// longitud(x) -> caracteres de una cadena o elementos de una lista o diccionario
+	Funciones["longitud"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'longitud' requiere 1 argumento(s), recibió %d", len(args))
+		}
+		switch x := args[0].(type) {
+		case map[string]interface{}:
+			return len(x), nil
+		case string, rune:
+			s, _ := comoCadena("longitud", x)
+			return utf8.RuneCountInString(s), nil
+		}
+		lista, err := comoLista("longitud", args[0])
+		if err != nil {
+			return nil, err
+		}
+		return len(lista), nil
+	}
+
+	// capitalizar(s, ["palabras"])
+	Funciones["capitalizar"] = func(args ...interface{}) (interface{}, error) {
+		s, err := argumentosCadena("capitalizar", args, 1, 2)
+		if err != nil {
+			return nil, err
+		}
+		palabras := false
+		if len(args) == 2 {
+			modo, _ := args[1].(string)
+			switch strings.ToLower(strings.TrimSpace(modo)) {
+			case "palabras":
+				palabras = true
+			case "texto", "primera":
+			default:
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'capitalizar' admite el modo \"palabras\" → %v", FormatearValor(args[1]))
+			}
+		}
+		return capitalizar(s, palabras), nil
+	}
+
+	// subcadena(s, inicio, [fin]) -> caracteres de inicio a fin (sin incluirlo)
+	Funciones["subcadena"] = func(args ...interface{}) (interface{}, error) {
+		s, err := argumentosCadena("subcadena", args, 2, 3)
+		if err != nil {
+			return nil, err
+		}
+		runas := []rune(s)
+		inicio, err := comoEnteroPequeño("subcadena", args[1])
+		if err != nil {
+			return nil, err
+		}
+		fin := len(runas)
+		if len(args) == 3 {
+			if fin, err = comoEnteroPequeño("subcadena", args[2]); err != nil {
+				return nil, err
+			}
+		}
+		inicio, fin = posicionRelativa(inicio, len(runas)), posicionRelativa(fin, len(runas))
+		if fin < inicio {
+			return "", nil
+		}
+		return string(runas[inicio:fin]), nil
+	}
+
+	// reemplazar(s, viejo, nuevo, [veces]) -> todas las apariciones por defecto
+	Funciones["reemplazar"] = func(args ...interface{}) (interface{}, error) {
+		s, err := argumentosCadena("reemplazar", args, 3, 4)
+		if err != nil {
+			return nil, err
+		}
+		viejo, err := comoCadena("reemplazar", args[1])
+		if err != nil {
+			return nil, err
+		}
+		nuevo, err := comoCadena("reemplazar", args[2])
+		if err != nil {
+			return nil, err
+		}
+		veces := -1
+		if len(args) == 4 {
+			if veces, err = comoEnteroPequeño("reemplazar", args[3]); err != nil {
+				return nil, err
+			}
+		}
+		return strings.Replace(s, viejo, nuevo, veces), nil
+	}
+
+	// dividir(s, [separador]) -> sin separador, corta por espacios
+	Funciones["dividir"] = func(args ...interface{}) (interface{}, error) {
+		s, err := argumentosCadena("dividir", args, 1, 2)
+		if err != nil {
+			return nil, err
+		}
+		var partes []string
+		if len(args) == 1 {
+			partes = strings.Fields(s)
+		} else {
+			sep, err := comoCadena("dividir", args[1])
+			if err != nil {
+				return nil, err
+			}
+			if sep == "" {
+				// Separador vacío: un elemento por carácter
+				partes = strings.Split(s, "")
+			} else {
+				partes = strings.Split(s, sep)
+			}
+		}
+		res := make([]interface{}, len(partes))
+		for i, p := range partes {
+			res[i] = p
+		}
+		return res, nil
+	}
+
+	// unir(lista, [separador]) -> los elementos que no son texto se formatean
+	Funciones["unir"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'unir' requiere una lista y, opcionalmente, un separador")
+		}
+		lista, err := comoLista("unir", args[0])
+		if err != nil {
+			return nil, err
+		}
+		sep := ""
+		if len(args) == 2 {
+			if sep, err = comoCadena("unir", args[1]); err != nil {
+				return nil, err
+			}
+		}
+		partes := make([]string, len(lista))
+		for i, x := range lista {
+			if s, err := comoCadena("unir", x); err == nil {
+				partes[i] = s
+			} else {
+				partes[i] = FormatearValor(x)
+			}
+		}
+		return strings.Join(partes, sep), nil
+	}
+
+	// buscar(s, texto, [desde]) -> posición (en caracteres) o -1
+	Funciones["buscar"] = func(args ...interface{}) (interface{}, error) {
+		s, err := argumentosCadena("buscar", args, 2, 3)
+		if err != nil {
+			return nil, err
+		}
+		sub, err := comoCadena("buscar", args[1])
+		if err != nil {
+			return nil, err
+		}
+		runas := []rune(s)
+		desde := 0
+		if len(args) == 3 {
+			if desde, err = comoEnteroPequeño("buscar", args[2]); err != nil {
+				return nil, err
+			}
+			desde = posicionRelativa(desde, len(runas))
+		}
+		i := strings.Index(string(runas[desde:]), sub)
+		if i < 0 {
+			return -1, nil
+		}
+		return desde + utf8.RuneCountInString(string(runas[desde:])[:i]), nil
+	}
+
+	// empieza_con(s, prefijo), termina_con(s, sufijo)
+	Funciones["empieza_con"] = func(args ...interface{}) (interface{}, error) {
+		s, err := argumentosCadena("empieza_con", args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		p, err := comoCadena("empieza_con", args[1])
+		if err != nil {
+			return nil, err
+		}
+		return strings.HasPrefix(s, p), nil
+	}
+	Funciones["termina_con"] = func(args ...interface{}) (interface{}, error) {
+		s, err := argumentosCadena("termina_con", args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		p, err := comoCadena("termina_con", args[1])
+		if err != nil {
+			return nil, err
+		}
+		return strings.HasSuffix(s, p), nil
+	}
+
+	// repetir(s, n, [separador])
+	Funciones["repetir"] = func(args ...interface{}) (interface{}, error) {
+		s, err := argumentosCadena("repetir", args, 2, 3)
+		if err != nil {
+			return nil, err
+		}
+		n, err := comoEnteroPequeño("repetir", args[1])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'repetir' requiere un número de repeticiones no negativo → %d", n)
+		}
+		sep := ""
+		if len(args) == 3 {
+			if sep, err = comoCadena("repetir", args[2]); err != nil {
+				return nil, err
+			}
+		}
+		if n == 0 {
+			return "", nil
+		}
+		if paso := len(s) + len(sep); paso > 0 && n-1 > (maxLargoCadena-len(s))/paso {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'repetir' daría una cadena de más de %d bytes", maxLargoCadena)
+		}
+		return strings.Repeat(s+sep, n-1) + s, nil
+	}
+
+	// invertir(x) -> cadena al revés o copia de la lista en orden inverso
+	Funciones["invertir"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'invertir' requiere 1 argumento(s), recibió %d", len(args))
+		}
+		if s, err := comoCadena("invertir", args[0]); err == nil {
+			return invertirRunas(s), nil
+		}
+		lista, err := comoLista("invertir", args[0])
+		if err != nil {
+			return nil, err
+		}
+		res := make([]interface{}, len(lista))
+		for i, x := range lista {
+			res[len(lista)-1-i] = x
+		}
+		return res, nil
+	}
+
+	// rellenar(s, ancho, [relleno], [lado]) -> rellenar("7", 3, "0") = "007"
+	Funciones["rellenar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 4 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'rellenar' requiere entre 2 y 4 argumentos, recibió %d", len(args))
+		}
+		s, err := comoCadena("rellenar", args[0])
+		if err != nil {
+			// Los números se rellenan tal como se imprimen
+			s = FormatearValor(args[0])
+		}
+		ancho, err := comoEnteroPequeño("rellenar", args[1])
+		if err != nil {
+			return nil, err
+		}
+		relleno, lado := " ", "izquierda"
+		if len(args) >= 3 {
+			if relleno, err = comoCadena("rellenar", args[2]); err != nil {
+				return nil, err
+			}
+		}
+		if len(args) == 4 {
+			if lado, err = comoCadena("rellenar", args[3]); err != nil {
+				return nil, err
+			}
+		}
+		res, err := rellenar(s, ancho, relleno, lado)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	// contar(s, texto) -> apariciones sin solaparse
+	Funciones["contar"] = func(args ...interface{}) (interface{}, error) {
+		s, err := argumentosCadena("contar", args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		sub, err := comoCadena("contar", args[1])
+		if err != nil {
+			return nil, err
+		}
+		if sub == "" {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'contar' requiere un texto no vacío para buscar")
+		}
+		return strings.Count(s, sub), nil
+	}
+
+	// Métodos: "texto".mayusculas(), "a b".dividir()...
+	for _, nombre := range []string{
+		"mayusculas", "minusculas", "sin_espacio", "espacios_izquierda", "espacios_derecha",
+		"longitud", "capitalizar", "subcadena", "reemplazar", "dividir", "buscar",
+		"empieza_con", "termina_con", "repetir", "invertir", "rellenar", "contar",
+	} {
+		Funciones["cadena."+nombre] = Funciones[nombre]
+	}
+	// "caracter" es el tipo de 'ñ'; comparte los métodos de texto
+	for _, nombre := range []string{"mayusculas", "minusculas", "repetir", "rellenar"} {
+		Funciones["caracter."+nombre] = Funciones[nombre]
+	}
+	// Nombre antiguo de mayúsculas
+	Funciones["cadena.convertir_caracter"] = Funciones["mayusculas"]
+	for _, nombre := range []string{"longitud", "unir", "invertir"} {
+		Funciones["lista."+nombre] = Funciones[nombre]
+	}
+	Funciones["diccionario.longitud"] = Funciones["longitud"]
+}
@@ -0,0 +1,78 @@
+package evaluador
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFuncionesDeCadenas(t *testing.T) {
+	ctx := PrepararContextoEvaluador()
+	ctx.Variables["l"] = []interface{}{1, "dos", 3.5}
+	ctx.Variables["m"] = []interface{}{[]interface{}{1, 2}, []interface{}{3, 4}}
+	ctx.Variables["s"] = "Canción del año"
+
+	casos := []struct {
+		expresion string
+		esperado  string
+	}{
+		// las longitudes y posiciones cuentan caracteres, no bytes
+		{`longitud("año")`, "3"},
+		{"s.longitud()", "15"},
+		{`mayusculas("ñandú")`, "ÑANDÚ"},
+		{`minusculas("ÁRBOL Ñ")`, "árbol ñ"},
+		{`sin_espacio("  hola  ")`, "hola"},
+		{`espacios_izquierda("  hola  ")`, "hola  "},
+		{`espacios_derecha("  hola  ")`, "  hola"},
+		{`subcadena("camión", -3)`, "ión"},
+		{`subcadena("camión", 1, 3)`, "am"},
+		{`reemplazar("a-b-c", "-", "+")`, "a+b+c"},
+		{`reemplazar("a-b-c", "-", "+", 1)`, "a+b-c"},
+		{`dividir("a b  c")`, "[a, b, c]"},
+		{`"a,b,c".dividir(",")`, "[a, b, c]"},
+		{`dividir("año", "")`, "[a, ñ, o]"},
+		{`unir(l, ", ")`, "1, dos, 3.5"},
+		{`l.unir("-")`, "1-dos-3.5"},
+		{`buscar("pingüino", "ino")`, "5"},
+		{`buscar("año año", "año", 1)`, "4"},
+		{`buscar("x", "z")`, "-1"},
+		{`empieza_con("ñu", "ñ")`, "verdadero"},
+		{`"hola".termina_con("la")`, "verdadero"},
+		{`repetir("ab", 3, "|")`, "ab|ab|ab"},
+		{`invertir("mañana")`, "anañam"},
+		{"invertir(l)", "[3.5, dos, 1]"},
+		{`rellenar("7", 3, "0")`, "007"},
+		{`rellenar(42, 6, "*", "centro")`, "**42**"},
+		{`"ñ".rellenar(3, ".", "derecha")`, "ñ.."},
+		{`capitalizar("ñandú veloz")`, "Ñandú veloz"},
+		{`capitalizar("ñandú veloz", "palabras")`, "Ñandú Veloz"},
+		{`contar("banana", "an")`, "2"},
+		// índices y rebanadas
+		{"s[4]", "i"},
+		{"s[-3:]", "año"},
+		{"s[:7]", "Canción"},
+		{"l[-1]", "3.5"},
+		{"l[1:]", "[dos, 3.5]"},
+		{"m[1][1]", "4"},
+	}
+	for _, c := range casos {
+		r, err := EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if s := FormatearValor(r); s != c.esperado {
+			t.Errorf("%s = %q, se esperaba %q", c.expresion, s, c.esperado)
+		}
+	}
+
+	for expresion, mensaje := range map[string]string{
+		"s[40]":                     "índice 40 fuera de rango (longitud 15)",
+		`repetir("a", -1)`:          "no negativo",
+		`rellenar("a", 3, "", "x")`: "texto de relleno no vacío",
+		"mayusculas(3)":             "requiere una cadena",
+	} {
+		if _, err := EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
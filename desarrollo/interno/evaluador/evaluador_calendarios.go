@@ -0,0 +1,597 @@
+package evaluador
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Calendarios de días hábiles:
+//   dias_habiles(desde, hasta, "mx")          → lunes a viernes sin festivos de México
+//   siguiente_dia_habil(convertir_fecha("2024-12-24"), "es")
+//   ajustar_dia_habil(vence, "mx_bancario", "siguiente_modificado")
+//   cargar_festivos("empresa", "festivos.csv", "mx")
+//
+// Los festivos se calculan por regla para cualquier año:
+//   mx           días de descanso obligatorio (Ley Federal del Trabajo, art. 74)
+//   mx_bancario  mx más Jueves y Viernes Santo, 2 de noviembre y 12 de diciembre
+//   es           fiestas nacionales de España (sin las autonómicas ni traslados)
+//   ninguno      solo sábados y domingos
+// El calendario también puede darse como una lista de fechas festivas.
+
+// Festivo es un día inhábil de un calendario.
+type Festivo struct {
+	Fecha  time.Time
+	Nombre string
+}
+
+// Calendario decide qué días son hábiles: de lunes a viernes, salvo sus festivos.
+type Calendario struct {
+	Nombre string
+	reglas func(año int) []Festivo
+	fijos  map[int]string // diaCivil → nombre, de las listas cargadas
+	base   *Calendario
+
+	mu     sync.Mutex
+	porAño map[int]map[int]string
+}
+
+// festivosDelAño reúne reglas, base y fechas cargadas de un año (con caché).
+func (c *Calendario) festivosDelAño(año int) map[int]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if res, ok := c.porAño[año]; ok {
+		return res
+	}
+	res := map[int]string{}
+	if c.base != nil {
+		for dia, nombre := range c.base.festivosDelAño(año) {
+			res[dia] = nombre
+		}
+	}
+	if c.reglas != nil {
+		for _, f := range c.reglas(año) {
+			res[diaCivil(f.Fecha)] = f.Nombre
+		}
+	}
+	inicio, fin := diaCivil(fechaCivil(año, time.January, 1)), diaCivil(fechaCivil(año+1, time.January, 1))
+	for dia, nombre := range c.fijos {
+		if dia >= inicio && dia < fin {
+			res[dia] = nombre
+		}
+	}
+	if c.porAño == nil {
+		c.porAño = map[int]map[int]string{}
+	}
+	c.porAño[año] = res
+	return res
+}
+
+// Festivos lista los festivos del año en orden.
+func (c *Calendario) Festivos(año int) []Festivo {
+	dias := c.festivosDelAño(año)
+	res := make([]Festivo, 0, len(dias))
+	for dia, nombre := range dias {
+		res = append(res, Festivo{Fecha: time.Unix(int64(dia)*86400, 0).UTC(), Nombre: nombre})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Fecha.Before(res[j].Fecha) })
+	return res
+}
+
+// EsFestivo devuelve el nombre del festivo de ese día, si lo es.
+func (c *Calendario) EsFestivo(t time.Time) (string, bool) {
+	nombre, ok := c.festivosDelAño(t.Year())[diaCivil(t)]
+	return nombre, ok
+}
+
+func (c *Calendario) EsHabil(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	_, festivo := c.EsFestivo(t)
+	return !festivo
+}
+
+// ContarHabiles cuenta los días hábiles desde 'desde' (incluido) hasta
+// 'hasta' (excluido); si hasta es anterior el resultado es negativo.
+func (c *Calendario) ContarHabiles(desde, hasta time.Time) int {
+	signo := 1
+	if diaCivil(hasta) < diaCivil(desde) {
+		desde, hasta, signo = hasta, desde, -1
+	}
+	dia := fechaCivil(desde.Year(), desde.Month(), desde.Day())
+	total := 0
+	for n := diaCivil(hasta) - diaCivil(desde); n > 0; n-- {
+		if c.EsHabil(dia) {
+			total++
+		}
+		dia = dia.AddDate(0, 0, 1)
+	}
+	return signo * total
+}
+
+// Avanzar devuelve el n-ésimo día hábil después de t (antes si n < 0).
+func (c *Calendario) Avanzar(t time.Time, n int) time.Time {
+	paso := 1
+	if n < 0 {
+		paso, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, paso)
+		if c.EsHabil(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// Ajustar mueve una fecha inhábil según la regla de pago:
+//
+//	siguiente             el siguiente día hábil
+//	anterior              el día hábil anterior
+//	siguiente_modificado  el siguiente, salvo que cambie de mes (entonces el anterior)
+//	anterior_modificado   el anterior, salvo que cambie de mes (entonces el siguiente)
+func (c *Calendario) Ajustar(t time.Time, regla string) (time.Time, error) {
+	if c.EsHabil(t) {
+		if _, ok := reglasAjuste[regla]; !ok {
+			return t, errorReglaAjuste(regla)
+		}
+		return t, nil
+	}
+	switch regla {
+	case "siguiente":
+		return c.Avanzar(t, 1), nil
+	case "anterior":
+		return c.Avanzar(t, -1), nil
+	case "siguiente_modificado":
+		if s := c.Avanzar(t, 1); s.Month() == t.Month() {
+			return s, nil
+		}
+		return c.Avanzar(t, -1), nil
+	case "anterior_modificado":
+		if a := c.Avanzar(t, -1); a.Month() == t.Month() {
+			return a, nil
+		}
+		return c.Avanzar(t, 1), nil
+	}
+	return t, errorReglaAjuste(regla)
+}
+
+var reglasAjuste = map[string]bool{"siguiente": true, "anterior": true, "siguiente_modificado": true, "anterior_modificado": true}
+
+func errorReglaAjuste(regla string) error {
+	return fmt.Errorf("❌ ERROR FATAL: regla de ajuste desconocida '%s' (use siguiente, anterior, siguiente_modificado o anterior_modificado)", regla)
+}
+
+// ---------------------------------------------------------------------------
+// Reglas
+
+func fechaCivil(año int, mes time.Month, dia int) time.Time {
+	return time.Date(año, mes, dia, 0, 0, 0, 0, time.UTC)
+}
+
+// DomingoDePascua calcula la Pascua gregoriana (algoritmo de Meeus/Jones/Butcher).
+func DomingoDePascua(año int) time.Time {
+	a := año % 19
+	b, c := año/100, año%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	mes := (h + l - 7*m + 114) / 31
+	dia := (h+l-7*m+114)%31 + 1
+	return fechaCivil(año, time.Month(mes), dia)
+}
+
+// enesimoDiaSemana: el tercer lunes de marzo es enesimoDiaSemana(año, 3, lunes, 3).
+func enesimoDiaSemana(año int, mes time.Month, dia time.Weekday, n int) time.Time {
+	primero := fechaCivil(año, mes, 1)
+	desfase := (int(dia) - int(primero.Weekday()) + 7) % 7
+	return primero.AddDate(0, 0, desfase+7*(n-1))
+}
+
+// festivosMexico sigue el art. 74 de la LFT; los lunes móviles rigen desde 2006.
+func festivosMexico(año int) []Festivo {
+	res := []Festivo{
+		{fechaCivil(año, time.January, 1), "Año Nuevo"},
+		{fechaCivil(año, time.May, 1), "Día del Trabajo"},
+		{fechaCivil(año, time.September, 16), "Día de la Independencia"},
+		{fechaCivil(año, time.December, 25), "Navidad"},
+	}
+	if año >= 2006 {
+		res = append(res,
+			Festivo{enesimoDiaSemana(año, time.February, time.Monday, 1), "Día de la Constitución"},
+			Festivo{enesimoDiaSemana(año, time.March, time.Monday, 3), "Natalicio de Benito Juárez"},
+			Festivo{enesimoDiaSemana(año, time.November, time.Monday, 3), "Día de la Revolución"})
+	} else {
+		res = append(res,
+			Festivo{fechaCivil(año, time.February, 5), "Día de la Constitución"},
+			Festivo{fechaCivil(año, time.March, 21), "Natalicio de Benito Juárez"},
+			Festivo{fechaCivil(año, time.November, 20), "Día de la Revolución"})
+	}
+	// Cambio de gobierno federal cada seis años: 1 de diciembre hasta 2018,
+	// 1 de octubre desde 2024
+	switch {
+	case año >= 2024 && (año-2024)%6 == 0:
+		res = append(res, Festivo{fechaCivil(año, time.October, 1), "Transmisión del Poder Ejecutivo Federal"})
+	case año <= 2018 && año >= 1934 && (año-1934)%6 == 0:
+		res = append(res, Festivo{fechaCivil(año, time.December, 1), "Transmisión del Poder Ejecutivo Federal"})
+	}
+	return res
+}
+
+// festivosMexicoBancario agrega los días en que los bancos no abren (CNBV).
+func festivosMexicoBancario(año int) []Festivo {
+	pascua := DomingoDePascua(año)
+	return append(festivosMexico(año),
+		Festivo{pascua.AddDate(0, 0, -3), "Jueves Santo"},
+		Festivo{pascua.AddDate(0, 0, -2), "Viernes Santo"},
+		Festivo{fechaCivil(año, time.November, 2), "Día de Muertos"},
+		Festivo{fechaCivil(año, time.December, 12), "Día de la Virgen de Guadalupe"})
+}
+
+// festivosEspaña: fiestas de ámbito nacional (las comunidades añaden las suyas).
+func festivosEspaña(año int) []Festivo {
+	return []Festivo{
+		{fechaCivil(año, time.January, 1), "Año Nuevo"},
+		{fechaCivil(año, time.January, 6), "Epifanía del Señor"},
+		{DomingoDePascua(año).AddDate(0, 0, -2), "Viernes Santo"},
+		{fechaCivil(año, time.May, 1), "Fiesta del Trabajo"},
+		{fechaCivil(año, time.August, 15), "Asunción de la Virgen"},
+		{fechaCivil(año, time.October, 12), "Fiesta Nacional de España"},
+		{fechaCivil(año, time.November, 1), "Todos los Santos"},
+		{fechaCivil(año, time.December, 6), "Día de la Constitución Española"},
+		{fechaCivil(año, time.December, 8), "Inmaculada Concepción"},
+		{fechaCivil(año, time.December, 25), "Natividad del Señor"},
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Registro de calendarios
+
+var (
+	calendariosMu         sync.RWMutex
+	calendarioSinFestivos = &Calendario{Nombre: "ninguno"}
+	calendarios           = map[string]*Calendario{
+		"ninguno":     calendarioSinFestivos,
+		"mx":          {Nombre: "mx", reglas: festivosMexico},
+		"mx_bancario": {Nombre: "mx_bancario", reglas: festivosMexicoBancario},
+		"es":          {Nombre: "es", reglas: festivosEspaña},
+	}
+	aliasCalendario = map[string]string{
+		"mexico": "mx", "méxico": "mx", "mexico_bancario": "mx_bancario", "méxico_bancario": "mx_bancario",
+		"espana": "es", "españa": "es",
+	}
+	calendariosIncluidos = map[string]bool{"ninguno": true, "mx": true, "mx_bancario": true, "es": true}
+)
+
+func normalizarNombreCalendario(nombre string) string {
+	nombre = strings.ToLower(strings.TrimSpace(nombre))
+	if alias, ok := aliasCalendario[nombre]; ok {
+		return alias
+	}
+	return nombre
+}
+
+// EsNombreCalendario indica si hay un calendario con ese nombre.
+func EsNombreCalendario(nombre string) bool {
+	calendariosMu.RLock()
+	defer calendariosMu.RUnlock()
+	_, ok := calendarios[normalizarNombreCalendario(nombre)]
+	return ok
+}
+
+// BuscarCalendario acepta el nombre de un calendario o una lista de festivos.
+func BuscarCalendario(v interface{}) (*Calendario, error) {
+	switch x := v.(type) {
+	case nil:
+		return calendarioSinFestivos, nil
+	case string:
+		calendariosMu.RLock()
+		defer calendariosMu.RUnlock()
+		if c, ok := calendarios[normalizarNombreCalendario(x)]; ok {
+			return c, nil
+		}
+		return nil, fmt.Errorf("❌ ERROR FATAL: calendario desconocido '%s' (disponibles: %s)", x, strings.Join(nombresCalendarios(), ", "))
+	case []interface{}, map[string]interface{}:
+		fijos, err := festivosDesdeValor(v)
+		if err != nil {
+			return nil, err
+		}
+		return &Calendario{Nombre: "lista", fijos: fijos}, nil
+	}
+	return nil, fmt.Errorf("❌ ERROR FATAL: se esperaba el nombre de un calendario o una lista de festivos → %v", FormatearValor(v))
+}
+
+func nombresCalendarios() []string {
+	res := make([]string, 0, len(calendarios))
+	for nombre := range calendarios {
+		res = append(res, nombre)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// festivosDesdeValor lee una lista de fechas (o de registros {fecha, nombre})
+// o un diccionario {"2024-12-24": "Nochebuena"}.
+func festivosDesdeValor(v interface{}) (map[int]string, error) {
+	res := map[int]string{}
+	agregar := func(fecha interface{}, nombre string) error {
+		t, err := comoFecha("cargar_festivos", fecha)
+		if err != nil {
+			return err
+		}
+		if nombre == "" {
+			nombre = "Festivo"
+		}
+		res[diaCivil(t)] = nombre
+		return nil
+	}
+	switch x := v.(type) {
+	case []interface{}:
+		for _, e := range x {
+			if registro, ok := e.(map[string]interface{}); ok {
+				nombre, _ := registro["nombre"].(string)
+				if err := agregar(registro["fecha"], nombre); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := agregar(e, ""); err != nil {
+				return nil, err
+			}
+		}
+	case map[string]interface{}:
+		for fecha, nombre := range x {
+			if err := agregar(fecha, fmt.Sprint(nombre)); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("❌ ERROR FATAL: los festivos deben ser una lista de fechas o un diccionario fecha → nombre")
+	}
+	return res, nil
+}
+
+// festivosDesdeArchivo lee JSON (mismas formas que festivosDesdeValor) o
+// texto con una fecha por línea y nombre opcional: "2024-12-24, Nochebuena".
+func festivosDesdeArchivo(ruta string) (map[int]string, error) {
+	datos, err := os.ReadFile(ruta)
+	if err != nil {
+		return nil, errorArchivo("cargar_festivos", ruta, err)
+	}
+	datos = bytes.TrimPrefix(datos, []byte("\ufeff"))
+	if strings.EqualFold(filepath.Ext(ruta), ".json") {
+		v, err := LeerJSON(string(datos))
+		if err != nil {
+			return nil, fmt.Errorf("%v (en '%s')", err, ruta)
+		}
+		return festivosDesdeValor(v)
+	}
+	res := map[int]string{}
+	lector := bufio.NewScanner(bytes.NewReader(datos))
+	for n := 1; lector.Scan(); n++ {
+		linea := strings.TrimSpace(lector.Text())
+		if linea == "" || strings.HasPrefix(linea, "#") {
+			continue
+		}
+		fecha, nombre := linea, "Festivo"
+		if i := strings.IndexAny(linea, ",;\t"); i >= 0 {
+			fecha, nombre = strings.TrimSpace(linea[:i]), strings.Trim(strings.TrimSpace(linea[i+1:]), `"`)
+		}
+		t, err := ParsearFecha(fecha)
+		if err != nil {
+			if n == 1 && len(res) == 0 {
+				continue // encabezado ("fecha,nombre")
+			}
+			return nil, fmt.Errorf("❌ ERROR FATAL: '%s' línea %d: fecha inválida '%s'", ruta, n, fecha)
+		}
+		res[diaCivil(t)] = nombre
+	}
+	return res, nil
+}
+
+// ---------------------------------------------------------------------------
+// Funciones
+
+// argumentosCalendario separa la fecha inicial, los argumentos intermedios y
+// el calendario opcional del final.
+func argumentosCalendario(nombre string, args []interface{}, fijos int) (time.Time, *Calendario, error) {
+	if len(args) < fijos || len(args) > fijos+1 {
+		return time.Time{}, nil, fmt.Errorf("❌ ERROR FATAL: '%s' requiere %d argumento(s) y opcionalmente un calendario, recibió %d", nombre, fijos, len(args))
+	}
+	t, err := comoFecha(nombre, args[0])
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	cal := calendarioSinFestivos
+	if len(args) > fijos {
+		if cal, err = BuscarCalendario(args[fijos]); err != nil {
+			return time.Time{}, nil, err
+		}
+	}
+	return t, cal, nil
+}
+
+func listaFestivos(festivos []Festivo) []interface{} {
+	res := make([]interface{}, len(festivos))
+	for i, f := range festivos {
+		res[i] = map[string]interface{}{"fecha": f.Fecha, "nombre": f.Nombre}
+	}
+	return res
+}
+
+func init() {
+	// dias_habiles(desde, hasta, [calendario]) -> días hábiles desde 'desde'
+	// (incluido) hasta 'hasta' (excluido)
+	Funciones["dias_habiles"] = func(args ...interface{}) (interface{}, error) {
+		desde, cal, err := argumentosCalendario("dias_habiles", args, 2)
+		if err != nil {
+			return nil, err
+		}
+		hasta, err := comoFecha("dias_habiles", args[1])
+		if err != nil {
+			return nil, err
+		}
+		return cal.ContarHabiles(desde, hasta), nil
+	}
+
+	// es_dia_habil(f, [calendario]) y es_festivo(f, [calendario]) -> booleano
+	Funciones["es_dia_habil"] = func(args ...interface{}) (interface{}, error) {
+		t, cal, err := argumentosCalendario("es_dia_habil", args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return cal.EsHabil(t), nil
+	}
+	Funciones["es_festivo"] = func(args ...interface{}) (interface{}, error) {
+		t, cal, err := argumentosCalendario("es_festivo", args, 1)
+		if err != nil {
+			return nil, err
+		}
+		_, ok := cal.EsFestivo(t)
+		return ok, nil
+	}
+
+	// siguiente_dia_habil(f, [calendario]) y anterior_dia_habil(f, [calendario])
+	// -> el primer día hábil después (o antes) de f
+	Funciones["siguiente_dia_habil"] = func(args ...interface{}) (interface{}, error) {
+		t, cal, err := argumentosCalendario("siguiente_dia_habil", args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return cal.Avanzar(t, 1), nil
+	}
+	Funciones["anterior_dia_habil"] = func(args ...interface{}) (interface{}, error) {
+		t, cal, err := argumentosCalendario("anterior_dia_habil", args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return cal.Avanzar(t, -1), nil
+	}
+
+	// sumar_dias_habiles(f, n, [calendario]) -> n días hábiles después de f
+	Funciones["sumar_dias_habiles"] = func(args ...interface{}) (interface{}, error) {
+		t, cal, err := argumentosCalendario("sumar_dias_habiles", args, 2)
+		if err != nil {
+			return nil, err
+		}
+		n, err := comoEnteroPequeño("sumar_dias_habiles", args[1])
+		if err != nil {
+			return nil, err
+		}
+		if n > 1_000_000 || n < -1_000_000 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'sumar_dias_habiles': demasiados días → %d", n)
+		}
+		return cal.Avanzar(t, n), nil
+	}
+
+	// ajustar_dia_habil(f, [calendario], [regla]) -> f si es hábil; si no, según
+	// la regla (siguiente por defecto)
+	Funciones["ajustar_dia_habil"] = func(args ...interface{}) (interface{}, error) {
+		regla := "siguiente"
+		if len(args) == 3 {
+			texto, err := comoCadena("ajustar_dia_habil", args[2])
+			if err != nil {
+				return nil, err
+			}
+			regla = strings.ToLower(strings.TrimSpace(texto))
+			args = args[:2]
+		}
+		t, cal, err := argumentosCalendario("ajustar_dia_habil", args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return cal.Ajustar(t, regla)
+	}
+
+	// festivos(año, calendario) -> lista de {fecha, nombre}
+	Funciones["festivos"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'festivos' requiere un año y un calendario")
+		}
+		año, err := comoEnteroPequeño("festivos", args[0])
+		if err != nil {
+			return nil, err
+		}
+		cal, err := BuscarCalendario(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return listaFestivos(cal.Festivos(año)), nil
+	}
+
+	// pascua(año) -> domingo de Pascua
+	Funciones["pascua"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'pascua' requiere un año")
+		}
+		año, err := comoEnteroPequeño("pascua", args[0])
+		if err != nil {
+			return nil, err
+		}
+		return DomingoDePascua(año), nil
+	}
+
+	// cargar_festivos(nombre, fuente, [base]) -> cantidad de festivos cargados.
+	// La fuente es una lista de fechas, un diccionario fecha → nombre o la ruta
+	// de un archivo (.json o texto "AAAA-MM-DD, nombre" por línea); con base,
+	// el calendario nuevo incluye también los festivos de ese calendario.
+	Funciones["cargar_festivos"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'cargar_festivos' requiere un nombre, los festivos (lista o archivo) y opcionalmente un calendario base")
+		}
+		nombre, err := comoCadena("cargar_festivos", args[0])
+		if err != nil {
+			return nil, err
+		}
+		nombre = normalizarNombreCalendario(nombre)
+		if nombre == "" || calendariosIncluidos[nombre] {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'cargar_festivos': '%s' es un calendario incluido; use otro nombre y páselo como base", nombre)
+		}
+		var fijos map[int]string
+		if ruta, ok := args[1].(string); ok {
+			fijos, err = festivosDesdeArchivo(ruta)
+		} else {
+			fijos, err = festivosDesdeValor(args[1])
+		}
+		if err != nil {
+			return nil, err
+		}
+		cal := &Calendario{Nombre: nombre, fijos: fijos}
+		if len(args) == 3 {
+			if cal.base, err = BuscarCalendario(args[2]); err != nil {
+				return nil, err
+			}
+		}
+		calendariosMu.Lock()
+		calendarios[nombre] = cal
+		calendariosMu.Unlock()
+		return len(fijos), nil
+	}
+
+	// calendarios() -> nombres disponibles
+	Funciones["calendarios"] = func(args ...interface{}) (interface{}, error) {
+		calendariosMu.RLock()
+		defer calendariosMu.RUnlock()
+		nombres := nombresCalendarios()
+		res := make([]interface{}, len(nombres))
+		for i, n := range nombres {
+			res[i] = n
+		}
+		return res, nil
+	}
+
+	for _, nombre := range []string{"es_dia_habil", "es_festivo", "siguiente_dia_habil", "anterior_dia_habil", "sumar_dias_habiles", "ajustar_dia_habil"} {
+		Funciones["fecha."+nombre] = Funciones[nombre]
+	}
+}
@@ -0,0 +1,120 @@
+package evaluador
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDomingoDePascua(t *testing.T) {
+	casos := map[int]string{
+		1818: "1818-03-22", // la más temprana posible
+		1943: "1943-04-25", // la más tardía posible
+		2000: "2000-04-23",
+		2019: "2019-04-21",
+		2024: "2024-03-31",
+		2025: "2025-04-20",
+		2038: "2038-04-25",
+		2285: "2285-03-22",
+	}
+	for año, esperado := range casos {
+		if got := DomingoDePascua(año).Format("2006-01-02"); got != esperado {
+			t.Errorf("DomingoDePascua(%d) = %s, se esperaba %s", año, got, esperado)
+		}
+	}
+}
+
+func fechasDeFestivos(c *Calendario, año int) []string {
+	var res []string
+	for _, f := range c.Festivos(año) {
+		res = append(res, f.Fecha.Format("2006-01-02"))
+	}
+	return res
+}
+
+func TestFestivos(t *testing.T) {
+	casos := []struct {
+		calendario string
+		año        int
+		fechas     []string
+	}{
+		{"mx", 2024, []string{"2024-01-01", "2024-02-05", "2024-03-18", "2024-05-01", "2024-09-16", "2024-10-01", "2024-11-18", "2024-12-25"}},
+		{"mx", 2025, []string{"2025-01-01", "2025-02-03", "2025-03-17", "2025-05-01", "2025-09-16", "2025-11-17", "2025-12-25"}},
+		{"mx", 2018, []string{"2018-01-01", "2018-02-05", "2018-03-19", "2018-05-01", "2018-09-16", "2018-11-19", "2018-12-01", "2018-12-25"}},
+		{"mx", 2005, []string{"2005-01-01", "2005-02-05", "2005-03-21", "2005-05-01", "2005-09-16", "2005-11-20", "2005-12-25"}},
+		{"mx_bancario", 2025, []string{"2025-01-01", "2025-02-03", "2025-03-17", "2025-04-17", "2025-04-18", "2025-05-01", "2025-09-16", "2025-11-02", "2025-11-17", "2025-12-12", "2025-12-25"}},
+		{"es", 2024, []string{"2024-01-01", "2024-01-06", "2024-03-29", "2024-05-01", "2024-08-15", "2024-10-12", "2024-11-01", "2024-12-06", "2024-12-08", "2024-12-25"}},
+		{"ninguno", 2024, nil},
+	}
+	for _, c := range casos {
+		cal, err := BuscarCalendario(c.calendario)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := fechasDeFestivos(cal, c.año)
+		if len(got) != len(c.fechas) {
+			t.Errorf("%s %d: %v, se esperaba %v", c.calendario, c.año, got, c.fechas)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.fechas[i] {
+				t.Errorf("%s %d: %v, se esperaba %v", c.calendario, c.año, got, c.fechas)
+				break
+			}
+		}
+	}
+}
+
+func TestDiasHabiles(t *testing.T) {
+	mx, _ := BuscarCalendario("mx")
+	banco, _ := BuscarCalendario("mx_bancario")
+	ninguno, _ := BuscarCalendario("ninguno")
+	fecha := func(s string) time.Time { f, _ := ParsearFecha(s); return f }
+
+	conteos := []struct {
+		cal          *Calendario
+		desde, hasta string
+		esperado     int
+	}{
+		{ninguno, "2024-03-01", "2024-04-01", 21},
+		{mx, "2024-03-01", "2024-04-01", 20},    // 18 de marzo
+		{banco, "2024-03-01", "2024-04-01", 18}, // y Jueves y Viernes Santo
+		{mx, "2024-04-01", "2024-03-01", -20},   // al revés, negativo
+		{mx, "2024-03-18", "2024-03-18", 0},
+	}
+	for _, c := range conteos {
+		if got := c.cal.ContarHabiles(fecha(c.desde), fecha(c.hasta)); got != c.esperado {
+			t.Errorf("%s: hábiles de %s a %s = %d, se esperaba %d", c.cal.Nombre, c.desde, c.hasta, got, c.esperado)
+		}
+	}
+
+	ajustes := []struct {
+		cal          *Calendario
+		fecha, regla string
+		esperado     string
+	}{
+		{mx, "2024-03-30", "siguiente", "2024-04-01"},
+		{mx, "2024-03-30", "anterior", "2024-03-29"},
+		{mx, "2024-03-31", "siguiente_modificado", "2024-03-29"}, // el siguiente ya es abril
+		{banco, "2024-03-31", "siguiente_modificado", "2024-03-27"},
+		{mx, "2024-06-01", "anterior_modificado", "2024-06-03"}, // el anterior es mayo
+		{mx, "2024-03-18", "siguiente", "2024-03-19"},
+		{mx, "2024-03-19", "siguiente", "2024-03-19"},
+	}
+	for _, c := range ajustes {
+		got, err := c.cal.Ajustar(fecha(c.fecha), c.regla)
+		if err != nil {
+			t.Errorf("Ajustar(%s, %s): %v", c.fecha, c.regla, err)
+			continue
+		}
+		if got.Format("2006-01-02") != c.esperado {
+			t.Errorf("%s: Ajustar(%s, %s) = %s, se esperaba %s", c.cal.Nombre, c.fecha, c.regla, got.Format("2006-01-02"), c.esperado)
+		}
+	}
+	if _, err := mx.Ajustar(fecha("2024-03-19"), "cualquiera"); err == nil {
+		t.Error("una regla de ajuste desconocida debía fallar")
+	}
+
+	if got := mx.Avanzar(fecha("2024-12-20"), 5).Format("2006-01-02"); got != "2024-12-30" {
+		t.Errorf("5 días hábiles después del 20 de diciembre = %s, se esperaba 2024-12-30", got)
+	}
+}
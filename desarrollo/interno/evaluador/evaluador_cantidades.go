@@ -0,0 +1,567 @@
+package evaluador
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Dimension guarda los exponentes de las magnitudes base del SI:
+// longitud (m), masa (kg), tiempo (s), corriente (A), temperatura (K),
+// cantidad de sustancia (mol) e intensidad luminosa (cd).
+type Dimension [7]int
+
+var nombresDimension = [7]string{"m", "kg", "s", "A", "K", "mol", "cd"}
+
+func (d Dimension) esAdimensional() bool { return d == Dimension{} }
+
+func (d Dimension) String() string {
+	var partes []string
+	for i, e := range d {
+		switch {
+		case e == 1:
+			partes = append(partes, nombresDimension[i])
+		case e != 0:
+			partes = append(partes, fmt.Sprintf("%s^%d", nombresDimension[i], e))
+		}
+	}
+	if len(partes) == 0 {
+		return "adimensional"
+	}
+	return strings.Join(partes, "*")
+}
+
+// terminoUnidad es un símbolo elevado a un exponente: s^-2.
+type terminoUnidad struct {
+	simbolo   string
+	exponente int
+}
+
+// Cantidad es una magnitud física: un valor expresado en una unidad.
+// 5 m/s, cantidad(9.8, "m/s^2"). Es inmutable.
+type Cantidad struct {
+	Valor  float64
+	unidad []terminoUnidad
+}
+
+type unidadConocida struct {
+	factor     float64 // cuántas unidades SI base equivalen a 1 de esta
+	dim        Dimension
+	prefijable bool
+}
+
+var ErrDimensionIncompatible = errors.New("❌ ERROR FATAL: dimensiones físicas incompatibles")
+
+// unidadesConocidas: símbolos del SI (admiten prefijos: km, mg, kPa, MW...)
+// y unidades de uso común.
+var unidadesConocidas = map[string]unidadConocida{
+	// Base SI
+	"m":   {1, Dimension{1, 0, 0, 0, 0, 0, 0}, true},
+	"g":   {1e-3, Dimension{0, 1, 0, 0, 0, 0, 0}, true},
+	"s":   {1, Dimension{0, 0, 1, 0, 0, 0, 0}, true},
+	"A":   {1, Dimension{0, 0, 0, 1, 0, 0, 0}, true},
+	"K":   {1, Dimension{0, 0, 0, 0, 1, 0, 0}, true},
+	"mol": {1, Dimension{0, 0, 0, 0, 0, 1, 0}, true},
+	"cd":  {1, Dimension{0, 0, 0, 0, 0, 0, 1}, true},
+	// Derivadas SI
+	"N":   {1, Dimension{1, 1, -2, 0, 0, 0, 0}, true},
+	"J":   {1, Dimension{2, 1, -2, 0, 0, 0, 0}, true},
+	"W":   {1, Dimension{2, 1, -3, 0, 0, 0, 0}, true},
+	"Pa":  {1, Dimension{-1, 1, -2, 0, 0, 0, 0}, true},
+	"Hz":  {1, Dimension{0, 0, -1, 0, 0, 0, 0}, true},
+	"C":   {1, Dimension{0, 0, 1, 1, 0, 0, 0}, true},
+	"V":   {1, Dimension{2, 1, -3, -1, 0, 0, 0}, true},
+	"ohm": {1, Dimension{2, 1, -3, -2, 0, 0, 0}, true},
+	"Ω":   {1, Dimension{2, 1, -3, -2, 0, 0, 0}, true},
+	"L":   {1e-3, Dimension{3, 0, 0, 0, 0, 0, 0}, true},
+	"l":   {1e-3, Dimension{3, 0, 0, 0, 0, 0, 0}, true},
+	"Wh":  {3600, Dimension{2, 1, -2, 0, 0, 0, 0}, true},
+	"eV":  {1.602176634e-19, Dimension{2, 1, -2, 0, 0, 0, 0}, true},
+	"cal": {4.184, Dimension{2, 1, -2, 0, 0, 0, 0}, true},
+	"bar": {1e5, Dimension{-1, 1, -2, 0, 0, 0, 0}, true},
+	// Tiempo
+	"min":    {60, Dimension{0, 0, 1, 0, 0, 0, 0}, false},
+	"h":      {3600, Dimension{0, 0, 1, 0, 0, 0, 0}, false},
+	"hora":   {3600, Dimension{0, 0, 1, 0, 0, 0, 0}, false},
+	"dia":    {86400, Dimension{0, 0, 1, 0, 0, 0, 0}, false},
+	"semana": {604800, Dimension{0, 0, 1, 0, 0, 0, 0}, false},
+	"año":    {31556952, Dimension{0, 0, 1, 0, 0, 0, 0}, false},
+	// Longitud, masa y volumen no SI
+	"pulgada": {0.0254, Dimension{1, 0, 0, 0, 0, 0, 0}, false},
+	"in":      {0.0254, Dimension{1, 0, 0, 0, 0, 0, 0}, false},
+	"pie":     {0.3048, Dimension{1, 0, 0, 0, 0, 0, 0}, false},
+	"ft":      {0.3048, Dimension{1, 0, 0, 0, 0, 0, 0}, false},
+	"yarda":   {0.9144, Dimension{1, 0, 0, 0, 0, 0, 0}, false},
+	"yd":      {0.9144, Dimension{1, 0, 0, 0, 0, 0, 0}, false},
+	"milla":   {1609.344, Dimension{1, 0, 0, 0, 0, 0, 0}, false},
+	"mi":      {1609.344, Dimension{1, 0, 0, 0, 0, 0, 0}, false},
+	"t":       {1000, Dimension{0, 1, 0, 0, 0, 0, 0}, false},
+	"libra":   {0.45359237, Dimension{0, 1, 0, 0, 0, 0, 0}, false},
+	"lb":      {0.45359237, Dimension{0, 1, 0, 0, 0, 0, 0}, false},
+	"onza":    {0.028349523125, Dimension{0, 1, 0, 0, 0, 0, 0}, false},
+	"oz":      {0.028349523125, Dimension{0, 1, 0, 0, 0, 0, 0}, false},
+	"galon":   {3.785411784e-3, Dimension{3, 0, 0, 0, 0, 0, 0}, false},
+	// Velocidad, presión y potencia
+	"nudo": {1852.0 / 3600, Dimension{1, 0, -1, 0, 0, 0, 0}, false},
+	"atm":  {101325, Dimension{-1, 1, -2, 0, 0, 0, 0}, false},
+	"psi":  {6894.757293168, Dimension{-1, 1, -2, 0, 0, 0, 0}, false},
+	"torr": {101325.0 / 760, Dimension{-1, 1, -2, 0, 0, 0, 0}, false},
+	"hp":   {745.69987158227, Dimension{2, 1, -3, 0, 0, 0, 0}, false},
+	// Ángulos (adimensionales)
+	"rad":   {1, Dimension{}, false},
+	"grado": {math.Pi / 180, Dimension{}, false},
+	"deg":   {math.Pi / 180, Dimension{}, false},
+}
+
+var prefijosSI = map[string]float64{
+	"Y": 1e24, "Z": 1e21, "E": 1e18, "P": 1e15, "T": 1e12, "G": 1e9, "M": 1e6,
+	"k": 1e3, "h": 1e2, "da": 1e1, "d": 1e-1, "c": 1e-2, "m": 1e-3,
+	"u": 1e-6, "µ": 1e-6, "n": 1e-9, "p": 1e-12, "f": 1e-15, "a": 1e-18,
+}
+
+// buscarUnidad resuelve un símbolo, probando prefijos del SI si hace falta.
+func buscarUnidad(simbolo string) (unidadConocida, bool) {
+	if u, ok := unidadesConocidas[simbolo]; ok {
+		return u, true
+	}
+	for prefijo, f := range prefijosSI {
+		if !strings.HasPrefix(simbolo, prefijo) {
+			continue
+		}
+		if u, ok := unidadesConocidas[simbolo[len(prefijo):]]; ok && u.prefijable {
+			return unidadConocida{factor: u.factor * f, dim: u.dim}, true
+		}
+	}
+	return unidadConocida{}, false
+}
+
+// ParsearUnidad interpreta expresiones como "m/s^2", "kg*m/s^2", "km/h",
+// "J/kg/K" o "m2". Cada '/' divide solo al término que le sigue.
+func ParsearUnidad(expr string) ([]terminoUnidad, error) {
+	texto := strings.NewReplacer("·", "*", " ", "*", "²", "^2", "³", "^3").Replace(strings.TrimSpace(expr))
+	if texto == "" {
+		return nil, nil
+	}
+	var terminos []terminoUnidad
+	signo := 1
+	inicio := 0
+	for i := 0; i <= len(texto); i++ {
+		if i < len(texto) && texto[i] != '*' && texto[i] != '/' {
+			continue
+		}
+		pieza := texto[inicio:i]
+		switch {
+		case pieza == "":
+			return nil, fmt.Errorf("❌ ERROR FATAL: unidad inválida → '%s'", expr)
+		case pieza == "1" && inicio == 0:
+			// "1/s" es la forma de escribir s^-1
+		default:
+			t, err := parsearTermino(pieza)
+			if err != nil {
+				return nil, fmt.Errorf("%w en '%s'", err, expr)
+			}
+			t.exponente *= signo
+			terminos = append(terminos, t)
+		}
+		if i < len(texto) {
+			signo = 1
+			if texto[i] == '/' {
+				signo = -1
+			}
+		}
+		inicio = i + 1
+	}
+	return combinarTerminos(terminos, nil, 1), nil
+}
+
+func parsearTermino(pieza string) (terminoUnidad, error) {
+	simbolo, exponente := pieza, 1
+	if i := strings.IndexByte(pieza, '^'); i >= 0 {
+		e, err := strconv.Atoi(pieza[i+1:])
+		if err != nil {
+			return terminoUnidad{}, fmt.Errorf("❌ ERROR FATAL: exponente de unidad inválido '%s'", pieza)
+		}
+		simbolo, exponente = pieza[:i], e
+	} else if j := strings.IndexFunc(pieza, unicode.IsDigit); j > 0 {
+		// Notación compacta: m2, cm3
+		e, err := strconv.Atoi(pieza[j:])
+		if err != nil {
+			return terminoUnidad{}, fmt.Errorf("❌ ERROR FATAL: unidad inválida '%s'", pieza)
+		}
+		simbolo, exponente = pieza[:j], e
+	}
+	if _, ok := buscarUnidad(simbolo); !ok {
+		return terminoUnidad{}, fmt.Errorf("❌ ERROR FATAL: unidad desconocida '%s'", simbolo)
+	}
+	return terminoUnidad{simbolo: simbolo, exponente: exponente}, nil
+}
+
+// combinarTerminos multiplica dos listas de términos (b elevado a 'potencia'),
+// sumando exponentes del mismo símbolo y descartando los que se anulan.
+func combinarTerminos(a, b []terminoUnidad, potencia int) []terminoUnidad {
+	var res []terminoUnidad
+	indice := map[string]int{}
+	agregar := func(t terminoUnidad) {
+		if i, ok := indice[t.simbolo]; ok {
+			res[i].exponente += t.exponente
+			return
+		}
+		indice[t.simbolo] = len(res)
+		res = append(res, t)
+	}
+	for _, t := range a {
+		agregar(t)
+	}
+	for _, t := range b {
+		agregar(terminoUnidad{t.simbolo, t.exponente * potencia})
+	}
+	limpio := res[:0]
+	for _, t := range res {
+		if t.exponente != 0 {
+			limpio = append(limpio, t)
+		}
+	}
+	return limpio
+}
+
+func formatearTerminos(terminos []terminoUnidad) string {
+	var num, den []string
+	for _, t := range terminos {
+		e := t.exponente
+		if e < 0 {
+			e = -e
+		}
+		s := t.simbolo
+		if e != 1 {
+			s += "^" + strconv.Itoa(e)
+		}
+		if t.exponente > 0 {
+			num = append(num, s)
+		} else {
+			den = append(den, s)
+		}
+	}
+	res := strings.Join(num, "*")
+	if res == "" && len(den) > 0 {
+		res = "1"
+	}
+	for _, d := range den {
+		res += "/" + d
+	}
+	return res
+}
+
+// NuevaCantidad crea una cantidad validando la expresión de unidad.
+func NuevaCantidad(valor float64, unidad string) (Cantidad, error) {
+	terminos, err := ParsearUnidad(unidad)
+	if err != nil {
+		return Cantidad{}, err
+	}
+	return Cantidad{Valor: valor, unidad: terminos}, nil
+}
+
+// Unidad devuelve la expresión de unidad normalizada ("m/s^2").
+func (c Cantidad) Unidad() string { return formatearTerminos(c.unidad) }
+
+// Dimension devuelve los exponentes SI de la unidad.
+func (c Cantidad) Dimension() Dimension {
+	var d Dimension
+	for _, t := range c.unidad {
+		u, _ := buscarUnidad(t.simbolo)
+		for i := range d {
+			d[i] += u.dim[i] * t.exponente
+		}
+	}
+	return d
+}
+
+func (c Cantidad) factor() float64 {
+	f := 1.0
+	for _, t := range c.unidad {
+		u, _ := buscarUnidad(t.simbolo)
+		f *= math.Pow(u.factor, float64(t.exponente))
+	}
+	return f
+}
+
+// EnSI devuelve el valor expresado en unidades SI base.
+func (c Cantidad) EnSI() float64 { return c.Valor * c.factor() }
+
+// A convierte a otra unidad de la misma dimensión: (100 km/h).a("m/s").
+func (c Cantidad) A(unidad string) (Cantidad, error) {
+	destino, err := NuevaCantidad(1, unidad)
+	if err != nil {
+		return Cantidad{}, err
+	}
+	if destino.Dimension() != c.Dimension() {
+		return Cantidad{}, fmt.Errorf("%w: no se puede convertir %s (%s) a %s (%s)",
+			ErrDimensionIncompatible, c.Unidad(), c.Dimension(), destino.Unidad(), destino.Dimension())
+	}
+	destino.Valor = c.EnSI() / destino.factor()
+	return destino, nil
+}
+
+func (c Cantidad) String() string {
+	v := c.Valor
+	var num string
+	if a := math.Abs(v); a != 0 && (a < 1e-4 || a >= 1e15) {
+		num = strconv.FormatFloat(v, 'g', -1, 64)
+	} else {
+		num = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	if len(c.unidad) == 0 {
+		return num
+	}
+	return num + " " + c.Unidad()
+}
+
+// simplificar devuelve un real si la cantidad resultó adimensional (5 m / 2 m = 2.5).
+func (c Cantidad) simplificar() interface{} {
+	if c.Dimension().esAdimensional() {
+		return c.EnSI()
+	}
+	return c
+}
+
+// potencia eleva la unidad a 'p'; solo se admiten exponentes que dejen
+// exponentes enteros en todos los términos (raíz de m^2 sí, de m no).
+func (c Cantidad) potencia(p float64) (Cantidad, error) {
+	res := Cantidad{Valor: math.Pow(c.Valor, p)}
+	for _, t := range c.unidad {
+		e := float64(t.exponente) * p
+		if e != math.Trunc(e) {
+			return Cantidad{}, fmt.Errorf("❌ ERROR FATAL: la potencia %v de '%s' no da una unidad válida", p, c.Unidad())
+		}
+		res.unidad = append(res.unidad, terminoUnidad{t.simbolo, int(e)})
+	}
+	res.unidad = combinarTerminos(res.unidad, nil, 1)
+	return res, nil
+}
+
+// Negar cambia el signo conservando la unidad.
+func (c Cantidad) Negar() Cantidad { return Cantidad{Valor: -c.Valor, unidad: c.unidad} }
+
+// Potencia expone potencia() para las funciones de matemáticas (raiz).
+func (c Cantidad) Potencia(p float64) (Cantidad, error) { return c.potencia(p) }
+
+// operarCantidades combina magnitudes físicas: suma y resta exigen la misma
+// dimensión; producto y cociente combinan las unidades.
+func operarCantidades(op token.Token, izq, der interface{}) (interface{}, bool, error) {
+	a, esCantIzq := izq.(Cantidad)
+	b, esCantDer := der.(Cantidad)
+	if !esCantIzq && !esCantDer {
+		return nil, false, nil
+	}
+	if op == token.ADD {
+		_, esIzqString := izq.(string)
+		_, esDerString := der.(string)
+		if esIzqString || esDerString {
+			return nil, false, nil
+		}
+	}
+	// Un número suelto actúa como cantidad adimensional
+	if !esCantIzq {
+		v, err := ConvertirAReal(izq)
+		if err != nil {
+			return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no se puede aplicar entre %s y cantidad", nombreOperador(op), obtenerTipoEnEspañol(izq))
+		}
+		a = Cantidad{Valor: v}
+	}
+	if !esCantDer {
+		v, err := ConvertirAReal(der)
+		if err != nil {
+			return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no se puede aplicar entre cantidad y %s", nombreOperador(op), obtenerTipoEnEspañol(der))
+		}
+		b = Cantidad{Valor: v}
+	}
+
+	mismaDimension := func() error {
+		if a.Dimension() != b.Dimension() {
+			return fmt.Errorf("%w: '%s' entre %s [%s] y %s [%s]", ErrDimensionIncompatible,
+				nombreOperador(op), a.Unidad(), a.Dimension(), b.Unidad(), b.Dimension())
+		}
+		return nil
+	}
+
+	switch op {
+	case token.ADD, token.SUB:
+		if err := mismaDimension(); err != nil {
+			return nil, true, err
+		}
+		// El resultado se expresa en la unidad del operando izquierdo
+		base := a
+		if !esCantIzq {
+			base = b
+		}
+		va, vb := a.EnSI()/base.factor(), b.EnSI()/base.factor()
+		if op == token.SUB {
+			vb = -vb
+		}
+		return Cantidad{Valor: va + vb, unidad: base.unidad}, true, nil
+	case token.MUL, token.AND_NOT:
+		res := Cantidad{Valor: a.Valor * b.Valor, unidad: combinarTerminos(a.unidad, b.unidad, 1)}
+		return res.simplificar(), true, nil
+	case token.QUO:
+		if b.Valor == 0 {
+			return nil, true, errors.New("❌ ERROR FATAL: división por cero")
+		}
+		res := Cantidad{Valor: a.Valor / b.Valor, unidad: combinarTerminos(a.unidad, b.unidad, -1)}
+		return res.simplificar(), true, nil
+	case token.XOR:
+		if esCantDer && !b.Dimension().esAdimensional() {
+			return nil, true, fmt.Errorf("%w: el exponente debe ser adimensional (recibiste %s)", ErrDimensionIncompatible, b.Unidad())
+		}
+		res, err := a.potencia(b.EnSI())
+		if err != nil {
+			return nil, true, err
+		}
+		return res.simplificar(), true, nil
+	case token.EQL, token.NEQ, token.LSS, token.GTR, token.LEQ, token.GEQ:
+		if err := mismaDimension(); err != nil {
+			return nil, true, err
+		}
+		x, y := a.EnSI(), b.EnSI()
+		// Tolerancia relativa: 1 km == 1000 m aunque los factores no sean exactos
+		igual := math.Abs(x-y) <= 1e-12*math.Max(math.Abs(x), math.Abs(y))
+		switch op {
+		case token.EQL:
+			return igual, true, nil
+		case token.NEQ:
+			return !igual, true, nil
+		case token.LSS:
+			return x < y && !igual, true, nil
+		case token.GTR:
+			return x > y && !igual, true, nil
+		case token.LEQ:
+			return x < y || igual, true, nil
+		default:
+			return x > y || igual, true, nil
+		}
+	}
+	return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no está definido para cantidades", nombreOperador(op))
+}
+
+// UnidadesDisponibles lista los símbolos reconocidos (para mensajes de ayuda).
+func UnidadesDisponibles() []string {
+	res := make([]string, 0, len(unidadesConocidas))
+	for s := range unidadesConocidas {
+		res = append(res, s)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// traducirCantidades convierte la notación "5 m/s" en cantidad(5, "m/s")
+// y "30 dias" en duracion(30, "dias").
+// Solo actúa cuando tras el número, separado por espacios, viene una unidad
+// válida pegada (sin espacios internos): "9.8 m/s^2", "100 km/h".
+func traducirCantidades(expr string) string {
+	var b strings.Builder
+	comilla := byte(0)
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		if comilla != 0 {
+			b.WriteByte(c)
+			if c == '\\' && i+1 < len(expr) {
+				b.WriteByte(expr[i+1])
+				i += 2
+				continue
+			}
+			if c == comilla {
+				comilla = 0
+			}
+			i++
+			continue
+		}
+		if c == '"' || c == '\'' || c == '`' {
+			comilla = c
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		if esDigito(c) && (i == 0 || !esParteIdentificador(expr[i-1])) {
+			finNum := finNumero(expr, i)
+			j := finNum
+			for j < len(expr) && expr[j] == ' ' {
+				j++
+			}
+			if j > finNum && j < len(expr) {
+				finUnidad := finTextoUnidad(expr, j)
+				if finUnidad > j {
+					// "30 dias", "2 meses": duraciones de calendario
+					if esPalabraDuracion(expr[j:finUnidad]) {
+						fmt.Fprintf(&b, "duracion(%s, %q)", expr[i:finNum], expr[j:finUnidad])
+						i = finUnidad
+						continue
+					}
+					if _, err := ParsearUnidad(expr[j:finUnidad]); err == nil {
+						fmt.Fprintf(&b, "cantidad(%s, %q)", expr[i:finNum], expr[j:finUnidad])
+						i = finUnidad
+						continue
+					}
+				}
+			}
+			b.WriteString(expr[i:finNum])
+			i = finNum
+			continue
+		}
+		b.WriteByte(c)
+		i++
+	}
+	return b.String()
+}
+
+func esDigito(c byte) bool { return c >= '0' && c <= '9' }
+
+func esParteIdentificador(c byte) bool {
+	return c == '_' || c == '.' || esDigito(c) || unicode.IsLetter(rune(c)) || c >= 0x80
+}
+
+// finNumero avanza sobre un literal numérico (con decimales y exponente).
+func finNumero(s string, i int) int {
+	for i < len(s) && (esDigito(s[i]) || s[i] == '.' || s[i] == '_') {
+		i++
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if j < len(s) && esDigito(s[j]) {
+			i = j
+			for i < len(s) && esDigito(s[i]) {
+				i++
+			}
+		}
+	}
+	return i
+}
+
+// finTextoUnidad avanza sobre una unidad candidata, que debe empezar por letra.
+func finTextoUnidad(s string, i int) int {
+	r := []rune(s[i:])
+	if len(r) == 0 || !(unicode.IsLetter(r[0]) || r[0] == 'µ' || r[0] == 'Ω') {
+		return i
+	}
+	j := i
+	for j < len(s) {
+		c := s[j]
+		switch {
+		case c == '^' && j+1 < len(s) && s[j+1] == '-':
+			j += 2
+		case c == '*' || c == '/' || c == '^' || c == '_' || esDigito(c) || unicode.IsLetter(rune(c)):
+			j++
+		case c >= 0x80:
+			// Letras no ASCII (año, µ, Ω, ·)
+			j++
+		default:
+			return j
+		}
+	}
+	return j
+}
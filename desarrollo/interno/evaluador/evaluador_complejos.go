@@ -0,0 +1,110 @@
+package evaluador
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"math"
+	"math/cmplx"
+
+	"nepa/desarrollo/interno/valores"
+)
+
+// ComoComplejo reconoce un número complejo o cualquier número real
+// (que se promueve con parte imaginaria cero).
+func ComoComplejo(v interface{}) (complex128, bool) {
+	if c, ok := valores.ComoComplejo(v); ok {
+		return c, true
+	}
+	switch v.(type) {
+	case string, bool, nil:
+		return 0, false
+	}
+	if esColeccion(v) {
+		return 0, false
+	}
+	f, err := ConvertirAReal(v)
+	if err != nil {
+		return 0, false
+	}
+	return complex(f, 0), true
+}
+
+func esComplejo(v interface{}) bool {
+	switch v.(type) {
+	case complex128, complex64:
+		return true
+	}
+	return false
+}
+
+// operarComplejos aplica la aritmética compleja cuando alguno de los operandos
+// es un complejo. Devuelve manejado=false en cualquier otro caso.
+func operarComplejos(op token.Token, izq, der interface{}) (interface{}, bool, error) {
+	if !esComplejo(izq) && !esComplejo(der) {
+		return nil, false, nil
+	}
+	// Texto + complejo sigue siendo concatenación
+	if op == token.ADD {
+		_, esIzqString := izq.(string)
+		_, esDerString := der.(string)
+		if esIzqString || esDerString {
+			return nil, false, nil
+		}
+	}
+
+	a, okIzq := ComoComplejo(izq)
+	b, okDer := ComoComplejo(der)
+	if !okIzq || !okDer {
+		return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no se puede aplicar entre %s y %s",
+			nombreOperador(op), obtenerTipoEnEspañol(izq), obtenerTipoEnEspañol(der))
+	}
+
+	switch op {
+	case token.ADD:
+		return a + b, true, nil
+	case token.SUB:
+		return a - b, true, nil
+	case token.MUL, token.AND_NOT:
+		return a * b, true, nil
+	case token.QUO:
+		if b == 0 {
+			return nil, true, errors.New("❌ ERROR FATAL: división por cero")
+		}
+		return a / b, true, nil
+	case token.XOR:
+		if a == 0 && real(b) < 0 {
+			return nil, true, errors.New("❌ ERROR FATAL: división por cero")
+		}
+		return PotenciaCompleja(a, b), true, nil
+	case token.EQL:
+		return a == b, true, nil
+	case token.NEQ:
+		return a != b, true, nil
+	case token.LSS, token.GTR, token.LEQ, token.GEQ:
+		return nil, true, fmt.Errorf("❌ ERROR FATAL: los números complejos no tienen orden, no se puede usar '%s' (compare sus módulos)", nombreOperador(op))
+	}
+	return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no está definido para números complejos", nombreOperador(op))
+}
+
+// PotenciaCompleja calcula a^b. Con exponente entero usa multiplicaciones
+// sucesivas para que (3+4i)^2 dé exactamente -7+24i.
+func PotenciaCompleja(a, b complex128) complex128 {
+	e := real(b)
+	if imag(b) != 0 || e != math.Trunc(e) || math.Abs(e) > 1<<20 {
+		return cmplx.Pow(a, b)
+	}
+	n := int64(math.Abs(e))
+	res, base := complex(1, 0), a
+	for n > 0 {
+		if n%2 == 1 {
+			res *= base
+		}
+		base *= base
+		n /= 2
+	}
+	if e < 0 {
+		return 1 / res
+	}
+	return res
+}
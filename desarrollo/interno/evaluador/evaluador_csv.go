@@ -0,0 +1,590 @@
+package evaluador
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Importar y exportar CSV:
+//   datos = leer_csv("mediciones.csv")                    → lista de registros
+//   m = leer_csv("mediciones.csv", {como: "matriz"})       → matriz numérica
+//   media(columna(datos, "temperatura"))
+//   escribir_csv("salida.csv", datos, {columnas: "fecha, temperatura"})
+//
+// Opciones de leer_csv (todas opcionales):
+//   separador   "," ";" "\t" "|"; por defecto se detecta en la primera línea
+//   decimal     "." o ","; por defecto "," si el separador es ";" y "." en otro caso
+//   encabezado  verdadero/falso; por defecto la primera fila es encabezado si
+//               ninguna de sus celdas es un número o una fecha
+//   tipos       falso deja todas las celdas como texto; con verdadero (por
+//               defecto) cada columna se lee como entero, real o fecha si todas
+//               sus celdas lo son
+//   como        "registros" (lista de diccionarios), "matriz" o "listas"; por
+//               defecto registros si hay encabezado, matriz si todo es numérico
+//               y listas en otro caso
+//   columnas    encabezados a conservar, en ese orden (lista o texto separado
+//               por comas)
+//
+// escribir_csv(ruta, datos, [opciones]) acepta separador, decimal, encabezado
+// y columnas (en registros elige y ordena las claves; en matrices son los
+// encabezados) y devuelve la cantidad de filas escritas.
+
+// opcionesCSV son las opciones ya leídas de leer_csv y escribir_csv.
+type opcionesCSV struct {
+	separador  rune
+	decimal    string
+	encabezado *bool
+	tipos      bool
+	como       string
+	columnas   []string
+}
+
+var separadoresCSV = []rune{',', ';', '\t', '|'}
+
+// formatosFecha son los formatos que se reconocen como fecha al leer.
+var formatosFecha = []string{"2006-01-02", "2006-01-02 15:04:05", time.RFC3339, "02/01/2006"}
+
+// realConMiles reconoce números con punto de miles y coma decimal: 1.234,5
+var realConMiles = regexp.MustCompile(`^[-+]?\d{1,3}(\.\d{3})+(,\d+)?$`)
+
+func leerOpcionesCSV(nombre string, v interface{}) (opcionesCSV, error) {
+	op := opcionesCSV{tipos: true}
+	if v == nil {
+		return op, nil
+	}
+	mapa, ok := v.(map[string]interface{})
+	if !ok {
+		return op, fmt.Errorf("❌ ERROR FATAL: las opciones de '%s' van en un diccionario, ej. {separador: \";\"}", nombre)
+	}
+	for clave, valor := range mapa {
+		switch strings.ToLower(clave) {
+		case "separador":
+			texto, err := comoCadena(nombre, valor)
+			if err != nil {
+				return op, err
+			}
+			runas := []rune(texto)
+			if len(runas) != 1 || runas[0] == '"' || runas[0] == '\n' || runas[0] == '\r' {
+				return op, fmt.Errorf("❌ ERROR FATAL: separador inválido en '%s': %q (use un solo caracter, ej. \";\")", nombre, texto)
+			}
+			op.separador = runas[0]
+		case "decimal":
+			texto, err := comoCadena(nombre, valor)
+			if err != nil {
+				return op, err
+			}
+			if texto != "." && texto != "," {
+				return op, fmt.Errorf("❌ ERROR FATAL: separador decimal inválido en '%s': %q (use \".\" o \",\")", nombre, texto)
+			}
+			op.decimal = texto
+		case "encabezado":
+			b, ok := valor.(bool)
+			if !ok {
+				return op, fmt.Errorf("❌ ERROR FATAL: 'encabezado' en '%s' debe ser verdadero o falso", nombre)
+			}
+			op.encabezado = &b
+		case "tipos":
+			b, ok := valor.(bool)
+			if !ok {
+				return op, fmt.Errorf("❌ ERROR FATAL: 'tipos' en '%s' debe ser verdadero o falso", nombre)
+			}
+			op.tipos = b
+		case "como":
+			texto, err := comoCadena(nombre, valor)
+			if err != nil {
+				return op, err
+			}
+			switch texto = strings.ToLower(texto); texto {
+			case "registros", "matriz", "listas":
+				op.como = texto
+			default:
+				return op, fmt.Errorf("❌ ERROR FATAL: 'como' en '%s' debe ser \"registros\", \"matriz\" o \"listas\"", nombre)
+			}
+		case "columnas":
+			// Lista de encabezados o un texto separado por comas: "fecha, temp"
+			lista, ok := valor.([]interface{})
+			if texto, esTexto := valor.(string); esTexto {
+				ok = true
+				lista = nil
+				for _, c := range strings.Split(texto, ",") {
+					lista = append(lista, strings.TrimSpace(c))
+				}
+			}
+			if !ok {
+				return op, fmt.Errorf("❌ ERROR FATAL: 'columnas' en '%s' debe ser una lista de encabezados o un texto como \"fecha, temp\"", nombre)
+			}
+			for _, c := range lista {
+				texto, err := comoCadena(nombre, c)
+				if err != nil {
+					return op, err
+				}
+				op.columnas = append(op.columnas, texto)
+			}
+		default:
+			return op, fmt.Errorf("❌ ERROR FATAL: opción desconocida '%s' en '%s' (use separador, decimal, encabezado, tipos, como o columnas)", clave, nombre)
+		}
+	}
+	return op, nil
+}
+
+// detectarSeparador elige el candidato que más aparece fuera de comillas en
+// la primera línea.
+func detectarSeparador(texto string) rune {
+	conteo := map[rune]int{}
+	comillas := false
+	for _, r := range texto {
+		if r == '\n' {
+			break
+		}
+		if r == '"' {
+			comillas = !comillas
+		} else if !comillas {
+			conteo[r]++
+		}
+	}
+	mejor := ','
+	for _, s := range separadoresCSV {
+		if conteo[s] > conteo[mejor] {
+			mejor = s
+		}
+	}
+	return mejor
+}
+
+// leerNumero interpreta una celda como entero o real con el separador decimal dado.
+func leerNumero(celda, decimal string) (interface{}, bool) {
+	if n, err := strconv.Atoi(celda); err == nil {
+		return n, true
+	}
+	if decimal == "," {
+		if realConMiles.MatchString(celda) {
+			celda = strings.ReplaceAll(celda, ".", "")
+		} else if strings.Contains(celda, ".") {
+			return nil, false
+		}
+		celda = strings.Replace(celda, ",", ".", 1)
+	}
+	f, err := strconv.ParseFloat(celda, 64)
+	if err != nil || strings.ContainsAny(celda, "xXpP_") || strings.EqualFold(strings.TrimLeft(celda, "+-"), "inf") ||
+		strings.EqualFold(strings.TrimLeft(celda, "+-"), "infinity") || strings.EqualFold(celda, "nan") {
+		return nil, false
+	}
+	return f, true
+}
+
+func leerFecha(celda string) (time.Time, string, bool) {
+	for _, formato := range formatosFecha {
+		if t, err := time.Parse(formato, celda); err == nil {
+			return t, formato, true
+		}
+	}
+	return time.Time{}, "", false
+}
+
+// tipoColumna decide cómo leer una columna: "entero", "real", "fecha:<formato>"
+// o "texto". Las celdas vacías no cuentan; los enteros con ceros a la
+// izquierda (códigos como 007) se dejan como texto.
+func tipoColumna(filas [][]string, col int, decimal string) string {
+	tipo := ""
+	for _, fila := range filas {
+		if col >= len(fila) || fila[col] == "" {
+			continue
+		}
+		celda := fila[col]
+		var tipoCelda string
+		if n, ok := leerNumero(celda, decimal); ok {
+			tipoCelda = "real"
+			if _, esEntero := n.(int); esEntero {
+				if digitos := strings.TrimLeft(celda, "+-"); len(digitos) > 1 && digitos[0] == '0' {
+					return "texto"
+				}
+				tipoCelda = "entero"
+			}
+		} else if _, formato, ok := leerFecha(celda); ok {
+			tipoCelda = "fecha:" + formato
+		} else {
+			return "texto"
+		}
+		switch {
+		case tipo == "" || tipo == tipoCelda:
+			tipo = tipoCelda
+		case tipo == "entero" && tipoCelda == "real", tipo == "real" && tipoCelda == "entero":
+			tipo = "real"
+		default:
+			return "texto"
+		}
+	}
+	if tipo == "" {
+		return "texto"
+	}
+	return tipo
+}
+
+func convertirCelda(celda, tipo, decimal string) interface{} {
+	if celda == "" {
+		return nil
+	}
+	switch {
+	case tipo == "entero":
+		n, _ := strconv.Atoi(celda)
+		return n
+	case tipo == "real":
+		n, _ := leerNumero(celda, decimal)
+		if entero, ok := n.(int); ok {
+			return float64(entero)
+		}
+		return n
+	case strings.HasPrefix(tipo, "fecha:"):
+		t, _ := time.Parse(strings.TrimPrefix(tipo, "fecha:"), celda)
+		return t
+	}
+	return celda
+}
+
+// pareceEncabezado: ninguna celda de la primera fila es un número o una fecha.
+func pareceEncabezado(fila []string, decimal string) bool {
+	for _, celda := range fila {
+		if _, ok := leerNumero(celda, decimal); ok {
+			return false
+		}
+		if _, _, ok := leerFecha(celda); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// LeerCSV lee un archivo CSV según las opciones de leer_csv.
+func LeerCSV(ruta string, opciones interface{}) (interface{}, error) {
+	const nombre = "leer_csv"
+	op, err := leerOpcionesCSV(nombre, opciones)
+	if err != nil {
+		return nil, err
+	}
+	datos, err := os.ReadFile(ruta)
+	if err != nil {
+		return nil, errorArchivo(nombre, ruta, err)
+	}
+	datos = bytes.TrimPrefix(datos, []byte("\ufeff"))
+	if op.separador == 0 {
+		op.separador = detectarSeparador(string(datos))
+	}
+	if op.decimal == "" {
+		op.decimal = "."
+		if op.separador == ';' {
+			op.decimal = ","
+		}
+	}
+
+	lector := csv.NewReader(bytes.NewReader(datos))
+	lector.Comma = op.separador
+	lector.FieldsPerRecord = -1
+	lector.TrimLeadingSpace = true
+	lector.LazyQuotes = true
+	var filas [][]string
+	var lineas []int // línea del archivo donde empieza cada fila
+	for {
+		fila, err := lector.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("❌ ERROR FATAL: '%s' no pudo leer '%s': %v", nombre, ruta, err)
+		}
+		for i := range fila {
+			fila[i] = strings.TrimSpace(fila[i])
+		}
+		linea, _ := lector.FieldPos(0)
+		filas, lineas = append(filas, fila), append(lineas, linea)
+	}
+
+	var encabezados []string
+	conEncabezado := len(filas) > 0 && pareceEncabezado(filas[0], op.decimal)
+	if op.encabezado != nil {
+		conEncabezado = *op.encabezado && len(filas) > 0
+	}
+	if conEncabezado {
+		encabezados, filas, lineas = filas[0], filas[1:], lineas[1:]
+		// Una fila más ancha que el encabezado no tiene dónde guardar lo que
+		// sobra; los separadores finales sin contenido se toleran.
+		for i, fila := range filas {
+			for j := len(encabezados); j < len(fila); j++ {
+				if fila[j] != "" {
+					return nil, fmt.Errorf("❌ ERROR FATAL: '%s': la línea %d de '%s' tiene %d columnas y el encabezado solo %d", nombre, lineas[i], ruta, len(fila), len(encabezados))
+				}
+			}
+		}
+	}
+
+	// columnas: índices de los encabezados pedidos, en ese orden
+	indices := []int{}
+	if op.columnas != nil {
+		if !conEncabezado {
+			return nil, fmt.Errorf("❌ ERROR FATAL: '%s' solo puede elegir columnas por nombre si el archivo tiene encabezado", nombre)
+		}
+		for _, c := range op.columnas {
+			pos := -1
+			for i, e := range encabezados {
+				if e == c {
+					pos = i
+					break
+				}
+			}
+			if pos < 0 {
+				return nil, fmt.Errorf("❌ ERROR FATAL: '%s' no encontró la columna '%s' en '%s'", nombre, c, ruta)
+			}
+			indices = append(indices, pos)
+		}
+	} else {
+		ancho := len(encabezados)
+		for _, fila := range filas {
+			if !conEncabezado && len(fila) > ancho {
+				ancho = len(fila)
+			}
+		}
+		for i := 0; i < ancho; i++ {
+			indices = append(indices, i)
+		}
+	}
+
+	tipos := make([]string, len(indices))
+	numerica := true
+	for j, col := range indices {
+		tipos[j] = "texto"
+		if op.tipos {
+			tipos[j] = tipoColumna(filas, col, op.decimal)
+		}
+		numerica = numerica && (tipos[j] == "entero" || tipos[j] == "real")
+	}
+
+	como := op.como
+	if como == "" {
+		switch {
+		case conEncabezado:
+			como = "registros"
+		case numerica && len(filas) > 0:
+			como = "matriz"
+		default:
+			como = "listas"
+		}
+	}
+
+	switch como {
+	case "matriz":
+		m := make([][]float64, len(filas))
+		for i, fila := range filas {
+			m[i] = make([]float64, len(indices))
+			for j, col := range indices {
+				celda := ""
+				if col < len(fila) {
+					celda = fila[col]
+				}
+				n, ok := leerNumero(celda, op.decimal)
+				if !ok {
+					return nil, fmt.Errorf("❌ ERROR FATAL: '%s' no puede formar una matriz: la celda %q (fila %d, columna %d) no es un número", nombre, celda, i+1, col+1)
+				}
+				m[i][j], _ = ConvertirAReal(n)
+			}
+		}
+		return m, nil
+
+	case "registros":
+		if !conEncabezado {
+			return nil, fmt.Errorf("❌ ERROR FATAL: '%s' necesita un encabezado para devolver registros", nombre)
+		}
+		res := make([]interface{}, len(filas))
+		for i, fila := range filas {
+			registro := make(map[string]interface{}, len(indices))
+			for j, col := range indices {
+				clave := ""
+				if col < len(encabezados) {
+					clave = encabezados[col]
+				}
+				if clave == "" {
+					clave = strconv.Itoa(col + 1)
+				}
+				var valor interface{}
+				if col < len(fila) {
+					valor = convertirCelda(fila[col], tipos[j], op.decimal)
+				}
+				registro[clave] = valor
+			}
+			res[i] = registro
+		}
+		return res, nil
+	}
+
+	res := make([]interface{}, len(filas))
+	for i, fila := range filas {
+		celdas := make([]interface{}, len(indices))
+		for j, col := range indices {
+			if col < len(fila) {
+				celdas[j] = convertirCelda(fila[col], tipos[j], op.decimal)
+			}
+		}
+		res[i] = celdas
+	}
+	return res, nil
+}
+
+// celdaCSV escribe un valor con el separador decimal pedido.
+func celdaCSV(v interface{}, decimal string) string {
+	var texto string
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64, Decimal:
+		texto = FormatearValor(x)
+		if decimal == "," {
+			texto = strings.Replace(texto, ".", ",", 1)
+		}
+		return texto
+	}
+	return FormatearValor(v)
+}
+
+// EscribirCSV escribe una matriz, una lista de registros o una lista de
+// listas según las opciones de escribir_csv; devuelve las filas escritas.
+func EscribirCSV(ruta string, datos interface{}, opciones interface{}) (int, error) {
+	const nombre = "escribir_csv"
+	op, err := leerOpcionesCSV(nombre, opciones)
+	if err != nil {
+		return 0, err
+	}
+	if op.separador == 0 {
+		op.separador = ','
+	}
+	if op.decimal == "" {
+		op.decimal = "."
+	}
+	if op.decimal == "," && op.separador == ',' {
+		return 0, fmt.Errorf("❌ ERROR FATAL: '%s' no puede usar coma decimal con coma como separador (use {separador: \";\"})", nombre)
+	}
+	if lista, ok := datos.([]interface{}); ok && esListaDeRegistros(lista) && op.columnas != nil {
+		for _, c := range op.columnas {
+			existe := false
+			for _, r := range lista {
+				if _, existe = r.(map[string]interface{})[c]; existe {
+					break
+				}
+			}
+			if !existe {
+				return 0, fmt.Errorf("❌ ERROR FATAL: '%s' no encontró la columna '%s' en los registros", nombre, c)
+			}
+		}
+	}
+	encabezados, crudas, err := filasDeDatos(nombre, datos, op.columnas)
+	if err != nil {
+		return 0, err
+	}
+
+	var b bytes.Buffer
+	escritor := csv.NewWriter(&b)
+	escritor.Comma = op.separador
+	if len(encabezados) > 0 && (op.encabezado == nil || *op.encabezado) {
+		if err := escritor.Write(encabezados); err != nil {
+			return 0, fmt.Errorf("❌ ERROR FATAL: '%s' no pudo escribir '%s': %v", nombre, ruta, err)
+		}
+	}
+	for _, fila := range crudas {
+		textos := make([]string, len(fila))
+		for i, v := range fila {
+			textos[i] = celdaCSV(v, op.decimal)
+		}
+		if err := escritor.Write(textos); err != nil {
+			return 0, fmt.Errorf("❌ ERROR FATAL: '%s' no pudo escribir '%s': %v", nombre, ruta, err)
+		}
+	}
+	escritor.Flush()
+	if err := os.WriteFile(ruta, b.Bytes(), 0o644); err != nil {
+		return 0, errorArchivo(nombre, ruta, err)
+	}
+	return len(crudas), nil
+}
+
+func init() {
+	// leer_csv(ruta, [opciones]) -> registros, matriz o listas (ver opciones arriba)
+	Funciones["leer_csv"] = func(args ...interface{}) (interface{}, error) {
+		ruta, err := argumentosRuta("leer_csv", args, 1, 2)
+		if err != nil {
+			return nil, err
+		}
+		var opciones interface{}
+		if len(args) == 2 {
+			opciones = args[1]
+		}
+		return LeerCSV(ruta, opciones)
+	}
+
+	// escribir_csv(ruta, datos, [opciones]) -> cantidad de filas escritas
+	Funciones["escribir_csv"] = func(args ...interface{}) (interface{}, error) {
+		ruta, err := argumentosRuta("escribir_csv", args, 2, 3)
+		if err != nil {
+			return nil, err
+		}
+		var opciones interface{}
+		if len(args) == 3 {
+			opciones = args[2]
+		}
+		filas, err := EscribirCSV(ruta, args[1], opciones)
+		if err != nil {
+			return nil, err
+		}
+		return filas, nil
+	}
+
+	// columna(datos, nombre|indice) -> lista con esa columna de una lista de
+	// registros (por nombre) o de una matriz o lista de listas (índice desde 0)
+	Funciones["columna"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'columna' requiere los datos y el nombre o índice de la columna")
+		}
+		if clave, esTexto := args[1].(string); esTexto {
+			lista, ok := args[0].([]interface{})
+			if !ok || !esListaDeRegistros(lista) {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'columna' por nombre requiere una lista de registros, recibió %s", obtenerTipoEnEspañol(args[0]))
+			}
+			res := make([]interface{}, len(lista))
+			existe := false
+			for i, r := range lista {
+				var ok bool
+				res[i], ok = r.(map[string]interface{})[clave]
+				existe = existe || ok
+			}
+			if !existe {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'columna' no encontró '%s' en los registros", clave)
+			}
+			return res, nil
+		}
+		indice, err := comoEnteroPequeño("columna", args[1])
+		if err != nil {
+			return nil, err
+		}
+		_, filas, err := filasDeDatos("columna", args[0], nil)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]interface{}, len(filas))
+		for i, fila := range filas {
+			if indice < 0 || indice >= len(fila) {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'columna' fuera de rango: %d (la fila %d tiene %d columnas)", indice, i+1, len(fila))
+			}
+			res[i] = fila[indice]
+		}
+		return res, nil
+	}
+
+	// Métodos: datos.columna("temperatura"), m.columna(0)
+	for _, tipo := range []string{"lista", "objeto"} {
+		Funciones[tipo+".columna"] = Funciones["columna"]
+	}
+}
@@ -0,0 +1,81 @@
+package evaluador
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCSVIdaYVuelta(t *testing.T) {
+	registros := []interface{}{
+		map[string]interface{}{"nombre": "Ana", "edad": 31, "saldo": 1250.5},
+		map[string]interface{}{"nombre": "Luis, hijo", "edad": 8, "saldo": -3.25},
+		map[string]interface{}{"nombre": "Sofía \"Sofi\"", "edad": 45, "saldo": 0.0},
+	}
+	casos := []struct {
+		nombre   string
+		opciones map[string]interface{}
+	}{
+		{"coma", nil},
+		{"punto y coma con coma decimal", map[string]interface{}{"separador": ";", "decimal": ","}},
+		{"tabulador", map[string]interface{}{"separador": "\t"}},
+	}
+	for _, c := range casos {
+		ruta := filepath.Join(t.TempDir(), "datos.csv")
+		var opciones interface{}
+		if c.opciones != nil {
+			opciones = c.opciones
+		}
+		n, err := EscribirCSV(ruta, registros, opciones)
+		if err != nil || n != len(registros) {
+			t.Fatalf("%s: EscribirCSV = %d, %v", c.nombre, n, err)
+		}
+		leidos, err := LeerCSV(ruta, opciones)
+		if err != nil {
+			t.Fatalf("%s: LeerCSV: %v", c.nombre, err)
+		}
+		if !reflect.DeepEqual(leidos, registros) {
+			contenido, _ := os.ReadFile(ruta)
+			t.Errorf("%s: se leyó %#v\ndel archivo:\n%s", c.nombre, leidos, contenido)
+		}
+	}
+}
+
+func TestCSVMatriz(t *testing.T) {
+	ruta := filepath.Join(t.TempDir(), "m.csv")
+	if err := os.WriteFile(ruta, []byte("\ufeff1;2,5;7\n3;4;8\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LeerCSV(ruta, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	esperado := [][]float64{{1, 2.5, 7}, {3, 4, 8}}
+	if !reflect.DeepEqual(m, esperado) {
+		t.Errorf("LeerCSV = %#v, se esperaba %#v", m, esperado)
+	}
+}
+
+func TestCSVFilaMasAnchaQueEncabezado(t *testing.T) {
+	ruta := filepath.Join(t.TempDir(), "f.csv")
+	contenido := "nombre,edad\nAna,31\n\"Luis\nPérez\",8\nSofía,45,extra\n"
+	if err := os.WriteFile(ruta, []byte(contenido), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LeerCSV(ruta, nil)
+	if err == nil || !strings.Contains(err.Error(), "línea 5") {
+		t.Errorf("una fila con más columnas que el encabezado debía fallar nombrando la línea 5: %v", err)
+	}
+
+	// Un separador final sin contenido no es una columna de más
+	if err := os.WriteFile(ruta, []byte("nombre,edad\nAna,31,\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	datos, err := LeerCSV(ruta, nil)
+	esperado := []interface{}{map[string]interface{}{"nombre": "Ana", "edad": 31}}
+	if err != nil || !reflect.DeepEqual(datos, esperado) {
+		t.Errorf("LeerCSV = %#v, %v", datos, err)
+	}
+}
@@ -0,0 +1,431 @@
+package evaluador
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal es un número exacto en base 10: coeficiente entero × 10^-escala.
+// 0.1 + 0.2 da exactamente 0.3, como esperan los cálculos contables.
+// Es inmutable: cada operación devuelve un valor nuevo.
+type Decimal struct {
+	coef   *big.Int
+	escala int
+}
+
+// ModoRedondeo indica cómo se descartan los dígitos sobrantes.
+type ModoRedondeo int
+
+const (
+	RedondeoMitadPar    ModoRedondeo = iota // 2.5 → 2, 3.5 → 4 (bancario)
+	RedondeoMitadArriba                     // 2.5 → 3, -2.5 → -3
+	RedondeoTruncar                         // 2.9 → 2, -2.9 → -2
+)
+
+// ConfigDecimal controla las operaciones inexactas (división, potencias
+// negativas): cuántos decimales conservar y cómo redondear.
+var ConfigDecimal = struct {
+	Escala   int
+	Redondeo ModoRedondeo
+}{Escala: 28, Redondeo: RedondeoMitadPar}
+
+var (
+	ErrDecimalInvalido  = errors.New("❌ ERROR FATAL: número decimal inválido")
+	ErrModoRedondeo     = errors.New("❌ ERROR FATAL: modo de redondeo desconocido (use 'mitad_par', 'mitad_arriba' o 'truncar')")
+	diez                = big.NewInt(10)
+	maxEscalaDecimal    = 1000
+	maxExponenteDecimal = 100000
+)
+
+// ModoRedondeoDesdeNombre traduce el nombre que escribe el usuario.
+func ModoRedondeoDesdeNombre(nombre string) (ModoRedondeo, error) {
+	switch strings.ToLower(strings.TrimSpace(nombre)) {
+	case "mitad_par", "bancario", "half_even":
+		return RedondeoMitadPar, nil
+	case "mitad_arriba", "half_up":
+		return RedondeoMitadArriba, nil
+	case "truncar", "truncate":
+		return RedondeoTruncar, nil
+	}
+	return 0, fmt.Errorf("%w → %s", ErrModoRedondeo, nombre)
+}
+
+func (m ModoRedondeo) String() string {
+	switch m {
+	case RedondeoMitadArriba:
+		return "mitad_arriba"
+	case RedondeoTruncar:
+		return "truncar"
+	}
+	return "mitad_par"
+}
+
+// NuevoDecimal interpreta textos como "12.50", "-0.001" o "1.5e3".
+func NuevoDecimal(texto string) (Decimal, error) {
+	s := strings.ReplaceAll(strings.TrimSpace(texto), "_", "")
+	exponente := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return Decimal{}, fmt.Errorf("%w → %s", ErrDecimalInvalido, texto)
+		}
+		if e > maxExponenteDecimal || e < -maxExponenteDecimal {
+			return Decimal{}, fmt.Errorf("❌ ERROR FATAL: exponente decimal fuera de rango (±%d) → %s", maxExponenteDecimal, texto)
+		}
+		exponente = e
+		s = s[:i]
+	}
+	escala := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		escala = len(s) - i - 1
+		s = s[:i] + s[i+1:]
+	}
+	if s == "" || s == "-" || s == "+" || strings.ContainsAny(s, ".eE") {
+		return Decimal{}, fmt.Errorf("%w → %s", ErrDecimalInvalido, texto)
+	}
+	coef, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%w → %s", ErrDecimalInvalido, texto)
+	}
+	escala -= exponente
+	if escala > maxExponenteDecimal || -escala > maxExponenteDecimal {
+		return Decimal{}, fmt.Errorf("❌ ERROR FATAL: número decimal fuera de rango (más de %d cifras) → %s", maxExponenteDecimal, texto)
+	}
+	if escala < 0 {
+		coef.Mul(coef, potenciaDiez(-escala))
+		escala = 0
+	}
+	return Decimal{coef: coef, escala: escala}, nil
+}
+
+// DecimalDesdeEntero crea un decimal exacto sin parte fraccionaria.
+func DecimalDesdeEntero(n int64) Decimal {
+	return Decimal{coef: big.NewInt(n)}
+}
+
+// DecimalDesdeReal usa la representación decimal más corta del real,
+// de modo que 0.1 se convierte en 0.1 y no en 0.1000000000000000055...
+func DecimalDesdeReal(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("%w → %v", ErrDecimalInvalido, f)
+	}
+	return NuevoDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// ComoDecimal convierte decimales, enteros, reales y cadenas numéricas.
+func ComoDecimal(v interface{}) (Decimal, bool) {
+	switch x := v.(type) {
+	case Decimal:
+		return x, true
+	case *Decimal:
+		return *x, x != nil
+	case int:
+		return DecimalDesdeEntero(int64(x)), true
+	case int32:
+		return DecimalDesdeEntero(int64(x)), true
+	case int64:
+		return DecimalDesdeEntero(x), true
+	case float32:
+		d, err := DecimalDesdeReal(float64(x))
+		return d, err == nil
+	case float64:
+		d, err := DecimalDesdeReal(x)
+		return d, err == nil
+	case string:
+		d, err := NuevoDecimal(x)
+		return d, err == nil
+	case *big.Int:
+		return Decimal{coef: new(big.Int).Set(x)}, x != nil
+	case *big.Rat:
+		if x == nil {
+			return Decimal{}, false
+		}
+		d, err := Decimal{coef: new(big.Int).Set(x.Num())}.Dividir(Decimal{coef: new(big.Int).Set(x.Denom())})
+		return d, err == nil
+	}
+	return Decimal{}, false
+}
+
+func esDecimal(v interface{}) bool {
+	switch v.(type) {
+	case Decimal, *Decimal:
+		return true
+	}
+	return false
+}
+
+func potenciaDiez(n int) *big.Int {
+	return new(big.Int).Exp(diez, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) c() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// Escala devuelve el número de dígitos después del punto.
+func (d Decimal) Escala() int { return d.escala }
+
+// Signo devuelve -1, 0 o 1.
+func (d Decimal) Signo() int { return d.c().Sign() }
+
+// EsCero indica si el valor es exactamente cero.
+func (d Decimal) EsCero() bool { return d.Signo() == 0 }
+
+// String muestra todos los dígitos de la escala: 12.50 sigue siendo 12.50.
+func (d Decimal) String() string {
+	digitos := new(big.Int).Abs(d.c()).String()
+	signo := ""
+	if d.Signo() < 0 {
+		signo = "-"
+	}
+	if d.escala <= 0 {
+		return signo + digitos
+	}
+	if len(digitos) <= d.escala {
+		digitos = strings.Repeat("0", d.escala-len(digitos)+1) + digitos
+	}
+	corte := len(digitos) - d.escala
+	return signo + digitos[:corte] + "." + digitos[corte:]
+}
+
+// Float64 aproxima el decimal al real más cercano.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// ConEscala lleva el valor a exactamente 'escala' decimales, rellenando con
+// ceros o redondeando según 'modo'.
+func (d Decimal) ConEscala(escala int, modo ModoRedondeo) Decimal {
+	if escala < 0 {
+		escala = 0
+	}
+	if escala >= d.escala {
+		return Decimal{coef: new(big.Int).Mul(d.c(), potenciaDiez(escala-d.escala)), escala: escala}
+	}
+	return Decimal{coef: dividirRedondeando(d.c(), potenciaDiez(d.escala-escala), modo), escala: escala}
+}
+
+// dividirRedondeando calcula n/m (m > 0) redondeando el cociente según 'modo'.
+func dividirRedondeando(n, m *big.Int, modo ModoRedondeo) *big.Int {
+	q, r := new(big.Int).QuoRem(n, m, new(big.Int))
+	if r.Sign() == 0 || modo == RedondeoTruncar {
+		return q
+	}
+	doble := new(big.Int).Abs(r)
+	doble.Lsh(doble, 1)
+	cmp := doble.Cmp(new(big.Int).Abs(m))
+	alejar := cmp > 0 || (cmp == 0 && (modo == RedondeoMitadArriba || q.Bit(0) == 1))
+	if alejar {
+		if n.Sign()*m.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// normalizar quita ceros finales sin bajar de 'minimo' decimales.
+func (d Decimal) normalizar(minimo int) Decimal {
+	coef, escala := new(big.Int).Set(d.c()), d.escala
+	r := new(big.Int)
+	for escala > minimo && coef.Sign() != 0 {
+		q, _ := new(big.Int).QuoRem(coef, diez, r)
+		if r.Sign() != 0 {
+			break
+		}
+		coef, escala = q, escala-1
+	}
+	if coef.Sign() == 0 && escala > minimo {
+		escala = minimo
+	}
+	return Decimal{coef: coef, escala: escala}
+}
+
+func alinear(a, b Decimal) (*big.Int, *big.Int, int) {
+	if a.escala == b.escala {
+		return a.c(), b.c(), a.escala
+	}
+	if a.escala > b.escala {
+		return a.c(), new(big.Int).Mul(b.c(), potenciaDiez(a.escala-b.escala)), a.escala
+	}
+	return new(big.Int).Mul(a.c(), potenciaDiez(b.escala-a.escala)), b.c(), b.escala
+}
+
+// Sumar devuelve d + o (exacto).
+func (d Decimal) Sumar(o Decimal) Decimal {
+	x, y, e := alinear(d, o)
+	return Decimal{coef: new(big.Int).Add(x, y), escala: e}
+}
+
+// Restar devuelve d - o (exacto).
+func (d Decimal) Restar(o Decimal) Decimal {
+	x, y, e := alinear(d, o)
+	return Decimal{coef: new(big.Int).Sub(x, y), escala: e}
+}
+
+// Multiplicar devuelve d × o; exacto mientras no supere la escala configurada.
+func (d Decimal) Multiplicar(o Decimal) Decimal {
+	res := Decimal{coef: new(big.Int).Mul(d.c(), o.c()), escala: d.escala + o.escala}
+	if res.escala > ConfigDecimal.Escala {
+		res = res.ConEscala(ConfigDecimal.Escala, ConfigDecimal.Redondeo).normalizar(0)
+	}
+	return res
+}
+
+// Dividir devuelve d / o con ConfigDecimal.Escala decimales como máximo.
+func (d Decimal) Dividir(o Decimal) (Decimal, error) {
+	if o.EsCero() {
+		return Decimal{}, errors.New("❌ ERROR FATAL: división por cero")
+	}
+	escala := ConfigDecimal.Escala
+	// d/o = (cd·10^(escala + eo - ed)) / co  × 10^-escala
+	n, m := new(big.Int).Set(d.c()), new(big.Int).Set(o.c())
+	if ajuste := escala + o.escala - d.escala; ajuste >= 0 {
+		n.Mul(n, potenciaDiez(ajuste))
+	} else {
+		m.Mul(m, potenciaDiez(-ajuste))
+	}
+	if m.Sign() < 0 {
+		n.Neg(n)
+		m.Neg(m)
+	}
+	q := dividirRedondeando(n, m, ConfigDecimal.Redondeo)
+	minimo := d.escala
+	if o.escala > minimo {
+		minimo = o.escala
+	}
+	return Decimal{coef: q, escala: escala}.normalizar(minimo), nil
+}
+
+// Resto devuelve el residuo de la división truncada (con el signo de d).
+func (d Decimal) Resto(o Decimal) (Decimal, error) {
+	if o.EsCero() {
+		return Decimal{}, errors.New("❌ ERROR FATAL: módulo por cero")
+	}
+	x, y, e := alinear(d, o)
+	return Decimal{coef: new(big.Int).Rem(x, y), escala: e}, nil
+}
+
+// Potencia eleva a un exponente entero; los negativos dividen.
+func (d Decimal) Potencia(n int) (Decimal, error) {
+	if n > maxExponenteDecimal || n < -maxExponenteDecimal {
+		return Decimal{}, fmt.Errorf("❌ ERROR FATAL: exponente decimal demasiado grande (%d)", n)
+	}
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	res, base := DecimalDesdeEntero(1), d
+	for n > 0 {
+		if n%2 == 1 {
+			res = res.Multiplicar(base)
+		}
+		n /= 2
+		if n > 0 {
+			base = base.Multiplicar(base)
+		}
+	}
+	if neg {
+		return DecimalDesdeEntero(1).Dividir(res)
+	}
+	return res, nil
+}
+
+// Negar devuelve -d.
+func (d Decimal) Negar() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.c()), escala: d.escala}
+}
+
+// Comparar devuelve -1, 0 o 1 según d sea menor, igual o mayor que o.
+func (d Decimal) Comparar(o Decimal) int {
+	x, y, _ := alinear(d, o)
+	return x.Cmp(y)
+}
+
+// Redondear es ConEscala con validación de escala, para las funciones del usuario.
+func (d Decimal) Redondear(escala int, modo ModoRedondeo) (Decimal, error) {
+	if escala < 0 || escala > maxEscalaDecimal {
+		return Decimal{}, fmt.Errorf("❌ ERROR FATAL: escala decimal fuera de rango (0..%d): %d", maxEscalaDecimal, escala)
+	}
+	return d.ConEscala(escala, modo), nil
+}
+
+// operarDecimales mantiene el tipo decimal cuando alguno de los operandos lo es.
+// Devuelve manejado=false si ninguno es decimal.
+func operarDecimales(op token.Token, izq, der interface{}) (interface{}, bool, error) {
+	if !esDecimal(izq) && !esDecimal(der) {
+		return nil, false, nil
+	}
+	// Texto + decimal sigue siendo concatenación
+	if op == token.ADD {
+		_, esIzqString := izq.(string)
+		_, esDerString := der.(string)
+		if esIzqString || esDerString {
+			return nil, false, nil
+		}
+	}
+	if op == token.LAND || op == token.LOR {
+		return nil, false, nil
+	}
+
+	a, okIzq := ComoDecimal(izq)
+	b, okDer := ComoDecimal(der)
+	if !okIzq || !okDer {
+		return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no se puede aplicar entre %s y %s",
+			nombreOperador(op), obtenerTipoEnEspañol(izq), obtenerTipoEnEspañol(der))
+	}
+
+	switch op {
+	case token.ADD:
+		return a.Sumar(b), true, nil
+	case token.SUB:
+		return a.Restar(b), true, nil
+	case token.MUL, token.AND_NOT:
+		return a.Multiplicar(b), true, nil
+	case token.QUO:
+		res, err := a.Dividir(b)
+		if err != nil {
+			return nil, true, err
+		}
+		return res, true, nil
+	case token.REM:
+		res, err := a.Resto(b)
+		if err != nil {
+			return nil, true, err
+		}
+		return res, true, nil
+	case token.XOR:
+		entero := b.normalizar(0)
+		if entero.escala != 0 || !entero.c().IsInt64() {
+			return nil, true, fmt.Errorf("❌ ERROR FATAL: la potencia de un decimal requiere un exponente entero (recibiste %s)", b)
+		}
+		res, err := a.Potencia(int(entero.c().Int64()))
+		if err != nil {
+			return nil, true, err
+		}
+		return res, true, nil
+	case token.EQL:
+		return a.Comparar(b) == 0, true, nil
+	case token.NEQ:
+		return a.Comparar(b) != 0, true, nil
+	case token.LSS:
+		return a.Comparar(b) < 0, true, nil
+	case token.GTR:
+		return a.Comparar(b) > 0, true, nil
+	case token.LEQ:
+		return a.Comparar(b) <= 0, true, nil
+	case token.GEQ:
+		return a.Comparar(b) >= 0, true, nil
+	}
+	return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no está definido para decimales", nombreOperador(op))
+}
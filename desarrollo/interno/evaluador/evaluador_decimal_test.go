@@ -0,0 +1,108 @@
+package evaluador
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNuevoDecimal(t *testing.T) {
+	casos := []struct {
+		texto  string
+		valor  string
+		escala int
+	}{
+		{"12.50", "12.50", 2},
+		{"-0.001", "-0.001", 3},
+		{"1.5e3", "1500", 0},
+		{"1_000.25", "1000.25", 2},
+		{"2.5E-2", "0.025", 3},
+		{"1e100000", "", 0},
+	}
+	for _, c := range casos {
+		d, err := NuevoDecimal(c.texto)
+		if err != nil {
+			t.Errorf("NuevoDecimal(%q): error inesperado %v", c.texto, err)
+			continue
+		}
+		if c.valor != "" && d.String() != c.valor {
+			t.Errorf("NuevoDecimal(%q) = %s, se esperaba %s", c.texto, d, c.valor)
+		}
+		if c.valor != "" && d.Escala() != c.escala {
+			t.Errorf("NuevoDecimal(%q): escala %d, se esperaba %d", c.texto, d.Escala(), c.escala)
+		}
+	}
+}
+
+func TestNuevoDecimalFueraDeRango(t *testing.T) {
+	for _, texto := range []string{"", "-", "1.2.3", "abc", "1e", "1e1000000000", "1e-2000000000", "1e100001", "1e-100001"} {
+		if _, err := NuevoDecimal(texto); err == nil {
+			t.Errorf("NuevoDecimal(%q) debía fallar", texto)
+		}
+	}
+}
+
+func TestDecimalConEscala(t *testing.T) {
+	casos := []struct {
+		texto  string
+		escala int
+		modo   ModoRedondeo
+		valor  string
+	}{
+		{"2.5", 0, RedondeoMitadPar, "2"},
+		{"3.5", 0, RedondeoMitadPar, "4"},
+		{"-2.5", 0, RedondeoMitadPar, "-2"},
+		{"2.5", 0, RedondeoMitadArriba, "3"},
+		{"-2.5", 0, RedondeoMitadArriba, "-3"},
+		{"2.9", 0, RedondeoTruncar, "2"},
+		{"-2.9", 0, RedondeoTruncar, "-2"},
+		{"1.005", 2, RedondeoMitadPar, "1.00"},
+		{"1.005", 2, RedondeoMitadArriba, "1.01"},
+		{"1.0051", 2, RedondeoMitadPar, "1.01"},
+		{"7", 3, RedondeoMitadPar, "7.000"},
+	}
+	for _, c := range casos {
+		d, err := NuevoDecimal(c.texto)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := d.ConEscala(c.escala, c.modo).String(); got != c.valor {
+			t.Errorf("%s.ConEscala(%d, %s) = %s, se esperaba %s", c.texto, c.escala, c.modo, got, c.valor)
+		}
+	}
+}
+
+func TestDecimalDividir(t *testing.T) {
+	uno, tres := DecimalDesdeEntero(1), DecimalDesdeEntero(3)
+	d, err := uno.Dividir(tres)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, esperado := d.String(), "0."+repetirDigito('3', ConfigDecimal.Escala); got != esperado {
+		t.Errorf("1/3 = %s, se esperaba %s", got, esperado)
+	}
+	if _, err := uno.Dividir(DecimalDesdeEntero(0)); err == nil {
+		t.Error("dividir entre cero debía fallar")
+	}
+	if _, err := uno.Redondear(maxEscalaDecimal+1, RedondeoMitadPar); err == nil {
+		t.Error("Redondear con una escala fuera de rango debía fallar")
+	}
+}
+
+func repetirDigito(c byte, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = c
+	}
+	return string(b)
+}
+
+func TestModoRedondeoDesdeNombre(t *testing.T) {
+	for nombre, modo := range map[string]ModoRedondeo{"bancario": RedondeoMitadPar, "MITAD_ARRIBA": RedondeoMitadArriba, " truncar ": RedondeoTruncar} {
+		if m, err := ModoRedondeoDesdeNombre(nombre); err != nil || m != modo {
+			t.Errorf("ModoRedondeoDesdeNombre(%q) = %v, %v", nombre, m, err)
+		}
+	}
+	if _, err := ModoRedondeoDesdeNombre("hacia_cero"); !errors.Is(err, ErrModoRedondeo) {
+		t.Errorf("se esperaba ErrModoRedondeo, se obtuvo %v", err)
+	}
+}
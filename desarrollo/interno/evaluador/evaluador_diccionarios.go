@@ -0,0 +1,99 @@
+package evaluador
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Los diccionarios literales se escriben {"x": 2, "y": 3}. go/parser solo
+// acepta llaves tras un tipo, así que prepararExpresion antepone el tipo
+// interno __diccionario y la llave queda como un literal compuesto.
+
+const identificadorDiccionario = "__diccionario"
+
+var ErrDiccionarioInvalido = errors.New("❌ ERROR FATAL: diccionario inválido")
+
+// traducirDiccionarios convierte cada '{' que abre un valor en '__diccionario{'.
+func traducirDiccionarios(expr string) string {
+	if !strings.Contains(expr, "{") {
+		return expr
+	}
+	var b strings.Builder
+	comilla := byte(0)
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case comilla != 0:
+			if c == '\\' && i+1 < len(expr) {
+				b.WriteByte(c)
+				i++
+				c = expr[i]
+			} else if c == comilla {
+				comilla = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			comilla = c
+		case c == '{' && (i == 0 || !esParteIdentificador(expr[i-1])):
+			b.WriteString(identificadorDiccionario)
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// evaluarDiccionario construye un map[string]interface{}; las claves pueden ir
+// entre comillas o como nombres sueltos ({x: 2}).
+func evaluarDiccionario(n *ast.CompositeLit, ctx *Contexto) (interface{}, error) {
+	if tipo, ok := n.Type.(*ast.Ident); !ok || tipo.Name != identificadorDiccionario {
+		return nil, ErrExpresionInvalida
+	}
+	res := make(map[string]interface{}, len(n.Elts))
+	for _, el := range n.Elts {
+		par, ok := el.(*ast.KeyValueExpr)
+		if !ok {
+			return nil, fmt.Errorf("%w: cada elemento debe ser clave: valor", ErrDiccionarioInvalido)
+		}
+		var clave string
+		switch k := par.Key.(type) {
+		case *ast.Ident:
+			clave = k.Name
+		case *ast.BasicLit:
+			if k.Kind == token.STRING {
+				clave, _ = strconv.Unquote(k.Value)
+			} else {
+				clave = k.Value
+			}
+		default:
+			return nil, fmt.Errorf("%w: la clave debe ser un texto o un nombre", ErrDiccionarioInvalido)
+		}
+		valor, err := evaluarNodo(par.Value, ctx)
+		if err != nil {
+			return nil, err
+		}
+		res[clave] = valor
+	}
+	return res, nil
+}
+
+// FormatearDiccionario muestra las claves en orden: {"a": 1, "b": 2}.
+func FormatearDiccionario(d map[string]interface{}) string {
+	claves := make([]string, 0, len(d))
+	for k := range d {
+		claves = append(claves, k)
+	}
+	sort.Strings(claves)
+	partes := make([]string, len(claves))
+	for i, k := range claves {
+		valor := FormatearValor(d[k])
+		if texto, ok := d[k].(string); ok {
+			valor = strconv.Quote(texto)
+		}
+		partes[i] = strconv.Quote(k) + ": " + valor
+	}
+	return "{" + strings.Join(partes, ", ") + "}"
+}
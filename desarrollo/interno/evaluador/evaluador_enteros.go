@@ -0,0 +1,280 @@
+package evaluador
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Los enteros viajan como int mientras caben en 64 bits y se promueven a
+// *big.Int cuando una operación se desborda (factorial(30), 2^100...).
+// Las fracciones exactas (racional(1, 3)) viajan como *big.Rat.
+
+// NormalizarEntero devuelve int si el valor cabe en 64 bits, *big.Int si no.
+func NormalizarEntero(b *big.Int) interface{} {
+	if b.IsInt64() {
+		return int(b.Int64())
+	}
+	return b
+}
+
+// maxBitsPotencia limita el tamaño de x^y (unos cinco millones de dígitos):
+// más allá el cálculo tarda minutos y agota la memoria.
+const maxBitsPotencia = 1 << 24
+
+// potenciaMuyGrande estima los bits de base^exp sin calcularla.
+func potenciaMuyGrande(base *big.Int, exp int64) bool {
+	bits := int64(base.BitLen())
+	if bits <= 1 { // 0, 1 y -1 no crecen
+		return false
+	}
+	return exp > maxBitsPotencia/bits
+}
+
+// esEntero reconoce los tipos enteros del evaluador.
+func esEntero(v interface{}) bool {
+	switch v.(type) {
+	case int, int32, int64, *big.Int:
+		return true
+	}
+	return false
+}
+
+// ComoEnteroGrande convierte enteros, *big.Int, reales sin parte decimal y
+// cadenas de dígitos a *big.Int.
+func ComoEnteroGrande(v interface{}) (*big.Int, bool) {
+	switch x := v.(type) {
+	case int:
+		return big.NewInt(int64(x)), true
+	case int32:
+		return big.NewInt(int64(x)), true
+	case int64:
+		return big.NewInt(x), true
+	case *big.Int:
+		return x, x != nil
+	case *big.Rat:
+		if x != nil && x.IsInt() {
+			return new(big.Int).Set(x.Num()), true
+		}
+	case float64:
+		if x == math.Trunc(x) && !math.IsInf(x, 0) {
+			b, _ := big.NewFloat(x).Int(nil)
+			return b, true
+		}
+	case Decimal:
+		if e := x.normalizar(0); e.escala == 0 {
+			return new(big.Int).Set(e.c()), true
+		}
+	case string:
+		return new(big.Int).SetString(strings.TrimSpace(x), 10)
+	}
+	return nil, false
+}
+
+// ComoRacional convierte enteros, racionales, decimales y reales a *big.Rat
+// (los reales por su representación decimal más corta: 0.1 → 1/10).
+func ComoRacional(v interface{}) (*big.Rat, bool) {
+	switch x := v.(type) {
+	case *big.Rat:
+		return x, x != nil
+	case float64:
+		d, err := DecimalDesdeReal(x)
+		if err != nil {
+			return nil, false
+		}
+		return ComoRacional(d)
+	case Decimal:
+		return new(big.Rat).SetFrac(x.c(), potenciaDiez(x.escala)), true
+	case string:
+		return new(big.Rat).SetString(strings.TrimSpace(x))
+	}
+	if b, ok := ComoEnteroGrande(v); ok {
+		return new(big.Rat).SetInt(b), true
+	}
+	return nil, false
+}
+
+// FormatearRacional muestra 1/3, -5/2 o 4 si el denominador es 1.
+func FormatearRacional(r *big.Rat) string {
+	return r.RatString()
+}
+
+func enteroPequeño(v interface{}) (int64, bool) {
+	switch x := v.(type) {
+	case int:
+		return int64(x), true
+	case int32:
+		return int64(x), true
+	case int64:
+		return x, true
+	}
+	return 0, false
+}
+
+// operarEnteros mantiene exactos los enteros en + - * ^ %, promoviendo a
+// *big.Int al desbordarse. La división de enteros siempre da real (7/2 = 3.5),
+// sea cual sea su tamaño; el cociente exacto se pide con racional(a, b).
+func operarEnteros(op token.Token, izq, der interface{}) (interface{}, bool, error) {
+	if !esEntero(izq) || !esEntero(der) {
+		return nil, false, nil
+	}
+	a, aPequeño := enteroPequeño(izq)
+	b, bPequeño := enteroPequeño(der)
+
+	// Camino rápido sin reservar memoria cuando no hay desbordamiento
+	if aPequeño && bPequeño {
+		switch op {
+		case token.ADD:
+			if s := a + b; (s > a) == (b > 0) {
+				return int(s), true, nil
+			}
+		case token.SUB:
+			if s := a - b; (s < a) == (b > 0) {
+				return int(s), true, nil
+			}
+		case token.MUL:
+			if a == 0 || b == 0 {
+				return 0, true, nil
+			}
+			if p := a * b; p/b == a && !(a == -1 && b == math.MinInt64) && !(b == -1 && a == math.MinInt64) {
+				return int(p), true, nil
+			}
+		case token.QUO:
+			return nil, false, nil
+		case token.REM:
+			if b == 0 {
+				return nil, true, errors.New("❌ ERROR FATAL: módulo por cero")
+			}
+			if b != -1 {
+				return int(a % b), true, nil
+			}
+			return 0, true, nil
+		case token.EQL:
+			return a == b, true, nil
+		case token.NEQ:
+			return a != b, true, nil
+		case token.LSS:
+			return a < b, true, nil
+		case token.GTR:
+			return a > b, true, nil
+		case token.LEQ:
+			return a <= b, true, nil
+		case token.GEQ:
+			return a >= b, true, nil
+		}
+	}
+
+	x, _ := ComoEnteroGrande(izq)
+	y, _ := ComoEnteroGrande(der)
+	switch op {
+	case token.ADD:
+		return NormalizarEntero(new(big.Int).Add(x, y)), true, nil
+	case token.SUB:
+		return NormalizarEntero(new(big.Int).Sub(x, y)), true, nil
+	case token.MUL, token.AND_NOT:
+		return NormalizarEntero(new(big.Int).Mul(x, y)), true, nil
+	case token.QUO:
+		if y.Sign() == 0 {
+			return nil, true, errors.New("❌ ERROR FATAL: división por cero")
+		}
+		f, _ := new(big.Rat).SetFrac(x, y).Float64()
+		if math.IsInf(f, 0) {
+			return nil, true, fmt.Errorf("❌ ERROR FATAL: el cociente no cabe en un real; use racional(%s, %s) para el valor exacto", x, y)
+		}
+		return f, true, nil
+	case token.REM:
+		if y.Sign() == 0 {
+			return nil, true, errors.New("❌ ERROR FATAL: módulo por cero")
+		}
+		return NormalizarEntero(new(big.Int).Rem(x, y)), true, nil
+	case token.XOR:
+		if y.Sign() < 0 {
+			// Exponente negativo: 2^-1 = 0.5 por la vía real
+			return nil, false, nil
+		}
+		if !y.IsInt64() || potenciaMuyGrande(x, y.Int64()) {
+			return nil, true, fmt.Errorf("❌ ERROR FATAL: exponente entero demasiado grande → %s", y)
+		}
+		return NormalizarEntero(new(big.Int).Exp(x, y, nil)), true, nil
+	case token.EQL:
+		return x.Cmp(y) == 0, true, nil
+	case token.NEQ:
+		return x.Cmp(y) != 0, true, nil
+	case token.LSS:
+		return x.Cmp(y) < 0, true, nil
+	case token.GTR:
+		return x.Cmp(y) > 0, true, nil
+	case token.LEQ:
+		return x.Cmp(y) <= 0, true, nil
+	case token.GEQ:
+		return x.Cmp(y) >= 0, true, nil
+	}
+	return nil, false, nil
+}
+
+// operarRacionales mantiene exactas las operaciones entre racionales y enteros.
+// Con un real de por medio, se sigue la vía real.
+func operarRacionales(op token.Token, izq, der interface{}) (interface{}, bool, error) {
+	_, esRacIzq := izq.(*big.Rat)
+	_, esRacDer := der.(*big.Rat)
+	if !esRacIzq && !esRacDer {
+		return nil, false, nil
+	}
+	if (!esRacIzq && !esEntero(izq)) || (!esRacDer && !esEntero(der)) {
+		return nil, false, nil
+	}
+	a, _ := ComoRacional(izq)
+	b, _ := ComoRacional(der)
+
+	switch op {
+	case token.ADD:
+		return new(big.Rat).Add(a, b), true, nil
+	case token.SUB:
+		return new(big.Rat).Sub(a, b), true, nil
+	case token.MUL, token.AND_NOT:
+		return new(big.Rat).Mul(a, b), true, nil
+	case token.QUO:
+		if b.Sign() == 0 {
+			return nil, true, errors.New("❌ ERROR FATAL: división por cero")
+		}
+		return new(big.Rat).Quo(a, b), true, nil
+	case token.XOR:
+		if !b.IsInt() || !b.Num().IsInt64() || math.Abs(float64(b.Num().Int64())) > math.MaxInt32 {
+			return nil, false, nil
+		}
+		return potenciaRacional(a, int(b.Num().Int64()))
+	case token.EQL:
+		return a.Cmp(b) == 0, true, nil
+	case token.NEQ:
+		return a.Cmp(b) != 0, true, nil
+	case token.LSS:
+		return a.Cmp(b) < 0, true, nil
+	case token.GTR:
+		return a.Cmp(b) > 0, true, nil
+	case token.LEQ:
+		return a.Cmp(b) <= 0, true, nil
+	case token.GEQ:
+		return a.Cmp(b) >= 0, true, nil
+	}
+	return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no está definido para racionales", nombreOperador(op))
+}
+
+func potenciaRacional(r *big.Rat, n int) (interface{}, bool, error) {
+	if n < 0 {
+		if r.Sign() == 0 {
+			return nil, true, errors.New("❌ ERROR FATAL: división por cero")
+		}
+		r = new(big.Rat).Inv(r)
+		n = -n
+	}
+	if potenciaMuyGrande(r.Num(), int64(n)) || potenciaMuyGrande(r.Denom(), int64(n)) {
+		return nil, true, fmt.Errorf("❌ ERROR FATAL: exponente racional demasiado grande → %d", n)
+	}
+	e := big.NewInt(int64(n))
+	num := new(big.Int).Exp(r.Num(), e, nil)
+	den := new(big.Int).Exp(r.Denom(), e, nil)
+	return new(big.Rat).SetFrac(num, den), true, nil
+}
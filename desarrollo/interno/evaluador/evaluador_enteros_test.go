@@ -0,0 +1,128 @@
+package evaluador
+
+import (
+	"go/token"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestPotenciaEntera(t *testing.T) {
+	grande, _ := new(big.Int).SetString("18446744073709551616", 10)
+	casos := []struct {
+		base, exp interface{}
+		esperado  interface{}
+	}{
+		{2, 10, 1024},
+		{2, 64, grande},
+		{-3, 3, -27},
+		{1, 1 << 40, 1},
+		{-1, 1<<40 + 1, -1},
+		{0, 1 << 40, 0},
+	}
+	for _, c := range casos {
+		res, manejado, err := operarEnteros(token.XOR, c.base, c.exp)
+		if err != nil || !manejado {
+			t.Errorf("%v^%v: manejado=%v err=%v", c.base, c.exp, manejado, err)
+			continue
+		}
+		if b, ok := c.esperado.(*big.Int); ok {
+			if r, ok := res.(*big.Int); !ok || r.Cmp(b) != 0 {
+				t.Errorf("%v^%v = %v, se esperaba %v", c.base, c.exp, res, b)
+			}
+		} else if res != c.esperado {
+			t.Errorf("%v^%v = %v (%T), se esperaba %v", c.base, c.exp, res, res, c.esperado)
+		}
+	}
+}
+
+func TestPotenciaEnteraLimite(t *testing.T) {
+	// 10^1000 cabe de sobra
+	res, _, err := operarEnteros(token.XOR, 10, 1000)
+	if err != nil {
+		t.Fatalf("10^1000: %v", err)
+	}
+	if n := len(res.(*big.Int).String()); n != 1001 {
+		t.Errorf("10^1000 tiene %d dígitos, se esperaban 1001", n)
+	}
+
+	enorme, _ := new(big.Int).SetString("100000000000000000000", 10)
+	for _, c := range []struct{ base, exp interface{} }{
+		{10, 1_000_000_000},
+		{2, maxBitsPotencia + 1},
+		{3, enorme},
+		{big.NewInt(1 << 62), 1 << 20},
+	} {
+		inicio := time.Now()
+		if _, manejado, err := operarEnteros(token.XOR, c.base, c.exp); err == nil || !manejado {
+			t.Errorf("%v^%v debía rechazarse (manejado=%v)", c.base, c.exp, manejado)
+		}
+		if d := time.Since(inicio); d > time.Second {
+			t.Errorf("%v^%v tardó %v en rechazarse", c.base, c.exp, d)
+		}
+	}
+
+	// Exponente negativo: lo resuelve la vía real
+	if _, manejado, _ := operarEnteros(token.XOR, 2, -1); manejado {
+		t.Error("2^-1 no debía resolverse como entero")
+	}
+}
+
+func TestPotenciaRacionalLimite(t *testing.T) {
+	r := big.NewRat(2, 3)
+	res, _, err := potenciaRacional(r, 3)
+	if err != nil || res.(*big.Rat).Cmp(big.NewRat(8, 27)) != 0 {
+		t.Errorf("(2/3)^3 = %v, %v", res, err)
+	}
+	res, _, err = potenciaRacional(r, -2)
+	if err != nil || res.(*big.Rat).Cmp(big.NewRat(9, 4)) != 0 {
+		t.Errorf("(2/3)^-2 = %v, %v", res, err)
+	}
+	if _, _, err := potenciaRacional(big.NewRat(10, 7), 1_000_000_000); err == nil {
+		t.Error("(10/7)^1e9 debía rechazarse")
+	}
+	if _, _, err := potenciaRacional(new(big.Rat), -1); err == nil {
+		t.Error("0^-1 debía fallar")
+	}
+}
+
+// La división de enteros da real tanto con enteros de 64 bits como con
+// enteros grandes.
+func TestDivisionEntera(t *testing.T) {
+	ctx := PrepararContextoEvaluador()
+	ctx.Variables["g"], _ = new(big.Int).SetString("100000000000000000000000000000", 10) // 10^29
+	casos := []struct {
+		expresion string
+		esperado  float64
+	}{
+		{"7 / 2", 3.5},
+		{"6 / 3", 2},
+		{"-7 / 2", -3.5},
+		{"g / 10", 1e28},
+		{"g / 4", 2.5e28},
+		{"(2 ^ 70) / 2", 590295810358705651712},
+		{"10 / (2 ^ 70)", 10 / 1180591620717411303424.0},
+	}
+	for _, c := range casos {
+		r, err := EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if f, ok := r.(float64); !ok || f != c.esperado {
+			t.Errorf("%s = %v (%T), se esperaba el real %v", c.expresion, r, r, c.esperado)
+		}
+	}
+
+	if _, err := EvalConContexto("(2 ^ 2000) / 3", ctx); err == nil {
+		t.Error("un cociente fuera del rango de los reales debía fallar")
+	}
+	if _, err := EvalConContexto("g / 0", ctx); err == nil {
+		t.Error("dividir un entero grande entre cero debía fallar")
+	}
+	// Enteros grandes y de 64 bits siguen la misma regla aunque el cociente sea exacto
+	r, _, err := operarEnteros(token.QUO, ctx.Variables["g"], big.NewInt(5))
+	if f, ok := r.(float64); err != nil || !ok || f != 2e28 {
+		t.Errorf("g / 5 = %v (%T), %v", r, r, err)
+	}
+}
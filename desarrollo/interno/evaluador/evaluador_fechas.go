@@ -0,0 +1,821 @@
+package evaluador
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"go/token"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // las zonas horarias funcionan aunque el sistema no traiga la base IANA
+	"unicode"
+
+	"nepa/desarrollo/interno/valores"
+)
+
+// Fechas y duraciones:
+//   vence = convertir_fecha("2024-01-31") + 1 mes     → 2024-02-29
+//   plazo = vence - hoy()                             → duración en días
+//   reunion = ahora("America/Mexico_City") + 2 horas
+//   formatear_fecha(vence, "DDDD D de MMMM de AAAA")  → "jueves 29 de febrero de 2024"
+//
+// "30 dias", "2 semanas", "3 meses", "1 año", "90 minutos"... crean una
+// duración. Meses y días son de calendario: sumar un mes conserva el día
+// (o usa el último del mes si no existe) y sumar un día conserva la hora
+// aunque ese día cambie el horario de verano. Horas, minutos y segundos son
+// tiempo exacto. Las cantidades de tiempo (5 h, 1 dia) también se aceptan.
+
+// Duracion es un lapso de tiempo: meses y días de calendario más tiempo exacto.
+type Duracion struct {
+	Meses  int
+	Dias   int
+	Exacta time.Duration
+}
+
+var ErrDuracionAmbigua = errors.New("❌ ERROR FATAL: una duración con meses no tiene una cantidad fija de días")
+
+// unidadesDuracion: cuántos meses, días o tiempo exacto vale 1 de cada unidad.
+var unidadesDuracion = map[string]Duracion{
+	"año": {Meses: 12}, "años": {Meses: 12}, "ano": {Meses: 12}, "anos": {Meses: 12},
+	"mes": {Meses: 1}, "meses": {Meses: 1},
+	"semana": {Dias: 7}, "semanas": {Dias: 7},
+	"dia": {Dias: 1}, "dias": {Dias: 1}, "día": {Dias: 1}, "días": {Dias: 1},
+	"hora": {Exacta: time.Hour}, "horas": {Exacta: time.Hour}, "h": {Exacta: time.Hour},
+	"minuto": {Exacta: time.Minute}, "minutos": {Exacta: time.Minute}, "min": {Exacta: time.Minute},
+	"segundo": {Exacta: time.Second}, "segundos": {Exacta: time.Second}, "s": {Exacta: time.Second},
+	"milisegundo": {Exacta: time.Millisecond}, "milisegundos": {Exacta: time.Millisecond}, "ms": {Exacta: time.Millisecond},
+}
+
+// esPalabraDuracion indica si "30 <unidad>" se traduce a duracion() en lugar
+// de a cantidad(): las unidades que ya son cantidades (dia, hora, h, s...)
+// siguen siéndolo y se convierten al operar con fechas.
+func esPalabraDuracion(unidad string) bool {
+	if _, ok := unidadesDuracion[unidad]; !ok {
+		return false
+	}
+	_, esCantidad := unidadesConocidas[unidad]
+	return !esCantidad
+}
+
+// NuevaDuracion crea "valor unidad": NuevaDuracion(30, "dias").
+func NuevaDuracion(valor float64, unidad string) (Duracion, error) {
+	base, ok := unidadesDuracion[strings.ToLower(strings.TrimSpace(unidad))]
+	if !ok {
+		return Duracion{}, fmt.Errorf("❌ ERROR FATAL: unidad de duración desconocida '%s' (use segundos, minutos, horas, dias, semanas, meses o años)", unidad)
+	}
+	return base.Escalar(valor)
+}
+
+// ParsearDuracion lee "30 dias", "1 año 2 meses" o el formato de Go "2h30m".
+func ParsearDuracion(texto string) (Duracion, error) {
+	texto = strings.TrimSpace(texto)
+	if d, err := time.ParseDuration(texto); err == nil {
+		return Duracion{Exacta: d}, nil
+	}
+	campos := strings.Fields(texto)
+	if len(campos) == 0 || len(campos)%2 != 0 {
+		return Duracion{}, fmt.Errorf("❌ ERROR FATAL: duración inválida '%s' (ejemplos: \"30 dias\", \"1 año 2 meses\", \"2h30m\")", texto)
+	}
+	var total Duracion
+	for i := 0; i < len(campos); i += 2 {
+		n, err := strconv.ParseFloat(campos[i], 64)
+		if err != nil {
+			return Duracion{}, fmt.Errorf("❌ ERROR FATAL: duración inválida '%s': '%s' no es un número", texto, campos[i])
+		}
+		d, err := NuevaDuracion(n, campos[i+1])
+		if err != nil {
+			return Duracion{}, err
+		}
+		total = total.Sumar(d)
+	}
+	return total, nil
+}
+
+func (d Duracion) Sumar(o Duracion) Duracion {
+	return Duracion{Meses: d.Meses + o.Meses, Dias: d.Dias + o.Dias, Exacta: d.Exacta + o.Exacta}
+}
+
+func (d Duracion) Negar() Duracion {
+	return Duracion{Meses: -d.Meses, Dias: -d.Dias, Exacta: -d.Exacta}
+}
+
+// Escalar multiplica por k. Los meses deben quedar enteros; la fracción de
+// día pasa a tiempo exacto (1.5 dias = 1 dia + 12 h).
+func (d Duracion) Escalar(k float64) (Duracion, error) {
+	if math.IsNaN(k) || math.IsInf(k, 0) {
+		return Duracion{}, fmt.Errorf("❌ ERROR FATAL: no se puede multiplicar una duración por %s", FormatearValor(k))
+	}
+	meses := float64(d.Meses) * k
+	if meses != math.Trunc(meses) {
+		return Duracion{}, fmt.Errorf("❌ ERROR FATAL: %s × %s no es una cantidad entera de meses", d, FormatearValor(k))
+	}
+	dias := float64(d.Dias) * k
+	entero := math.Trunc(dias)
+	exacta := float64(d.Exacta)*k + (dias-entero)*float64(24*time.Hour)
+	return Duracion{Meses: int(meses), Dias: int(entero), Exacta: time.Duration(math.Round(exacta))}, nil
+}
+
+// EnNanosegundos devuelve el largo fijo de la duración (un día = 24 h).
+func (d Duracion) EnNanosegundos() (time.Duration, error) {
+	if d.Meses != 0 {
+		return 0, ErrDuracionAmbigua
+	}
+	return time.Duration(d.Dias)*24*time.Hour + d.Exacta, nil
+}
+
+// Comparar devuelve -1, 0 o 1. Con meses solo se puede ordenar si el resto
+// coincide (o si solo difieren los meses); cuando no, da error, aunque para
+// == y != basta saber que no son iguales.
+func (d Duracion) Comparar(o Duracion) (int, error) {
+	fijo := func(x Duracion) time.Duration { return time.Duration(x.Dias)*24*time.Hour + x.Exacta }
+	a, b := fijo(d), fijo(o)
+	switch {
+	case d.Meses == o.Meses:
+		return cmp.Compare(a, b), nil
+	case a == b:
+		return cmp.Compare(d.Meses, o.Meses), nil
+	}
+	return 0, fmt.Errorf("❌ ERROR FATAL: no se puede comparar %s con %s (los meses no tienen largo fijo)", d, o)
+}
+
+// String: "1 año 2 meses 3 dias 4 h 30 min".
+func (d Duracion) String() string {
+	var partes []string
+	plural := func(n int, uno, varios string) {
+		if n == 1 || n == -1 {
+			partes = append(partes, fmt.Sprintf("%d %s", n, uno))
+		} else if n != 0 {
+			partes = append(partes, fmt.Sprintf("%d %s", n, varios))
+		}
+	}
+	plural(d.Meses/12, "año", "años")
+	plural(d.Meses%12, "mes", "meses")
+	plural(d.Dias, "dia", "dias")
+	resto := d.Exacta
+	if horas := resto / time.Hour; horas != 0 {
+		partes = append(partes, fmt.Sprintf("%d h", horas))
+		resto -= horas * time.Hour
+	}
+	if minutos := resto / time.Minute; minutos != 0 {
+		partes = append(partes, fmt.Sprintf("%d min", minutos))
+		resto -= minutos * time.Minute
+	}
+	if resto != 0 {
+		partes = append(partes, strconv.FormatFloat(resto.Seconds(), 'f', -1, 64)+" s")
+	}
+	if len(partes) == 0 {
+		return "0 s"
+	}
+	return strings.Join(partes, " ")
+}
+
+// ISO devuelve la forma ISO 8601 (P1Y2M3DT4H30M) que usa JSON.
+func (d Duracion) ISO() string {
+	var b strings.Builder
+	b.WriteByte('P')
+	if años := d.Meses / 12; años != 0 {
+		fmt.Fprintf(&b, "%dY", años)
+	}
+	if meses := d.Meses % 12; meses != 0 {
+		fmt.Fprintf(&b, "%dM", meses)
+	}
+	if d.Dias != 0 {
+		fmt.Fprintf(&b, "%dD", d.Dias)
+	}
+	if d.Exacta != 0 {
+		b.WriteByte('T')
+		resto := d.Exacta
+		if horas := resto / time.Hour; horas != 0 {
+			fmt.Fprintf(&b, "%dH", horas)
+			resto -= horas * time.Hour
+		}
+		if minutos := resto / time.Minute; minutos != 0 {
+			fmt.Fprintf(&b, "%dM", minutos)
+			resto -= minutos * time.Minute
+		}
+		if resto != 0 {
+			b.WriteString(strconv.FormatFloat(resto.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+	if b.Len() == 1 {
+		return "PT0S"
+	}
+	return b.String()
+}
+
+// SumarAFecha avanza t: primero los meses (sin desbordar el mes), luego los
+// días de calendario y al final el tiempo exacto.
+func (d Duracion) SumarAFecha(t time.Time) time.Time {
+	if d.Meses != 0 {
+		primero := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).AddDate(0, d.Meses, 0)
+		dia := t.Day()
+		if ultimo := diasDelMes(primero); dia > ultimo {
+			dia = ultimo
+		}
+		t = primero.AddDate(0, 0, dia-1)
+	}
+	return t.AddDate(0, 0, d.Dias).Add(d.Exacta)
+}
+
+// DiferenciaFechas devuelve a - b. Entre fechas sin hora de la misma zona el
+// resultado son días de calendario; en otro caso, tiempo exacto.
+func DiferenciaFechas(a, b time.Time) Duracion {
+	if valores.EsSoloFecha(a) && valores.EsSoloFecha(b) && a.Location().String() == b.Location().String() {
+		return Duracion{Dias: diaCivil(a) - diaCivil(b)}
+	}
+	return Duracion{Exacta: a.Sub(b)}
+}
+
+// diaCivil numera los días del calendario sin depender de la zona horaria.
+func diaCivil(t time.Time) int {
+	return int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// tipoDeFecha elige la variable que guarda t: hora, fecha o tiempo (con hora
+// o zona horaria).
+func tipoDeFecha(t time.Time) string {
+	switch {
+	case valores.EsSoloHora(t):
+		return "hora"
+	case valores.EsSoloFecha(t) && t.Location() == time.UTC:
+		return "fecha"
+	}
+	return "tiempo"
+}
+
+func diasDelMes(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func esBisiesto(año int) bool { return año%4 == 0 && (año%100 != 0 || año%400 == 0) }
+
+// comoDuracion acepta duraciones, time.Duration (convertir_tiempo) y
+// cantidades con dimensión de tiempo. 1 dia, 1 semana y 1 año se toman como
+// unidades de calendario.
+func comoDuracion(v interface{}) (Duracion, bool, error) {
+	switch x := v.(type) {
+	case Duracion:
+		return x, true, nil
+	case time.Duration:
+		return Duracion{Exacta: x}, true, nil
+	case Cantidad:
+		if x.Dimension() != (Dimension{0, 0, 1, 0, 0, 0, 0}) {
+			return Duracion{}, false, nil
+		}
+		if len(x.unidad) == 1 && x.unidad[0].exponente == 1 {
+			if base, ok := unidadesDuracion[x.unidad[0].simbolo]; ok && base.Exacta == 0 {
+				d, err := base.Escalar(x.Valor)
+				return d, true, err
+			}
+		}
+		return Duracion{Exacta: time.Duration(math.Round(x.EnSI() * float64(time.Second)))}, true, nil
+	}
+	return Duracion{}, false, nil
+}
+
+// operarFechas: fecha ± duración, fecha - fecha, aritmética de duraciones y
+// comparaciones. Duración con otra magnitud física se resuelve como cantidad
+// en segundos (distancia / 2 horas).
+func operarFechas(op token.Token, izq, der interface{}) (interface{}, bool, error) {
+	ti, esFechaIzq := izq.(time.Time)
+	td, esFechaDer := der.(time.Time)
+	_, esDurIzq := izq.(Duracion)
+	_, esDurDer := der.(Duracion)
+	_, esGoIzq := izq.(time.Duration)
+	_, esGoDer := der.(time.Duration)
+	if !esFechaIzq && !esFechaDer && !esDurIzq && !esDurDer && !esGoIzq && !esGoDer {
+		return nil, false, nil
+	}
+	if op == token.ADD {
+		_, esIzqString := izq.(string)
+		_, esDerString := der.(string)
+		if esIzqString || esDerString {
+			return nil, false, nil
+		}
+	}
+	noDefinido := func() (interface{}, bool, error) {
+		return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no se puede aplicar entre %s y %s", nombreOperador(op), obtenerTipoEnEspañol(izq), obtenerTipoEnEspañol(der))
+	}
+
+	switch {
+	case esFechaIzq && esFechaDer:
+		if op == token.SUB {
+			return DiferenciaFechas(ti, td), true, nil
+		}
+		if res, ok := resultadoComparacion(op, compararFechas(ti, td)); ok {
+			return res, true, nil
+		}
+		return noDefinido()
+
+	case esFechaIzq || esFechaDer:
+		fecha, otro := ti, der
+		if esFechaDer {
+			fecha, otro = td, izq
+		}
+		d, esDur, err := comoDuracion(otro)
+		if err != nil {
+			return nil, true, err
+		}
+		if !esDur {
+			if esNumerico(otro) && (op == token.ADD || op == token.SUB) {
+				return nil, true, fmt.Errorf("❌ ERROR FATAL: a una fecha se le suma una duración, no un número (use por ejemplo 'f + %s dias')", FormatearValor(otro))
+			}
+			return noDefinido()
+		}
+		switch {
+		case op == token.ADD:
+			return d.SumarAFecha(fecha), true, nil
+		case op == token.SUB && esFechaIzq:
+			return d.Negar().SumarAFecha(fecha), true, nil
+		}
+		return noDefinido()
+	}
+
+	// Duraciones: si el otro lado es una magnitud que no es tiempo, la
+	// duración pasa a cantidad en segundos
+	if c, ok := izq.(Cantidad); ok && c.Dimension() != (Dimension{0, 0, 1, 0, 0, 0, 0}) {
+		return operarDuracionComoCantidad(op, izq, der)
+	}
+	if c, ok := der.(Cantidad); ok && c.Dimension() != (Dimension{0, 0, 1, 0, 0, 0, 0}) {
+		return operarDuracionComoCantidad(op, izq, der)
+	}
+	a, durA, err := comoDuracion(izq)
+	if err != nil {
+		return nil, true, err
+	}
+	b, durB, err := comoDuracion(der)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if durA && durB {
+		switch op {
+		case token.ADD:
+			return a.Sumar(b), true, nil
+		case token.SUB:
+			return a.Sumar(b.Negar()), true, nil
+		case token.QUO:
+			return cocienteDuraciones(a, b)
+		}
+		orden, err := a.Comparar(b)
+		if err != nil && (op == token.EQL || op == token.NEQ) {
+			// Incomparables solo si difieren los meses y el resto: no son iguales
+			return op == token.NEQ, true, nil
+		}
+		if res, ok := resultadoComparacion(op, orden); ok {
+			if err != nil {
+				return nil, true, err
+			}
+			return res, true, nil
+		}
+		return noDefinido()
+	}
+
+	// Duración por número
+	d, otro := a, der
+	if !durA {
+		d, otro = b, izq
+	}
+	k, err := ConvertirAReal(otro)
+	if err != nil || !esNumerico(otro) {
+		return noDefinido()
+	}
+	switch {
+	case op == token.MUL:
+		return resultadoDuracion(d.Escalar(k))
+	case op == token.QUO && durA:
+		if k == 0 {
+			return nil, true, errors.New("❌ ERROR FATAL: división por cero")
+		}
+		return resultadoDuracion(d.Escalar(1 / k))
+	}
+	return noDefinido()
+}
+
+func resultadoDuracion(d Duracion, err error) (interface{}, bool, error) {
+	if err != nil {
+		return nil, true, err
+	}
+	return d, true, nil
+}
+
+func esNumerico(v interface{}) bool {
+	switch v.(type) {
+	case int, int64, float64, Decimal, *big.Int, *big.Rat:
+		return true
+	}
+	return false
+}
+
+func operarDuracionComoCantidad(op token.Token, izq, der interface{}) (interface{}, bool, error) {
+	aCantidad := func(v interface{}) (interface{}, error) {
+		d, esDur, err := comoDuracion(v)
+		if err != nil || !esDur {
+			return v, err
+		}
+		if _, yaEs := v.(Cantidad); yaEs {
+			return v, nil
+		}
+		n, err := d.EnNanosegundos()
+		if err != nil {
+			return nil, err
+		}
+		return NuevaCantidad(n.Seconds(), "s")
+	}
+	a, err := aCantidad(izq)
+	if err != nil {
+		return nil, true, err
+	}
+	b, err := aCantidad(der)
+	if err != nil {
+		return nil, true, err
+	}
+	return operarCantidades(op, a, b)
+}
+
+// cocienteDuraciones: 30 dias / 1 semana = 4.28..., 6 meses / 1 mes = 6.
+func cocienteDuraciones(a, b Duracion) (interface{}, bool, error) {
+	if a.Dias == 0 && a.Exacta == 0 && b.Dias == 0 && b.Exacta == 0 {
+		if b.Meses == 0 {
+			return nil, true, errors.New("❌ ERROR FATAL: división por cero")
+		}
+		return float64(a.Meses) / float64(b.Meses), true, nil
+	}
+	x, err := a.EnNanosegundos()
+	if err != nil {
+		return nil, true, err
+	}
+	y, err := b.EnNanosegundos()
+	if err != nil {
+		return nil, true, err
+	}
+	if y == 0 {
+		return nil, true, errors.New("❌ ERROR FATAL: división por cero")
+	}
+	return float64(x) / float64(y), true, nil
+}
+
+func compararFechas(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// resultadoComparacion traduce -1/0/1 al operador de comparación pedido.
+func resultadoComparacion(op token.Token, cmp int) (bool, bool) {
+	switch op {
+	case token.EQL:
+		return cmp == 0, true
+	case token.NEQ:
+		return cmp != 0, true
+	case token.LSS:
+		return cmp < 0, true
+	case token.GTR:
+		return cmp > 0, true
+	case token.LEQ:
+		return cmp <= 0, true
+	case token.GEQ:
+		return cmp >= 0, true
+	}
+	return false, false
+}
+
+// ---------------------------------------------------------------------------
+// Lectura y formato
+
+// ParsearFecha lee AAAA-MM-DD con hora y zona opcionales (ver valores.ParsearFecha).
+func ParsearFecha(texto string) (time.Time, error) { return valores.ParsearFecha(texto) }
+
+var (
+	nombresMes = [...]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio",
+		"agosto", "septiembre", "octubre", "noviembre", "diciembre"}
+	nombresDia = [...]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"}
+)
+
+func abreviar(nombre string) string { return string([]rune(nombre)[:3]) }
+
+// FormatearFechaConPatron usa un patrón con nombres en español. Cada símbolo
+// es una racha de la misma letra separada de otras letras, así que el texto
+// normal ("de", "las") se copia tal cual:
+//
+//	AAAA 2024   AA 24
+//	MMMM enero  MMM ene   MM 01   M 1
+//	DDDD lunes  DDD lun   DD 05   D 5
+//	hh 09  h 9  mm 05  ss 07   Z CST   ZZ -06:00
+func FormatearFechaConPatron(t time.Time, patron string) string {
+	var b strings.Builder
+	r := []rune(patron)
+	for i := 0; i < len(r); {
+		j := i
+		for j < len(r) && r[j] == r[i] {
+			j++
+		}
+		solo := (i == 0 || !unicode.IsLetter(r[i-1])) && (j == len(r) || !unicode.IsLetter(r[j]))
+		if texto, ok := simboloFecha(t, r[i], j-i); ok && solo {
+			b.WriteString(texto)
+		} else {
+			b.WriteString(string(r[i:j]))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+func simboloFecha(t time.Time, letra rune, n int) (string, bool) {
+	switch {
+	case letra == 'A' && n == 4:
+		return fmt.Sprintf("%04d", t.Year()), true
+	case letra == 'A' && n == 2:
+		return fmt.Sprintf("%02d", t.Year()%100), true
+	case letra == 'M' && n == 4:
+		return nombresMes[t.Month()-1], true
+	case letra == 'M' && n == 3:
+		return abreviar(nombresMes[t.Month()-1]), true
+	case letra == 'M' && n <= 2:
+		return fmt.Sprintf("%0*d", n, int(t.Month())), true
+	case letra == 'D' && n == 4:
+		return nombresDia[t.Weekday()], true
+	case letra == 'D' && n == 3:
+		return abreviar(nombresDia[t.Weekday()]), true
+	case letra == 'D' && n <= 2:
+		return fmt.Sprintf("%0*d", n, t.Day()), true
+	case letra == 'h' && n <= 2:
+		return fmt.Sprintf("%0*d", n, t.Hour()), true
+	case letra == 'm' && n == 2:
+		return fmt.Sprintf("%02d", t.Minute()), true
+	case letra == 's' && n == 2:
+		return fmt.Sprintf("%02d", t.Second()), true
+	case letra == 'Z' && n == 1:
+		return t.Format("MST"), true
+	case letra == 'Z' && n == 2:
+		return t.Format("-07:00"), true
+	}
+	return "", false
+}
+
+// ---------------------------------------------------------------------------
+// Funciones
+
+// comoFecha acepta fechas y textos AAAA-MM-DD.
+func comoFecha(nombre string, v interface{}) (time.Time, error) {
+	switch x := v.(type) {
+	case time.Time:
+		return x, nil
+	case string:
+		if t, err := ParsearFecha(x); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("❌ ERROR FATAL: '%s' requiere una fecha → %v", nombre, FormatearValor(v))
+}
+
+func argumentosFecha(nombre string, args []interface{}, minimo, maximo int) (time.Time, error) {
+	if len(args) < minimo || len(args) > maximo {
+		if minimo == maximo {
+			return time.Time{}, fmt.Errorf("❌ ERROR FATAL: '%s' requiere %d argumento(s), recibió %d", nombre, minimo, len(args))
+		}
+		return time.Time{}, fmt.Errorf("❌ ERROR FATAL: '%s' requiere entre %d y %d argumentos, recibió %d", nombre, minimo, maximo, len(args))
+	}
+	return comoFecha(nombre, args[0])
+}
+
+func init() {
+	// hoy() -> fecha de hoy (sin hora)
+	Funciones["hoy"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'hoy' no recibe argumentos")
+		}
+		t := time.Now()
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+
+	// ahora([zona]) -> fecha y hora actuales, en la zona local o la indicada
+	Funciones["ahora"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 0 {
+			return time.Now().Truncate(time.Second), nil
+		}
+		zona, err := argumentosCadena("ahora", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		loc, err := valores.CargarZona(zona)
+		if err != nil {
+			return nil, err
+		}
+		return time.Now().In(loc).Truncate(time.Second), nil
+	}
+
+	// crear_fecha(año, mes, dia, [hora, minuto, segundo], [zona])
+	Funciones["crear_fecha"] = func(args ...interface{}) (interface{}, error) {
+		loc := time.UTC
+		if len(args) > 0 {
+			if zona, ok := args[len(args)-1].(string); ok {
+				var err error
+				if loc, err = valores.CargarZona(zona); err != nil {
+					return nil, err
+				}
+				args = args[:len(args)-1]
+			}
+		}
+		if len(args) != 3 && len(args) != 5 && len(args) != 6 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'crear_fecha' requiere año, mes y día (y opcionalmente hora, minuto, segundo y zona)")
+		}
+		partes := make([]int, 6)
+		for i, a := range args {
+			n, err := comoEnteroPequeño("crear_fecha", a)
+			if err != nil {
+				return nil, err
+			}
+			partes[i] = n
+		}
+		if partes[1] < 1 || partes[1] > 12 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'crear_fecha': mes fuera de rango → %d", partes[1])
+		}
+		t := time.Date(partes[0], time.Month(partes[1]), partes[2], partes[3], partes[4], partes[5], 0, loc)
+		if t.Day() != partes[2] || t.Hour() != partes[3] || t.Minute() != partes[4] || t.Second() != partes[5] {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'crear_fecha': la fecha %04d-%02d-%02d %02d:%02d:%02d no existe", partes[0], partes[1], partes[2], partes[3], partes[4], partes[5])
+		}
+		return t, nil
+	}
+
+	// duracion(n, unidad) o duracion("1 año 2 meses") -> duración
+	Funciones["duracion"] = func(args ...interface{}) (interface{}, error) {
+		switch len(args) {
+		case 1:
+			if d, esDur, err := comoDuracion(args[0]); esDur || err != nil {
+				res, _, err := resultadoDuracion(d, err)
+				return res, err
+			}
+			texto, err := comoCadena("duracion", args[0])
+			if err != nil {
+				return nil, err
+			}
+			res, _, err := resultadoDuracion(ParsearDuracion(texto))
+			return res, err
+		case 2:
+			n, err := ConvertirAReal(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'duracion' requiere un número → %v", FormatearValor(args[0]))
+			}
+			unidad, err := comoCadena("duracion", args[1])
+			if err != nil {
+				return nil, err
+			}
+			res, _, err := resultadoDuracion(NuevaDuracion(n, unidad))
+			return res, err
+		}
+		return nil, fmt.Errorf("❌ ERROR FATAL: 'duracion' requiere un texto (\"30 dias\") o un número y una unidad")
+	}
+
+	// dia_semana(f) -> "lunes", "martes", ...
+	Funciones["dia_semana"] = func(args ...interface{}) (interface{}, error) {
+		t, err := argumentosFecha("dia_semana", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		return nombresDia[t.Weekday()], nil
+	}
+
+	// inicio_de_mes(f) y fin_de_mes(f) -> primer y último día del mes
+	Funciones["inicio_de_mes"] = func(args ...interface{}) (interface{}, error) {
+		t, err := argumentosFecha("inicio_de_mes", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()), nil
+	}
+	Funciones["fin_de_mes"] = func(args ...interface{}) (interface{}, error) {
+		t, err := argumentosFecha("fin_de_mes", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		return time.Date(t.Year(), t.Month(), diasDelMes(t), 0, 0, 0, 0, t.Location()), nil
+	}
+
+	// es_bisiesto(f | año) -> booleano
+	Funciones["es_bisiesto"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'es_bisiesto' requiere una fecha o un año")
+		}
+		if año, err := comoEnteroPequeño("es_bisiesto", args[0]); err == nil {
+			return esBisiesto(año), nil
+		}
+		t, err := comoFecha("es_bisiesto", args[0])
+		if err != nil {
+			return nil, err
+		}
+		return esBisiesto(t.Year()), nil
+	}
+
+	// formatear_fecha(f, [patron]) -> texto con nombres de mes y día en español
+	Funciones["formatear_fecha"] = func(args ...interface{}) (interface{}, error) {
+		t, err := argumentosFecha("formatear_fecha", args, 1, 2)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 1 {
+			return FormatearFecha(t), nil
+		}
+		patron, err := comoCadena("formatear_fecha", args[1])
+		if err != nil {
+			return nil, err
+		}
+		return FormatearFechaConPatron(t, patron), nil
+	}
+
+	// en_zona(f, zona) -> el mismo instante visto en otra zona horaria
+	Funciones["en_zona"] = func(args ...interface{}) (interface{}, error) {
+		t, err := argumentosFecha("en_zona", args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		zona, err := comoCadena("en_zona", args[1])
+		if err != nil {
+			return nil, err
+		}
+		loc, err := valores.CargarZona(zona)
+		if err != nil {
+			return nil, err
+		}
+		return t.In(loc), nil
+	}
+
+	// zona(f) -> nombre de la zona horaria ("UTC", "Europe/Madrid")
+	Funciones["zona"] = func(args ...interface{}) (interface{}, error) {
+		t, err := argumentosFecha("zona", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		return t.Location().String(), nil
+	}
+
+	// Métodos de fecha: f.dia_semana(), f.formatear("D de MMMM"), f.año()...
+	for _, nombre := range []string{"dia_semana", "inicio_de_mes", "fin_de_mes", "es_bisiesto", "en_zona", "zona"} {
+		Funciones["fecha."+nombre] = Funciones[nombre]
+	}
+	Funciones["fecha.formatear"] = Funciones["formatear_fecha"]
+	componentes := map[string]func(time.Time) int{
+		"año":         func(t time.Time) int { return t.Year() },
+		"mes":         func(t time.Time) int { return int(t.Month()) },
+		"dia":         func(t time.Time) int { return t.Day() },
+		"hora":        func(t time.Time) int { return t.Hour() },
+		"minuto":      func(t time.Time) int { return t.Minute() },
+		"segundo":     func(t time.Time) int { return t.Second() },
+		"dia_del_año": func(t time.Time) int { return t.YearDay() },
+	}
+	for nombre, leer := range componentes {
+		nombre, leer := nombre, leer
+		Funciones["fecha."+nombre] = func(args ...interface{}) (interface{}, error) {
+			t, err := argumentosFecha(nombre, args, 1, 1)
+			if err != nil {
+				return nil, err
+			}
+			return leer(t), nil
+		}
+	}
+
+	// Métodos de duración: d.dias(), d.horas(), d.minutos(), d.segundos(), d.meses()
+	enUnidad := func(nombre string, unidad time.Duration) func(args ...interface{}) (interface{}, error) {
+		return func(args ...interface{}) (interface{}, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'duracion.%s' no recibe argumentos", nombre)
+			}
+			d, esDur, err := comoDuracion(args[0])
+			if err != nil || !esDur {
+				return nil, fmt.Errorf("❌ ERROR FATAL: '%s' requiere una duración → %v", nombre, FormatearValor(args[0]))
+			}
+			n, err := d.EnNanosegundos()
+			if err != nil {
+				return nil, err
+			}
+			if n%unidad == 0 {
+				return int(n / unidad), nil
+			}
+			return float64(n) / float64(unidad), nil
+		}
+	}
+	Funciones["duracion.dias"] = enUnidad("dias", 24*time.Hour)
+	Funciones["duracion.horas"] = enUnidad("horas", time.Hour)
+	Funciones["duracion.minutos"] = enUnidad("minutos", time.Minute)
+	Funciones["duracion.segundos"] = enUnidad("segundos", time.Second)
+	Funciones["duracion.meses"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'duracion.meses' no recibe argumentos")
+		}
+		d, esDur, err := comoDuracion(args[0])
+		if err != nil || !esDur {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'meses' requiere una duración → %v", FormatearValor(args[0]))
+		}
+		if d.Dias != 0 || d.Exacta != 0 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: %s no es una cantidad entera de meses", d)
+		}
+		return d.Meses, nil
+	}
+}
@@ -2,7 +2,12 @@ package evaluador
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
+	"time"
+
+	"nepa/desarrollo/interno/valores"
 )
 
 // FormatearValor convierte cualquier valor interno de Nepa a una cadena legible.
@@ -20,9 +25,33 @@ func FormatearValor(v interface{}) string {
 		// Pero si tiene decimales, los muestre todos sin notación científica extraña.
 		return strconv.FormatFloat(x, 'f', -1, 64)
 
+	case complex128:
+		return valores.FormatearComplejo(x)
+
+	case Decimal:
+		return x.String()
+
+	case *big.Int:
+		return x.String()
+
+	case *big.Rat:
+		return FormatearRacional(x)
+
+	case Cantidad:
+		return x.String()
+
 	case string:
 		return x
 
+	case time.Time:
+		return FormatearFecha(x)
+
+	case Duracion:
+		return x.String()
+
+	case time.Duration:
+		return Duracion{Exacta: x}.String()
+
 	case nil:
 		return "nulo"
 
@@ -38,8 +67,26 @@ func FormatearValor(v interface{}) string {
 		res += "]"
 		return res
 
+	case [][]float64:
+		// Matrices: [[1, 2], [3, 4]]
+		filas := make([]string, len(x))
+		for i, fila := range x {
+			celdas := make([]string, len(fila))
+			for j, n := range fila {
+				celdas[j] = FormatearValor(n)
+			}
+			filas[i] = "[" + strings.Join(celdas, ", ") + "]"
+		}
+		return "[" + strings.Join(filas, ", ") + "]"
+
+	case map[string]interface{}:
+		return FormatearDiccionario(x)
+
 	default:
 		// Para tipos inyectados (como bit) o estructuras complejas
 		return fmt.Sprintf("%v", x)
 	}
 }
+
+// FormatearFecha muestra una fecha, un tiempo o una hora (ver valores.FormatearFecha).
+func FormatearFecha(t time.Time) string { return valores.FormatearFecha(t) }
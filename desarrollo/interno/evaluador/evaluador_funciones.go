@@ -2,7 +2,6 @@ package evaluador
 
 import (
     "errors"
-    "strings"
 )
 
 // Funciones internas y externas registradas.
@@ -41,28 +40,7 @@ var Funciones = map[string]func(args ...interface{}) (interface{}, error){
         return m, nil
     },
 
-    // --- Métodos sobre cadenas ---
-    "cadena.convertir_caracter": func(args ...interface{}) (interface{}, error) {
-        if len(args) < 1 {
-            return nil, errors.New("convertir_caracter requiere al menos una cadena")
-        }
-        s, ok := args[0].(string)
-        if !ok {
-            return nil, errors.New("primer argumento no es una cadena")
-        }
-        return strings.ToUpper(s), nil
-    },
-
-    "cadena.longitud": func(args ...interface{}) (interface{}, error) {
-        if len(args) < 1 {
-            return nil, errors.New("longitud requiere al menos una cadena")
-        }
-        s, ok := args[0].(string)
-        if !ok {
-            return nil, errors.New("primer argumento no es una cadena")
-        }
-        return len(s), nil
-    },
+    // Las funciones y métodos de texto están en evaluador_cadenas.go
 }
 
 // --- Infraestructura de registro de módulos ---
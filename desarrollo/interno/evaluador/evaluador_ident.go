@@ -29,7 +29,11 @@ func evaluarIdentificador(n *ast.Ident, ctx *Contexto) (interface{}, error) {
 			res, errGlobal := administrador.ObtenerVariable(nombre)
 			
 			// Si también hay error en el global, entonces no existe
+			// (salvo que sea el nombre de una función: se usa como valor)
 			if errGlobal != nil {
+				if f, ok := Funciones[nombre]; ok {
+					return NuevaFuncion(nombre, nil, f), nil
+				}
 				return nil, fmt.Errorf("%w → %s", ErrIdentificadorNoExiste, nombre)
 			}
 			v = res
@@ -0,0 +1,132 @@
+package evaluador
+
+import (
+	"fmt"
+	"go/ast"
+)
+
+// Índices y rebanadas dentro de expresiones. Las posiciones empiezan en 0 y
+// las negativas cuentan desde el final; en las cadenas se cuentan caracteres:
+//   "canción"[4]    → "i"
+//   "canción"[-3:]  → "ión"
+//   lista[1:3], matriz[1][0], datos["clave"]
+
+// evaluarIndice resuelve x[i].
+func evaluarIndice(n *ast.IndexExpr, ctx *Contexto) (interface{}, error) {
+	valor, err := evaluarNodo(n.X, ctx)
+	if err != nil {
+		return nil, err
+	}
+	indice, err := evaluarNodo(n.Index, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return indexar(valor, indice)
+}
+
+// indexar devuelve un elemento de una cadena, lista o diccionario.
+func indexar(valor, indice interface{}) (interface{}, error) {
+	if d, ok := valor.(map[string]interface{}); ok {
+		clave, err := comoCadena("índice", indice)
+		if err != nil {
+			clave = FormatearValor(indice)
+		}
+		v, existe := d[clave]
+		if !existe {
+			return nil, fmt.Errorf("❌ ERROR FATAL: la clave '%s' no existe en el diccionario", clave)
+		}
+		return v, nil
+	}
+
+	i, err := comoEnteroPequeño("índice", indice)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := valor.(string); ok {
+		runas := []rune(s)
+		j, err := posicionValida(i, len(runas))
+		if err != nil {
+			return nil, err
+		}
+		return string(runas[j]), nil
+	}
+	lista, err := comoLista("índice", valor)
+	if err != nil {
+		return nil, fmt.Errorf("❌ ERROR FATAL: no se puede indexar %v (se esperaba una cadena, lista o diccionario)", FormatearValor(valor))
+	}
+	j, err := posicionValida(i, len(lista))
+	if err != nil {
+		return nil, err
+	}
+	return lista[j], nil
+}
+
+// posicionValida traduce índices negativos y comprueba el rango.
+func posicionValida(i, n int) (int, error) {
+	j := i
+	if j < 0 {
+		j += n
+	}
+	if j < 0 || j >= n {
+		return 0, fmt.Errorf("❌ ERROR FATAL: índice %d fuera de rango (longitud %d)", i, n)
+	}
+	return j, nil
+}
+
+// evaluarRebanada resuelve x[desde:hasta] sobre cadenas y listas.
+func evaluarRebanada(n *ast.SliceExpr, ctx *Contexto) (interface{}, error) {
+	if n.Slice3 {
+		return nil, fmt.Errorf("%w: las rebanadas admiten solo [desde:hasta]", ErrExpresionInvalida)
+	}
+	valor, err := evaluarNodo(n.X, ctx)
+	if err != nil {
+		return nil, err
+	}
+	limite := func(e ast.Expr, porDefecto, largo int) (int, error) {
+		if e == nil {
+			return porDefecto, nil
+		}
+		v, err := evaluarNodo(e, ctx)
+		if err != nil {
+			return 0, err
+		}
+		i, err := comoEnteroPequeño("rebanada", v)
+		if err != nil {
+			return 0, err
+		}
+		return posicionRelativa(i, largo), nil
+	}
+
+	if s, ok := valor.(string); ok {
+		runas := []rune(s)
+		desde, err := limite(n.Low, 0, len(runas))
+		if err != nil {
+			return nil, err
+		}
+		hasta, err := limite(n.High, len(runas), len(runas))
+		if err != nil {
+			return nil, err
+		}
+		if hasta < desde {
+			return "", nil
+		}
+		return string(runas[desde:hasta]), nil
+	}
+	lista, err := comoLista("rebanada", valor)
+	if err != nil {
+		return nil, fmt.Errorf("❌ ERROR FATAL: solo se pueden rebanar cadenas y listas → %v", FormatearValor(valor))
+	}
+	desde, err := limite(n.Low, 0, len(lista))
+	if err != nil {
+		return nil, err
+	}
+	hasta, err := limite(n.High, len(lista), len(lista))
+	if err != nil {
+		return nil, err
+	}
+	if hasta < desde {
+		return []interface{}{}, nil
+	}
+	// Copia: modificar la rebanada no altera la lista original
+	return append([]interface{}{}, lista[desde:hasta]...), nil
+}
@@ -0,0 +1,576 @@
+package evaluador
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Cadenas interpoladas y formato de números.
+//
+// Dentro de una cadena entre comillas dobles, {expresion} o
+// {expresion:formato} se sustituye por su valor:
+//   "Total: {total:,.2f} para {nombre}"
+// Solo se interpolan las llaves que empiezan con una letra o '_' y contienen
+// una expresión válida cuyos nombres existen, así que "\\d{2}", "${1}",
+// "{hola mundo}" o "{\"a\": 1}" quedan igual. Las cadenas `crudas` no se
+// interpolan y "{{x}}" escribe "{x}" literal; fuera de eso {{ y }} se copian.
+//
+// Dentro de las llaves, los textos se escriben entre `acentos graves`
+// ("{decimal(`2.675`):.2f}") para no cerrar la cadena exterior.
+//
+// El formato sigue el estilo [[relleno]alineación][signo][0][ancho][,|_][.precisión][tipo][@localización]:
+//   alineación  < izquierda, > derecha, ^ centro
+//   signo       + siempre, espacio para positivos
+//   ,           separador de miles de la localización; _ separa con guion bajo
+//   tipo        f fijo, e científico, % porcentaje, d entero, x/X/o/b bases, g general, s texto
+//   @es-ES      localización para este valor (por defecto la de configurar_formato)
+
+// localizacion describe los separadores de una región. minimoAgrupar es la
+// cantidad mínima de dígitos de la parte entera para usar separador de miles
+// (en es-ES, 1234 se escribe sin punto y 12.345 con punto).
+type localizacion struct {
+	decimal       string
+	miles         string
+	minimoAgrupar int
+}
+
+var localizaciones = map[string]localizacion{
+	"es-mx": {".", ",", 4},
+	"es-us": {".", ",", 4},
+	"en-us": {".", ",", 4},
+	"es-es": {",", ".", 5},
+	"es-ar": {",", ".", 4},
+	"es-co": {",", ".", 4},
+	"es-cl": {",", ".", 4},
+}
+
+// ConfigFormato guarda la localización por defecto de los números formateados.
+var ConfigFormato = struct {
+	Localizacion string
+}{Localizacion: "es-MX"}
+
+func buscarLocalizacion(nombre string) (localizacion, error) {
+	l, ok := localizaciones[strings.ToLower(strings.ReplaceAll(strings.TrimSpace(nombre), "_", "-"))]
+	if !ok {
+		return localizacion{}, fmt.Errorf("❌ ERROR FATAL: localización desconocida '%s' (use es-MX, es-ES, es-AR, es-CO, es-CL, es-US o en-US)", nombre)
+	}
+	return l, nil
+}
+
+// especificacion es un formato ya analizado.
+type especificacion struct {
+	relleno      rune
+	alineacion   byte
+	signo        byte
+	ancho        int
+	agrupar      byte
+	precision    int
+	tipo         byte
+	localizacion string
+}
+
+func analizarEspecificacion(texto string) (especificacion, error) {
+	esp := especificacion{relleno: ' ', precision: -1, localizacion: ConfigFormato.Localizacion}
+	invalida := func() (especificacion, error) {
+		return esp, fmt.Errorf("❌ ERROR FATAL: formato inválido '%s'", texto)
+	}
+	resto := texto
+	if i := strings.LastIndex(resto, "@"); i >= 0 {
+		esp.localizacion = resto[i+1:]
+		if _, err := buscarLocalizacion(esp.localizacion); err != nil {
+			return esp, err
+		}
+		resto = resto[:i]
+	}
+
+	// relleno y alineación
+	if r, tam := utf8.DecodeRuneInString(resto); tam > 0 && tam < len(resto) && strings.IndexByte("<>^", resto[tam]) >= 0 {
+		esp.relleno, esp.alineacion = r, resto[tam]
+		resto = resto[tam+1:]
+	} else if resto != "" && strings.IndexByte("<>^", resto[0]) >= 0 {
+		esp.alineacion = resto[0]
+		resto = resto[1:]
+	}
+	if resto != "" && strings.IndexByte("+- ", resto[0]) >= 0 {
+		esp.signo = resto[0]
+		resto = resto[1:]
+	}
+	if strings.HasPrefix(resto, "0") {
+		// Ceros entre el signo y los dígitos: -0042
+		if esp.alineacion == 0 {
+			esp.relleno, esp.alineacion = '0', '='
+		}
+		resto = resto[1:]
+	}
+	// ancho y precisión construyen texto: se limitan como repetir y rellenar
+	medida := func(digitos string) (int, bool) {
+		n, err := strconv.Atoi(digitos)
+		return n, err == nil && n <= maxLargoCadena
+	}
+	fueraDeRango := func(que string) (especificacion, error) {
+		return esp, fmt.Errorf("❌ ERROR FATAL: %s fuera de rango en el formato '%s' (máximo %d)", que, texto, maxLargoCadena)
+	}
+	i := 0
+	for i < len(resto) && resto[i] >= '0' && resto[i] <= '9' {
+		i++
+	}
+	if i > 0 {
+		var ok bool
+		if esp.ancho, ok = medida(resto[:i]); !ok {
+			return fueraDeRango("ancho")
+		}
+		resto = resto[i:]
+	}
+	if resto != "" && (resto[0] == ',' || resto[0] == '_') {
+		esp.agrupar = resto[0]
+		resto = resto[1:]
+	}
+	if strings.HasPrefix(resto, ".") {
+		j := 1
+		for j < len(resto) && resto[j] >= '0' && resto[j] <= '9' {
+			j++
+		}
+		if j == 1 {
+			return invalida()
+		}
+		var ok bool
+		if esp.precision, ok = medida(resto[1:j]); !ok {
+			return fueraDeRango("precisión")
+		}
+		resto = resto[j:]
+	}
+	if len(resto) == 1 && strings.IndexByte("fFeE%dxXobgGs", resto[0]) >= 0 {
+		esp.tipo = resto[0]
+		resto = ""
+	}
+	if resto != "" {
+		return invalida()
+	}
+	return esp, nil
+}
+
+// esNumero reconoce los números que acepta el formato numérico.
+func esNumero(v interface{}) bool {
+	switch v.(type) {
+	case int, int32, int64, float64, *big.Int, *big.Rat, Decimal:
+		return true
+	}
+	return false
+}
+
+// cifras escribe un número sin signo según el tipo y la precisión; devuelve
+// también si era negativo y si admite separadores locales.
+func cifras(v interface{}, esp especificacion) (string, bool, bool, error) {
+	tipo, prec := esp.tipo, esp.precision
+	if tipo == 0 && prec >= 0 {
+		tipo = 'f'
+	}
+	switch tipo {
+	case 'd', 'x', 'X', 'o', 'b':
+		b, ok := ComoEnteroGrande(v)
+		if !ok {
+			return "", false, false, fmt.Errorf("❌ ERROR FATAL: el formato '%c' requiere un entero → %v", tipo, FormatearValor(v))
+		}
+		base := map[byte]int{'d': 10, 'x': 16, 'X': 16, 'o': 8, 'b': 2}[tipo]
+		texto := new(big.Int).Abs(b).Text(base)
+		if tipo == 'X' {
+			texto = strings.ToUpper(texto)
+		}
+		return texto, b.Sign() < 0, tipo == 'd', nil
+
+	case 'f', 'F', '%':
+		if prec < 0 {
+			prec = 6
+		}
+		if d, ok := v.(Decimal); ok {
+			// Los decimales se redondean de forma exacta
+			if tipo == '%' {
+				d = d.Multiplicar(DecimalDesdeEntero(100))
+			}
+			d = d.ConEscala(prec, ConfigDecimal.Redondeo)
+			return strings.TrimPrefix(d.String(), "-"), d.Signo() < 0, true, nil
+		}
+		if r, ok := v.(*big.Rat); ok {
+			if tipo == '%' {
+				r = new(big.Rat).Mul(r, big.NewRat(100, 1))
+			}
+			return strings.TrimPrefix(r.FloatString(prec), "-"), r.Sign() < 0, true, nil
+		}
+		f, err := ConvertirAReal(v)
+		if err != nil {
+			return "", false, false, err
+		}
+		if tipo == '%' {
+			f *= 100
+		}
+		texto := strconv.FormatFloat(f, 'f', prec, 64)
+		return strings.TrimPrefix(texto, "-"), strings.HasPrefix(texto, "-"), true, nil
+
+	case 'e', 'E', 'g', 'G':
+		f, err := ConvertirAReal(v)
+		if err != nil {
+			return "", false, false, err
+		}
+		if prec < 0 && (tipo == 'e' || tipo == 'E') {
+			prec = 6
+		}
+		texto := strconv.FormatFloat(f, tipo, prec, 64)
+		return strings.TrimPrefix(texto, "-"), strings.HasPrefix(texto, "-"), true, nil
+	}
+
+	texto := FormatearValor(v)
+	return strings.TrimPrefix(texto, "-"), strings.HasPrefix(texto, "-"), true, nil
+}
+
+// localizarNumero aplica el separador decimal y, si se pide, el de miles.
+func localizarNumero(texto string, esp especificacion) (string, error) {
+	loc, err := buscarLocalizacion(esp.localizacion)
+	if err != nil {
+		return "", err
+	}
+	entero, resto := texto, ""
+	if i := strings.IndexAny(texto, ".eE%"); i >= 0 {
+		entero, resto = texto[:i], texto[i:]
+	}
+	if strings.HasPrefix(resto, ".") {
+		resto = loc.decimal + resto[1:]
+	}
+	if esp.agrupar != 0 {
+		sep, minimo := loc.miles, loc.minimoAgrupar
+		if esp.agrupar == '_' {
+			sep, minimo = "_", 4
+		}
+		if len(entero) >= minimo {
+			var b strings.Builder
+			for i, c := range entero {
+				if i > 0 && (len(entero)-i)%3 == 0 {
+					b.WriteString(sep)
+				}
+				b.WriteRune(c)
+			}
+			entero = b.String()
+		}
+	}
+	return entero + resto, nil
+}
+
+// FormatearConEspecificacion escribe un valor según un formato como ",.2f".
+func FormatearConEspecificacion(v interface{}, formato string) (string, error) {
+	esp, err := analizarEspecificacion(formato)
+	if err != nil {
+		return "", err
+	}
+
+	var signo, cuerpo string
+	alineacion := esp.alineacion
+	if esNumero(v) && esp.tipo != 's' {
+		texto, negativo, localizable, err := cifras(v, esp)
+		if err != nil {
+			return "", err
+		}
+		if localizable {
+			if texto, err = localizarNumero(texto, esp); err != nil {
+				return "", err
+			}
+		}
+		if esp.tipo == '%' {
+			texto += "%"
+		}
+		switch {
+		case negativo:
+			signo = "-"
+		case esp.signo == '+':
+			signo = "+"
+		case esp.signo == ' ':
+			signo = " "
+		}
+		cuerpo = texto
+		if alineacion == 0 {
+			alineacion = '>'
+		}
+	} else {
+		if esp.tipo != 0 && esp.tipo != 's' {
+			return "", fmt.Errorf("❌ ERROR FATAL: el formato '%c' requiere un número → %v", esp.tipo, FormatearValor(v))
+		}
+		cuerpo = FormatearValor(v)
+		if esp.precision >= 0 && utf8.RuneCountInString(cuerpo) > esp.precision {
+			// En textos, la precisión recorta: {nombre:.3} → "Mar"
+			cuerpo = string([]rune(cuerpo)[:esp.precision])
+		}
+		if alineacion == 0 || alineacion == '=' {
+			alineacion = '<'
+		}
+	}
+
+	faltan := esp.ancho - utf8.RuneCountInString(signo+cuerpo)
+	if faltan <= 0 {
+		return signo + cuerpo, nil
+	}
+	relleno := func(n int) string { return strings.Repeat(string(esp.relleno), n) }
+	switch alineacion {
+	case '<':
+		return signo + cuerpo + relleno(faltan), nil
+	case '^':
+		return relleno(faltan/2) + signo + cuerpo + relleno(faltan-faltan/2), nil
+	case '=':
+		return signo + relleno(faltan) + cuerpo, nil
+	}
+	return relleno(faltan) + signo + cuerpo, nil
+}
+
+// formatearSinEspecificacion es el formato de {x}: como FormatearValor, pero
+// con el separador decimal de la localización activa.
+func formatearSinEspecificacion(v interface{}) string {
+	switch v.(type) {
+	case float64, Decimal:
+		if texto, err := localizarNumero(FormatearValor(v), especificacion{localizacion: ConfigFormato.Localizacion}); err == nil {
+			return texto
+		}
+	}
+	return FormatearValor(v)
+}
+
+// expandirPlantilla sustituye cada {campo[:formato]} usando resolver. Con
+// soloExpresiones (literales del programa), las llaves que no contienen una
+// expresión se copian tal cual, y {{ }} solo se reducen alrededor de algo que
+// sería un campo ("{{x}}" → "{x}"); así un JSON como "{\"a\": {\"b\": 1}}" no
+// pierde llaves.
+func expandirPlantilla(plantilla string, soloExpresiones bool, resolver func(campo string) (interface{}, bool, error)) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(plantilla); i++ {
+		c := plantilla[i]
+		if (c == '{' || c == '}') && i+1 < len(plantilla) && plantilla[i+1] == c {
+			if !soloExpresiones {
+				b.WriteByte(c)
+				i++
+				continue
+			}
+			if c == '{' && i+2 < len(plantilla) && empiezaCampo(plantilla[i+2:]) {
+				if fin := cierreLlave(plantilla, i+1); fin >= 0 && fin+1 < len(plantilla) && plantilla[fin+1] == '}' {
+					b.WriteString(plantilla[i+1 : fin+1])
+					i = fin + 1
+					continue
+				}
+			}
+			b.WriteString(plantilla[i : i+2])
+			i++
+			continue
+		}
+		// ${grupo} es una referencia de reemplazar_regex, no una interpolación
+		if c != '{' || (soloExpresiones && i > 0 && plantilla[i-1] == '$') {
+			b.WriteByte(c)
+			continue
+		}
+		fin := cierreLlave(plantilla, i)
+		if fin < 0 {
+			if soloExpresiones {
+				b.WriteByte(c)
+				continue
+			}
+			return "", fmt.Errorf("❌ ERROR FATAL: falta '}' en la plantilla → %s", plantilla)
+		}
+		contenido := plantilla[i+1 : fin]
+		campo, formato := separarFormato(contenido)
+		if soloExpresiones && !empiezaCampo(campo) {
+			b.WriteByte(c)
+			continue
+		}
+		valor, encontrado, err := resolver(strings.TrimSpace(campo))
+		if err != nil {
+			return "", err
+		}
+		if !encontrado {
+			b.WriteByte(c)
+			continue
+		}
+		if formato == "" {
+			b.WriteString(formatearSinEspecificacion(valor))
+		} else {
+			texto, err := FormatearConEspecificacion(valor, formato)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(texto)
+		}
+		i = fin
+	}
+	return b.String(), nil
+}
+
+// empiezaCampo indica si el texto puede empezar una expresión interpolada.
+func empiezaCampo(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsLetter(r) || r == '_'
+}
+
+// cierreLlave encuentra la '}' que cierra la llave abierta en inicio,
+// respetando llaves anidadas y cadenas.
+func cierreLlave(s string, inicio int) int {
+	nivel := 0
+	comilla := byte(0)
+	for i := inicio; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case comilla != 0:
+			if c == '\\' {
+				i++
+			} else if c == comilla {
+				comilla = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			comilla = c
+		case c == '{':
+			nivel++
+		case c == '}':
+			nivel--
+			if nivel == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// separarFormato corta "expresion:formato" en el último ':' que no esté
+// dentro de paréntesis, corchetes, llaves o cadenas (s[1:3] no es un formato).
+func separarFormato(contenido string) (string, string) {
+	nivel := 0
+	comilla := byte(0)
+	corte := -1
+	for i := 0; i < len(contenido); i++ {
+		c := contenido[i]
+		switch {
+		case comilla != 0:
+			if c == '\\' {
+				i++
+			} else if c == comilla {
+				comilla = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			comilla = c
+		case c == '(' || c == '[' || c == '{':
+			nivel++
+		case c == ')' || c == ']' || c == '}':
+			nivel--
+		case c == ':' && nivel == 0:
+			corte = i
+		}
+	}
+	if corte < 0 {
+		return contenido, ""
+	}
+	return contenido[:corte], contenido[corte+1:]
+}
+
+// evaluarLiteralInterpolado evalúa un literal y, si es una cadena entre
+// comillas dobles con llaves, sustituye las expresiones con el contexto.
+func evaluarLiteralInterpolado(n *ast.BasicLit, ctx *Contexto) (interface{}, error) {
+	valor, err := evaluarLiteral(n)
+	if err != nil || n.Kind != token.STRING || !strings.HasPrefix(n.Value, `"`) || !strings.Contains(n.Value, "{") {
+		return valor, err
+	}
+	return expandirPlantilla(valor.(string), true, func(campo string) (interface{}, bool, error) {
+		nodo, err := parser.ParseExpr(prepararExpresion(campo))
+		if err != nil {
+			return nil, false, nil
+		}
+		v, err := evaluarNodo(reasociar(nodo), ctx)
+		if errors.Is(err, ErrIdentificadorNoExiste) || errors.Is(err, ErrFuncionNoExiste) {
+			// "a{b}c" sin una variable b es texto, no una interpolación
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("❌ ERROR FATAL: no se pudo interpolar {%s}: %w", campo, err)
+		}
+		return v, true, nil
+	})
+}
+
+// plantillaSinInterpolar hace que el literal de una plantilla de formatear()
+// llegue intacto a la función: {nombre} es un campo, no una variable.
+func plantillaSinInterpolar(e ast.Expr) ast.Expr {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING || !strings.HasPrefix(lit.Value, `"`) {
+		return e
+	}
+	valor, err := evaluarLiteral(lit)
+	if err != nil || strings.Contains(valor.(string), "`") {
+		return e
+	}
+	return &ast.BasicLit{ValuePos: lit.ValuePos, Kind: token.STRING, Value: "`" + valor.(string) + "`"}
+}
+
+func init() {
+	// formatear(plantilla, args...) -> "{} {0} {nombre:,.2f}" con argumentos por
+	// posición o con un diccionario; formatear(valor, formato) da formato a un solo valor.
+	Funciones["formatear"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'formatear' requiere una plantilla o un valor y un formato")
+		}
+		plantilla, esTexto := args[0].(string)
+		if !esTexto {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'formatear' con un valor requiere un formato, ej. formatear(x, \",.2f\")")
+			}
+			formato, err := comoCadena("formatear", args[1])
+			if err != nil {
+				return nil, err
+			}
+			return FormatearConEspecificacion(args[0], formato)
+		}
+		valores := args[1:]
+		var campos map[string]interface{}
+		if len(valores) == 1 {
+			campos, _ = valores[0].(map[string]interface{})
+		}
+		siguiente := 0
+		return expandirPlantilla(plantilla, false, func(campo string) (interface{}, bool, error) {
+			if campo == "" {
+				if siguiente >= len(valores) {
+					return nil, false, fmt.Errorf("❌ ERROR FATAL: 'formatear' tiene más {} que argumentos (%d)", len(valores))
+				}
+				siguiente++
+				return valores[siguiente-1], true, nil
+			}
+			if i, err := strconv.Atoi(campo); err == nil {
+				if i < 0 || i >= len(valores) {
+					return nil, false, fmt.Errorf("❌ ERROR FATAL: 'formatear' no tiene el argumento {%d}", i)
+				}
+				return valores[i], true, nil
+			}
+			if v, ok := campos[campo]; ok {
+				return v, true, nil
+			}
+			return nil, false, fmt.Errorf("❌ ERROR FATAL: 'formatear' no encontró el campo {%s}", campo)
+		})
+	}
+
+	// configurar_formato(localizacion) -> "es-MX" (1,234.5) o "es-ES" (12.345,5)
+	Funciones["configurar_formato"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'configurar_formato' requiere una localización, ej. \"es-ES\"")
+		}
+		nombre, err := comoCadena("configurar_formato", args[0])
+		if err != nil {
+			return nil, err
+		}
+		if _, err := buscarLocalizacion(nombre); err != nil {
+			return nil, err
+		}
+		ConfigFormato.Localizacion = nombre
+		return true, nil
+	}
+
+	// Métodos: "Hola {}".formatear(nombre), total.formatear(",.2f")
+	for _, tipo := range []string{"cadena", "entero", "real", "decimal", "racional"} {
+		Funciones[tipo+".formatear"] = Funciones["formatear"]
+	}
+}
@@ -0,0 +1,121 @@
+package evaluador
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCadenasInterpoladas(t *testing.T) {
+	ctx := PrepararContextoEvaluador()
+	ctx.Variables["nombre"] = "Ana"
+	ctx.Variables["total"] = 1234.5
+	ctx.Variables["x"] = 3
+
+	casos := []struct {
+		expresion string
+		esperado  string
+	}{
+		{`"Hola {nombre}"`, "Hola Ana"},
+		{`"Total: {total:,.2f}"`, "Total: 1,234.50"},
+		{`"{x + 1:>4}|"`, "   4|"},
+		{`"{nombre:.2}"`, "An"},
+		{`"{{x}} vale {x}"`, "{x} vale 3"},
+		// lo que no es un campo queda igual
+		{`"\\d{2}"`, `\d{2}`},
+		{`"${1}"`, "${1}"},
+		{`"{hola mundo}"`, "{hola mundo}"},
+		{`"a{b}c"`, "a{b}c"},
+		{`"{b + 1}"`, "{b + 1}"},
+		{`"{sin_registrar(2)}"`, "{sin_registrar(2)}"},
+		// las llaves dobles que no rodean un campo no se tocan
+		{`"{\"a\": {\"b\": 1}}"`, `{"a": {"b": 1}}`},
+		{`"{\"a\": [{\"b\": {\"c\": 2}}]}"`, `{"a": [{"b": {"c": 2}}]}`},
+		{`"{\"k\": {x}}"`, `{"k": 3}`},
+		{`"{{"`, "{{"},
+		{`"}}"`, "}}"},
+		{"`{nombre}`", "{nombre}"},
+	}
+	for _, c := range casos {
+		r, err := EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if r != c.esperado {
+			t.Errorf("%s = %q, se esperaba %q", c.expresion, r, c.esperado)
+		}
+	}
+
+	if _, err := EvalConContexto(`"{x / 0}"`, ctx); err == nil {
+		t.Error("un campo que existe pero falla debía dar error")
+	}
+}
+
+func TestFormatearConEspecificacion(t *testing.T) {
+	casos := []struct {
+		valor    interface{}
+		formato  string
+		esperado string
+	}{
+		{1234567.891, ",.2f", "1,234,567.89"},
+		{1234567.891, ",.2f@es-ES", "1.234.567,89"},
+		{1234, ",d@es-ES", "1234"},
+		{12345, ",d@es-ES", "12.345"},
+		{-42, "05d", "-0042"},
+		{42, "+d", "+42"},
+		{0.256, ".1%", "25.6%"},
+		{255, "x", "ff"},
+		{255, "#>6X", "####FF"},
+		{1000000, "_d", "1_000_000"},
+		{3.5, "*^9.2f", "**3.50***"},
+		{"abc", ">5", "  abc"},
+		{"Martínez", ".3", "Mar"},
+		{DecimalDesdeEntero(2), ".3f", "2.000"},
+	}
+	for _, c := range casos {
+		r, err := FormatearConEspecificacion(c.valor, c.formato)
+		if err != nil {
+			t.Errorf("%v con %q: %v", c.valor, c.formato, err)
+			continue
+		}
+		if r != c.esperado {
+			t.Errorf("%v con %q = %q, se esperaba %q", c.valor, c.formato, r, c.esperado)
+		}
+	}
+
+	for _, formato := range []string{"99999999999", ".99999999999", "100000000", ".100000000f", "99999999999999999999999", ".", "10q", "@xx-XX"} {
+		if _, err := FormatearConEspecificacion(1, formato); err == nil {
+			t.Errorf("el formato %q debía fallar", formato)
+		}
+	}
+	if _, err := Funciones["formatear"]("{:99999999999}", 1); err == nil || !strings.Contains(err.Error(), "fuera de rango") {
+		t.Errorf("formatear con un ancho enorme: %v", err)
+	}
+}
+
+func TestFormatearPlantilla(t *testing.T) {
+	casos := []struct {
+		args     []interface{}
+		esperado string
+	}{
+		{[]interface{}{"{} y {}", 1, "dos"}, "1 y dos"},
+		{[]interface{}{"{1} {0}", "a", "b"}, "b a"},
+		{[]interface{}{"{nombre}: {saldo:,.2f}", map[string]interface{}{"nombre": "Luis", "saldo": 1500.0}}, "Luis: 1,500.00"},
+		{[]interface{}{"{{}} {}", 1}, "{} 1"},
+	}
+	for _, c := range casos {
+		r, err := Funciones["formatear"](c.args...)
+		if err != nil {
+			t.Errorf("formatear%v: %v", c.args, err)
+			continue
+		}
+		if r != c.esperado {
+			t.Errorf("formatear%v = %q, se esperaba %q", c.args, r, c.esperado)
+		}
+	}
+	for _, args := range [][]interface{}{{"{} {}", 1}, {"{3}", 1}, {"{falta}", map[string]interface{}{}}, {"{abierta", 1}} {
+		if _, err := Funciones["formatear"](args...); err == nil {
+			t.Errorf("formatear%v debía fallar", args)
+		}
+	}
+}
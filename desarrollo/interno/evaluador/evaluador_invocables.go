@@ -0,0 +1,133 @@
+package evaluador
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"strings"
+)
+
+// ErrNoInvocable se produce al pasar un valor que no es una función donde se
+// espera una (derivar(5, 1)).
+var ErrNoInvocable = errors.New("❌ ERROR FATAL: el valor no es una función")
+
+// Funcion es un valor invocable: una función nativa registrada (seno), una
+// función de usuario definida con 'funcion f(real x):' o una lambda. Permite
+// pasar funciones como argumento: derivar(f, 2), integrar(seno, 0, pi).
+type Funcion struct {
+	Nombre     string
+	Parametros []string // nil si la aridad es libre (funciones nativas)
+	llamar     func(args ...interface{}) (interface{}, error)
+	fuente     string // texto de la lambda, para mostrarla
+}
+
+// NuevaFuncion construye un valor invocable a partir de su implementación.
+func NuevaFuncion(nombre string, parametros []string, llamar func(args ...interface{}) (interface{}, error)) Funcion {
+	return Funcion{Nombre: nombre, Parametros: parametros, llamar: llamar}
+}
+
+// Llamar invoca la función verificando la cantidad de argumentos si se conoce.
+func (f Funcion) Llamar(args ...interface{}) (interface{}, error) {
+	if f.llamar == nil {
+		return nil, ErrNoInvocable
+	}
+	if f.Parametros != nil && len(args) != len(f.Parametros) {
+		return nil, fmt.Errorf("❌ ERROR FATAL: '%s' espera %d argumentos y recibió %d", f.Etiqueta(), len(f.Parametros), len(args))
+	}
+	return f.llamar(args...)
+}
+
+// LlamarReal invoca la función con reales y exige un resultado real; es el
+// puente que usan los métodos numéricos (derivar, integrar, raiz_de...).
+func (f Funcion) LlamarReal(xs ...float64) (float64, error) {
+	args := make([]interface{}, len(xs))
+	for i, x := range xs {
+		args[i] = x
+	}
+	res, err := f.Llamar(args...)
+	if err != nil {
+		return 0, err
+	}
+	return ConvertirAReal(res)
+}
+
+// Etiqueta identifica la función en mensajes: su nombre o, si es anónima, su texto.
+func (f Funcion) Etiqueta() string {
+	if f.Nombre != "" {
+		return f.Nombre
+	}
+	return f.String()
+}
+
+func (f Funcion) String() string {
+	if f.fuente != "" {
+		return f.fuente
+	}
+	nombre := f.Nombre
+	if nombre == "" {
+		nombre = "anonima"
+	}
+	if f.Parametros == nil {
+		return "funcion " + nombre
+	}
+	return fmt.Sprintf("funcion %s(%s)", nombre, strings.Join(f.Parametros, ", "))
+}
+
+// ComoFuncion acepta un valor Funcion o el nombre de una función registrada
+// ("seno"), de modo que derivar("seno", 0) y derivar(seno, 0) son equivalentes.
+// Un polinomio también es invocable: p(2) lo evalúa en 2, y una expresión
+// simbólica recibe sus variables en orden alfabético.
+func ComoFuncion(v interface{}) (Funcion, bool) {
+	switch x := v.(type) {
+	case Funcion:
+		return x, x.llamar != nil
+	case Polinomio:
+		f := NuevaFuncion("", []string{x.variable}, func(args ...interface{}) (interface{}, error) {
+			return x.EvaluarValor(args[0])
+		})
+		f.fuente = x.String()
+		return f, true
+	case Simbolico:
+		variables := x.Variables()
+		f := NuevaFuncion("", variables, func(args ...interface{}) (interface{}, error) {
+			valores := make(map[string]interface{}, len(variables))
+			for i, v := range variables {
+				valores[v] = args[i]
+			}
+			return x.Evaluar(valores)
+		})
+		f.fuente = x.String()
+		return f, true
+	case string:
+		nombre := strings.ToLower(strings.TrimSpace(x))
+		if f, ok := Funciones[nombre]; ok {
+			return NuevaFuncion(nombre, nil, f), true
+		}
+	}
+	return Funcion{}, false
+}
+
+// comoInvocable es ComoFuncion sin nombres: un texto guardado en una variable
+// no se llama como función.
+func comoInvocable(v interface{}) (Funcion, bool) {
+	if _, esTexto := v.(string); esTexto {
+		return Funcion{}, false
+	}
+	return ComoFuncion(v)
+}
+
+// operarFunciones rechaza operar con funciones (comparar dos Funcion con ==
+// haría entrar en pánico a Go). Con texto se concatena su representación.
+func operarFunciones(op token.Token, izq, der interface{}) (interface{}, bool, error) {
+	_, esFuncIzq := izq.(Funcion)
+	_, esFuncDer := der.(Funcion)
+	if !esFuncIzq && !esFuncDer {
+		return nil, false, nil
+	}
+	_, esTextoIzq := izq.(string)
+	_, esTextoDer := der.(string)
+	if op == token.ADD && (esTextoIzq || esTextoDer) {
+		return nil, false, nil
+	}
+	return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no está definido para funciones", nombreOperador(op))
+}
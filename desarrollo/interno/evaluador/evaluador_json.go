@@ -0,0 +1,318 @@
+package evaluador
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"nepa/desarrollo/interno/administrador"
+)
+
+// JSON:
+//   config = json_archivo("config.json")
+//   datos = json_leer(`{"nombre": "Ana", "medidas": [1, 2.5]}`)
+//   texto = json_escribir(datos)              → compacto
+//   texto = json_escribir(datos, verdadero)   → indentado con 2 espacios (o un entero)
+//
+// Al leer, los objetos son diccionarios y los arreglos listas; un número sin
+// punto ni exponente es entero (de cualquier tamaño) y cualquier otro es real.
+// Al escribir, los reales llevan siempre punto o exponente (2.0, no 2) para
+// que vuelvan a leerse como real, las claves salen ordenadas y los tipos sin
+// equivalente en JSON usan una forma fija:
+//   fecha/tiempo  "2024-05-01" o "2024-05-01 13:45:00"
+//   duracion      "P1M15DT2H" (ISO 8601)
+//   caracter      "ñ"
+//   racional      {"numerador": 1, "denominador": 3}
+//   complejo      {"real": 1.0, "imag": -2.0}
+//   cantidad      {"valor": 9.8, "unidad": "m/s^2"}
+//   polinomio     {"variable": "x", "coeficientes": [1.0, 0.0, 3.0]}
+//   simbolico     "x^2 + 1"
+// NaN, infinitos, funciones y archivos no tienen representación y son error.
+
+// LeerJSON convierte texto JSON en valores de nepa.
+func LeerJSON(texto string) (interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(texto))
+	dec.UseNumber()
+	var crudo interface{}
+	if err := dec.Decode(&crudo); err != nil {
+		return nil, errorJSON(texto, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("❌ ERROR FATAL: JSON inválido: sobra texto después del valor (%s)", posicionJSON(texto, dec.InputOffset()))
+	}
+	return desdeJSON(crudo)
+}
+
+// errorJSON agrega línea y columna a los errores de sintaxis.
+func errorJSON(texto string, err error) error {
+	var sintaxis *json.SyntaxError
+	if errors.As(err, &sintaxis) {
+		return fmt.Errorf("❌ ERROR FATAL: JSON inválido en %s: %v", posicionJSON(texto, sintaxis.Offset), err)
+	}
+	if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("❌ ERROR FATAL: JSON inválido: el texto termina antes de completar el valor")
+	}
+	return fmt.Errorf("❌ ERROR FATAL: JSON inválido: %v", err)
+}
+
+func posicionJSON(texto string, offset int64) string {
+	if offset > int64(len(texto)) {
+		offset = int64(len(texto))
+	}
+	antes := texto[:offset]
+	linea := strings.Count(antes, "\n") + 1
+	columna := len([]rune(antes[strings.LastIndex(antes, "\n")+1:]))
+	return fmt.Sprintf("línea %d, columna %d", linea, columna)
+}
+
+func desdeJSON(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case json.Number:
+		return numeroDesdeJSON(string(x))
+	case []interface{}:
+		res := make([]interface{}, len(x))
+		for i, e := range x {
+			convertido, err := desdeJSON(e)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = convertido
+		}
+		return res, nil
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			convertido, err := desdeJSON(e)
+			if err != nil {
+				return nil, err
+			}
+			res[k] = convertido
+		}
+		return res, nil
+	}
+	// string, bool y nil pasan tal cual
+	return v, nil
+}
+
+// numeroDesdeJSON: entero si no hay punto ni exponente, real en otro caso.
+func numeroDesdeJSON(texto string) (interface{}, error) {
+	if !strings.ContainsAny(texto, ".eE") {
+		if n, err := strconv.Atoi(texto); err == nil {
+			return n, nil
+		}
+		if n, ok := new(big.Int).SetString(texto, 10); ok {
+			return n, nil
+		}
+	}
+	f, err := strconv.ParseFloat(texto, 64)
+	if err != nil {
+		return nil, fmt.Errorf("❌ ERROR FATAL: número JSON fuera de rango: %s", texto)
+	}
+	return f, nil
+}
+
+// EscribirJSON convierte un valor de nepa a texto JSON; con sangria > 0 se
+// indenta con esa cantidad de espacios.
+func EscribirJSON(v interface{}, sangria int) (string, error) {
+	var b bytes.Buffer
+	if err := escribirValorJSON(&b, v); err != nil {
+		return "", err
+	}
+	if sangria <= 0 {
+		return b.String(), nil
+	}
+	var indentado bytes.Buffer
+	if err := json.Indent(&indentado, b.Bytes(), "", strings.Repeat(" ", sangria)); err != nil {
+		return "", err
+	}
+	return indentado.String(), nil
+}
+
+func cadenaJSON(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// realJSON escribe un real que al leerse vuelve a ser real.
+func realJSON(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("❌ ERROR FATAL: JSON no admite %s", FormatearValor(f))
+	}
+	abs := math.Abs(f)
+	formato := byte('f')
+	if abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		formato = 'e'
+	}
+	texto := strconv.FormatFloat(f, formato, -1, 64)
+	if !strings.ContainsAny(texto, ".e") {
+		texto += ".0"
+	}
+	return texto, nil
+}
+
+func escribirValorJSON(b *bytes.Buffer, v interface{}) error {
+	switch x := v.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool:
+		b.WriteString(strconv.FormatBool(x))
+	case string:
+		b.WriteString(cadenaJSON(x))
+	case rune:
+		b.WriteString(cadenaJSON(string(x)))
+	case int:
+		b.WriteString(strconv.Itoa(x))
+	case int64:
+		b.WriteString(strconv.FormatInt(x, 10))
+	case *big.Int:
+		b.WriteString(x.String())
+	case float64:
+		texto, err := realJSON(x)
+		if err != nil {
+			return err
+		}
+		b.WriteString(texto)
+	case Decimal:
+		texto := x.String()
+		if !strings.Contains(texto, ".") {
+			texto += ".0"
+		}
+		b.WriteString(texto)
+	case *big.Rat:
+		return escribirValorJSON(b, map[string]interface{}{"numerador": new(big.Int).Set(x.Num()), "denominador": new(big.Int).Set(x.Denom())})
+	case complex128:
+		return escribirValorJSON(b, map[string]interface{}{"real": real(x), "imag": imag(x)})
+	case Cantidad:
+		return escribirValorJSON(b, map[string]interface{}{"valor": x.Valor, "unidad": x.Unidad()})
+	case Polinomio:
+		coef := make([]interface{}, 0, x.Grado()+1)
+		for _, c := range x.Coeficientes() {
+			coef = append(coef, c)
+		}
+		return escribirValorJSON(b, map[string]interface{}{"variable": x.Variable(), "coeficientes": coef})
+	case Simbolico:
+		b.WriteString(cadenaJSON(x.String()))
+	case time.Time:
+		b.WriteString(cadenaJSON(FormatearFecha(x)))
+	case Duracion:
+		b.WriteString(cadenaJSON(x.ISO()))
+	case time.Duration:
+		b.WriteString(cadenaJSON(Duracion{Exacta: x}.ISO()))
+	case administrador.Variable:
+		return escribirValorJSON(b, x.ValorComoInterface())
+	case Funcion, *Archivo:
+		return fmt.Errorf("❌ ERROR FATAL: un valor de tipo %s no se puede convertir a JSON", obtenerTipoEnEspañol(v))
+	case map[string]interface{}:
+		claves := make([]string, 0, len(x))
+		for k := range x {
+			claves = append(claves, k)
+		}
+		sort.Strings(claves)
+		b.WriteByte('{')
+		for i, k := range claves {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(cadenaJSON(k))
+			b.WriteByte(':')
+			if err := escribirValorJSON(b, x[k]); err != nil {
+				return err
+			}
+		}
+		b.WriteByte('}')
+	default:
+		// Listas y matrices de cualquier tipo de elemento ([]interface{}, [][]float64...)
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return fmt.Errorf("❌ ERROR FATAL: un valor de tipo %s no se puede convertir a JSON", obtenerTipoEnEspañol(v))
+		}
+		b.WriteByte('[')
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if err := escribirValorJSON(b, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+	}
+	return nil
+}
+
+func init() {
+	// Variable.JSON() escribe los valores igual que json_escribir
+	administrador.RegistrarCodificadorJSON(func(v interface{}) (string, error) { return EscribirJSON(v, 0) })
+
+	// json_leer(texto) -> diccionarios, listas, enteros, reales, textos, booleanos y nulo
+	Funciones["json_leer"] = func(args ...interface{}) (interface{}, error) {
+		texto, err := argumentosCadena("json_leer", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		return LeerJSON(texto)
+	}
+
+	// json_archivo(ruta) -> json_leer del contenido del archivo
+	Funciones["json_archivo"] = func(args ...interface{}) (interface{}, error) {
+		ruta, err := argumentosRuta("json_archivo", args, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		datos, err := os.ReadFile(ruta)
+		if err != nil {
+			return nil, errorArchivo("json_archivo", ruta, err)
+		}
+		v, err := LeerJSON(string(bytes.TrimPrefix(datos, []byte("\ufeff"))))
+		if err != nil {
+			return nil, fmt.Errorf("%v (en '%s')", err, ruta)
+		}
+		return v, nil
+	}
+
+	// json_escribir(valor, [indentado]) -> texto JSON; indentado es verdadero
+	// (2 espacios) o la cantidad de espacios
+	Funciones["json_escribir"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'json_escribir' requiere un valor y opcionalmente verdadero o la cantidad de espacios")
+		}
+		sangria := 0
+		if len(args) == 2 {
+			if b, ok := args[1].(bool); ok {
+				if b {
+					sangria = 2
+				}
+			} else {
+				n, err := comoEnteroPequeño("json_escribir", args[1])
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("❌ ERROR FATAL: 'json_escribir' espera verdadero/falso o una cantidad de espacios, recibió %v", FormatearValor(args[1]))
+				}
+				sangria = n
+			}
+		}
+		texto, err := EscribirJSON(args[0], sangria)
+		if err != nil {
+			return nil, err
+		}
+		return texto, nil
+	}
+
+	// Métodos: config.json_escribir(verdadero), m.json_escribir(), "[1, 2]".json_leer()
+	for _, tipo := range []string{"lista", "diccionario", "objeto", "entero", "real", "decimal", "racional", "complejo", "booleano"} {
+		Funciones[tipo+".json_escribir"] = Funciones["json_escribir"]
+	}
+	Funciones["cadena.json_leer"] = Funciones["json_leer"]
+}
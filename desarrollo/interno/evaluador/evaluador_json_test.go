@@ -0,0 +1,37 @@
+package evaluador
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONIdaYVuelta(t *testing.T) {
+	valores := []interface{}{
+		nil,
+		true,
+		42,
+		-2.5,
+		"texto con \"comillas\" y ñ",
+		[]interface{}{1, "dos", 3.5, false, nil},
+		map[string]interface{}{"a": 1, "b": []interface{}{map[string]interface{}{"c": "d"}}, "vacío": map[string]interface{}{}},
+	}
+	for _, v := range valores {
+		for _, sangria := range []int{0, 2} {
+			texto, err := EscribirJSON(v, sangria)
+			if err != nil {
+				t.Fatalf("EscribirJSON(%#v): %v", v, err)
+			}
+			leido, err := LeerJSON(texto)
+			if err != nil {
+				t.Fatalf("LeerJSON(%s): %v", texto, err)
+			}
+			if !reflect.DeepEqual(leido, v) {
+				t.Errorf("ida y vuelta de %#v dio %#v (texto %s)", v, leido, texto)
+			}
+		}
+	}
+
+	if _, err := LeerJSON(`{"a": 1,}`); err == nil {
+		t.Error("un JSON inválido debía fallar")
+	}
+}
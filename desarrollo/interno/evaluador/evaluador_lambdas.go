@@ -0,0 +1,190 @@
+package evaluador
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// Las funciones anónimas se escriben funcion(x, y) => x^2 + y. Como go/parser
+// no conoce esa sintaxis, prepararExpresion las traduce a una llamada interna
+// __lambda("x, y", "x^2 + y") que se resuelve aquí capturando el contexto.
+
+const identificadorLambda = "__lambda"
+
+var ErrLambdaInvalida = errors.New("❌ ERROR FATAL: función anónima inválida")
+
+// traducirLambdas reemplaza cada 'funcion(params) => cuerpo' por la llamada
+// interna. El cuerpo llega hasta la coma o el paréntesis que cierra el nivel
+// en el que aparece la lambda, o hasta el final de la expresión.
+func traducirLambdas(expr string) string {
+	if !strings.Contains(expr, "=>") {
+		return expr
+	}
+	var b strings.Builder
+	comilla := byte(0)
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		if comilla != 0 {
+			if c == '\\' && i+1 < len(expr) {
+				b.WriteByte(c)
+				i++
+				c = expr[i]
+			} else if c == comilla {
+				comilla = 0
+			}
+			b.WriteByte(c)
+			continue
+		}
+		if c == '"' || c == '\'' || c == '`' {
+			comilla = c
+			b.WriteByte(c)
+			continue
+		}
+		if strings.HasPrefix(expr[i:], "funcion") && (i == 0 || !esParteIdentificador(expr[i-1])) {
+			if params, cuerpo, fin, ok := leerLambda(expr, i+len("funcion")); ok {
+				fmt.Fprintf(&b, "%s(%s, %s)", identificadorLambda, strconv.Quote(params), strconv.Quote(cuerpo))
+				i = fin - 1
+				continue
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// leerLambda reconoce '(params) => cuerpo' desde la posición inicio y devuelve
+// dónde termina el cuerpo.
+func leerLambda(expr string, inicio int) (string, string, int, bool) {
+	i := inicio
+	for i < len(expr) && expr[i] == ' ' {
+		i++
+	}
+	if i >= len(expr) || expr[i] != '(' {
+		return "", "", 0, false
+	}
+	cierre := strings.IndexByte(expr[i:], ')')
+	if cierre < 0 {
+		return "", "", 0, false
+	}
+	params := expr[i+1 : i+cierre]
+	i += cierre + 1
+	for i < len(expr) && expr[i] == ' ' {
+		i++
+	}
+	if !strings.HasPrefix(expr[i:], "=>") {
+		return "", "", 0, false
+	}
+	i += 2
+
+	desde := i
+	nivel := 0
+	comilla := byte(0)
+	for ; i < len(expr); i++ {
+		c := expr[i]
+		if comilla != 0 {
+			if c == '\\' {
+				i++
+			} else if c == comilla {
+				comilla = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'', '`':
+			comilla = c
+		case '(', '[', '{':
+			nivel++
+		case ')', ']', '}':
+			if nivel == 0 {
+				return params, strings.TrimSpace(expr[desde:i]), i, true
+			}
+			nivel--
+		case ',':
+			if nivel == 0 {
+				return params, strings.TrimSpace(expr[desde:i]), i, true
+			}
+		}
+	}
+	return params, strings.TrimSpace(expr[desde:]), len(expr), true
+}
+
+// crearLambda construye la Funcion a partir de la llamada interna. El cuerpo
+// se analiza una sola vez; cada invocación lo evalúa con los parámetros sobre
+// una copia de las variables visibles al crearla (clausura), tomada en ese
+// momento y no al llamarla.
+func crearLambda(n *ast.CallExpr, ctx *Contexto) (interface{}, error) {
+	if len(n.Args) != 2 {
+		return nil, ErrLambdaInvalida
+	}
+	if ctx == nil {
+		ctx = &Contexto{}
+	}
+	var textos [2]string
+	for i, a := range n.Args {
+		lit, ok := a.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return nil, ErrLambdaInvalida
+		}
+		texto, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return nil, ErrLambdaInvalida
+		}
+		textos[i] = texto
+	}
+
+	// Los parámetros pueden llevar tipo: funcion(real x) => ...
+	parametros := []string{}
+	for _, p := range strings.Split(textos[0], ",") {
+		campos := strings.Fields(p)
+		if len(campos) == 0 {
+			continue
+		}
+		nombre := strings.ToLower(campos[len(campos)-1])
+		if !token.IsIdentifier(nombre) {
+			return nil, fmt.Errorf("%w: parámetro '%s'", ErrLambdaInvalida, strings.TrimSpace(p))
+		}
+		parametros = append(parametros, nombre)
+	}
+	if textos[1] == "" {
+		return nil, fmt.Errorf("%w: falta el cuerpo después de '=>'", ErrLambdaInvalida)
+	}
+	arbol, err := parser.ParseExpr(prepararExpresion(textos[1]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLambdaInvalida, err)
+	}
+	cuerpo := reasociar(arbol)
+
+	// La clausura guarda los valores de ahora: una lambda creada dentro de un
+	// ciclo recuerda la variable del ciclo en ese paso aunque luego cambie.
+	capturadas := make(map[string]interface{}, len(ctx.Variables))
+	for k, v := range ctx.Variables {
+		if variable, ok := v.(interface{ ValorComoInterface() interface{} }); ok {
+			v = variable.ValorComoInterface()
+		}
+		capturadas[k] = v
+	}
+
+	llamar := func(args ...interface{}) (interface{}, error) {
+		local := &Contexto{
+			Variables:  make(map[string]interface{}, len(capturadas)+len(parametros)),
+			Globales:   ctx.Globales,
+			Constantes: ctx.Constantes,
+			Funciones:  ctx.Funciones,
+		}
+		for k, v := range capturadas {
+			local.Variables[k] = v
+		}
+		for i, p := range parametros {
+			local.Variables[p] = args[i]
+		}
+		return evaluarNodo(cuerpo, local)
+	}
+	f := NuevaFuncion("", parametros, llamar)
+	f.fuente = fmt.Sprintf("funcion(%s) => %s", strings.Join(parametros, ", "), textos[1])
+	return f, nil
+}
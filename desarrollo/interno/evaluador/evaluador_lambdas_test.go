@@ -0,0 +1,57 @@
+package evaluador
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLambdas(t *testing.T) {
+	ctx := PrepararContextoEvaluador()
+	ctx.Variables["l"] = []interface{}{3, 1, 4, 1, 5, 9, 2, 6}
+	ctx.Variables["palabras"] = []interface{}{"pera", "uva", "manzana", "kiwi"}
+	ctx.Variables["k"] = 10
+	Funciones["doble_prueba"] = func(args ...interface{}) (interface{}, error) { return args[0].(int) * 2, nil }
+	defer delete(Funciones, "doble_prueba")
+
+	casos := []struct {
+		expresion string
+		esperado  string
+	}{
+		{"funcion(x) => x^2 + 1", "funcion(x) => x^2 + 1"},
+		{"funcion() => 7", "funcion() => 7"},
+		{"(funcion(x) => x^2 + 1)(3)", "10"},
+		{"mapear(l, funcion(x) => x * 2)", "[6, 2, 8, 2, 10, 18, 4, 12]"},
+		{"filtrar(l, funcion(x) => x > 2)", "[3, 4, 5, 9, 6]"},
+		{"reducir(l, funcion(a, x) => a + x)", "31"},
+		{"reducir(l, funcion(a, x) => a + x, 100)", "131"},
+		{"ordenar_por(palabras, funcion(p) => p.longitud())", "[uva, pera, kiwi, manzana]"},
+		{"ordenar_por(l, funcion(x) => -x)", "[9, 6, 5, 4, 3, 2, 1, 1]"},
+		{"l.mapear(funcion(x) => x % 2)", "[1, 1, 0, 1, 1, 1, 0, 0]"},
+		// las lambdas capturan las variables del contexto donde se crean
+		{"mapear(l, funcion(x) => x + k)", "[13, 11, 14, 11, 15, 19, 12, 16]"},
+		{"(funcion(x) => funcion(y) => x + y)(2)(40)", "42"},
+		// las funciones con nombre también son valores
+		{"mapear(l, doble_prueba)", "[6, 2, 8, 2, 10, 18, 4, 12]"},
+	}
+	for _, c := range casos {
+		r, err := EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if s := FormatearValor(r); s != c.esperado {
+			t.Errorf("%s = %s, se esperaba %s", c.expresion, s, c.esperado)
+		}
+	}
+
+	for expresion, mensaje := range map[string]string{
+		"filtrar(l, funcion(x) => x)":                                        "verdadero o falso",
+		"(funcion(x) => x) == (funcion(x) => x)":                             "no está definido para funciones",
+		"(funcion(x, y) => x)(1)":                                            "espera 2 argumentos y recibió 1",
+		"reducir(filtrar(l, funcion(x) => x > 100), funcion(a, x) => a + x)": "requiere un valor inicial",
+	} {
+		if _, err := EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
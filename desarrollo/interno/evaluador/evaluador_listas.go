@@ -0,0 +1,249 @@
+package evaluador
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Funciones de orden superior sobre listas: reciben una función (lambda,
+// función de usuario o nativa) y la aplican a cada elemento.
+//   mapear(lista, funcion(x) => x * 2)
+//   filtrar(lista, funcion(x) => x > 0)
+//   reducir(lista, funcion(acumulado, x) => acumulado + x, 0)
+//   ordenar_por(lista, funcion(p) => p.longitud())
+
+// comoLista acepta cualquier lista o arreglo de Go ([]interface{}, []float64...).
+func comoLista(nombre string, v interface{}) ([]interface{}, error) {
+	if l, ok := v.([]interface{}); ok {
+		return l, nil
+	}
+	rv := reflect.ValueOf(v)
+	if v == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return nil, fmt.Errorf("❌ ERROR FATAL: '%s' requiere una lista → %v", nombre, FormatearValor(v))
+	}
+	res := make([]interface{}, rv.Len())
+	for i := range res {
+		res[i] = rv.Index(i).Interface()
+	}
+	return res, nil
+}
+
+// argumentosOrdenSuperior valida (lista, función, [extras...]).
+func argumentosOrdenSuperior(nombre string, args []interface{}, minimo, maximo int) ([]interface{}, Funcion, error) {
+	if len(args) < minimo || len(args) > maximo {
+		return nil, Funcion{}, fmt.Errorf("❌ ERROR FATAL: '%s' requiere una lista y una función", nombre)
+	}
+	lista, err := comoLista(nombre, args[0])
+	if err != nil {
+		return nil, Funcion{}, err
+	}
+	f, ok := ComoFuncion(args[1])
+	if !ok {
+		return nil, Funcion{}, fmt.Errorf("%w en '%s' → %v", ErrNoInvocable, nombre, FormatearValor(args[1]))
+	}
+	return lista, f, nil
+}
+
+// menorQue compara claves de ordenamiento: texto con texto y el resto con el
+// operador '<' del lenguaje (enteros, reales, decimales, cantidades...).
+func menorQue(a, b interface{}) (bool, error) {
+	ta, esTextoA := a.(string)
+	tb, esTextoB := b.(string)
+	if esTextoA && esTextoB {
+		return ta < tb, nil
+	}
+	res, err := aplicarOperacion(token.LSS, a, b)
+	if err != nil {
+		return false, err
+	}
+	menor, ok := res.(bool)
+	if !ok {
+		return false, fmt.Errorf("❌ ERROR FATAL: no se pueden ordenar %v y %v", FormatearValor(a), FormatearValor(b))
+	}
+	return menor, nil
+}
+
+func init() {
+	// mapear(lista, f) -> [f(x) para cada x]
+	Funciones["mapear"] = func(args ...interface{}) (interface{}, error) {
+		lista, f, err := argumentosOrdenSuperior("mapear", args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]interface{}, len(lista))
+		for i, x := range lista {
+			if res[i], err = f.Llamar(x); err != nil {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'mapear' falló en el elemento %d: %w", i, err)
+			}
+		}
+		return res, nil
+	}
+
+	// filtrar(lista, f) -> elementos para los que f(x) es verdadero
+	Funciones["filtrar"] = func(args ...interface{}) (interface{}, error) {
+		lista, f, err := argumentosOrdenSuperior("filtrar", args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		res := []interface{}{}
+		for i, x := range lista {
+			v, err := f.Llamar(x)
+			if err != nil {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'filtrar' falló en el elemento %d: %w", i, err)
+			}
+			conservar, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("❌ ERROR FATAL: la función de 'filtrar' debe devolver verdadero o falso (devolvió %v)", FormatearValor(v))
+			}
+			if conservar {
+				res = append(res, x)
+			}
+		}
+		return res, nil
+	}
+
+	// reducir(lista, f, [inicial]) -> f(...f(f(inicial, x0), x1)..., xn)
+	Funciones["reducir"] = func(args ...interface{}) (interface{}, error) {
+		lista, f, err := argumentosOrdenSuperior("reducir", args, 2, 3)
+		if err != nil {
+			return nil, err
+		}
+		var acumulado interface{}
+		if len(args) == 3 {
+			acumulado = args[2]
+		} else {
+			if len(lista) == 0 {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'reducir' sobre una lista vacía requiere un valor inicial")
+			}
+			acumulado, lista = lista[0], lista[1:]
+		}
+		for i, x := range lista {
+			if acumulado, err = f.Llamar(acumulado, x); err != nil {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'reducir' falló en el elemento %d: %w", i, err)
+			}
+		}
+		return acumulado, nil
+	}
+
+	// ordenar_por(lista, f) -> copia ordenada (estable) según la clave f(x)
+	Funciones["ordenar_por"] = func(args ...interface{}) (interface{}, error) {
+		lista, f, err := argumentosOrdenSuperior("ordenar_por", args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		// Cada clave se calcula una sola vez
+		claves := make([]interface{}, len(lista))
+		for i, x := range lista {
+			if claves[i], err = f.Llamar(x); err != nil {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'ordenar_por' falló en el elemento %d: %w", i, err)
+			}
+		}
+		indices := make([]int, len(lista))
+		for i := range indices {
+			indices[i] = i
+		}
+		var errOrden error
+		sort.SliceStable(indices, func(a, b int) bool {
+			menor, err := menorQue(claves[indices[a]], claves[indices[b]])
+			if err != nil && errOrden == nil {
+				errOrden = err
+			}
+			return menor
+		})
+		if errOrden != nil {
+			return nil, errOrden
+		}
+		res := make([]interface{}, len(lista))
+		for i, j := range indices {
+			res[i] = lista[j]
+		}
+		return res, nil
+	}
+
+	// ordenar(lista, ["desc"]) -> copia ordenada (estable) por el valor de cada elemento
+	Funciones["ordenar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'ordenar' requiere una lista y, opcionalmente, \"asc\" o \"desc\"")
+		}
+		lista, err := comoLista("ordenar", args[0])
+		if err != nil {
+			return nil, err
+		}
+		descendente := false
+		if len(args) == 2 {
+			orden, _ := args[1].(string)
+			switch strings.ToLower(strings.TrimSpace(orden)) {
+			case "asc", "ascendente":
+			case "desc", "descendente":
+				descendente = true
+			default:
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'ordenar' admite \"asc\" o \"desc\" → %v", FormatearValor(args[1]))
+			}
+		}
+		res := append([]interface{}{}, lista...)
+		var errOrden error
+		sort.SliceStable(res, func(a, b int) bool {
+			if descendente {
+				a, b = b, a
+			}
+			menor, err := menorQue(res[a], res[b])
+			if err != nil && errOrden == nil {
+				errOrden = err
+			}
+			return menor
+		})
+		if errOrden != nil {
+			return nil, errOrden
+		}
+		return res, nil
+	}
+
+	// primeros(x, n) y ultimos(x, n) -> los n primeros o últimos elementos de
+	// una lista (o caracteres de una cadena)
+	extremos := func(nombre string, desdeElFinal bool) func(args ...interface{}) (interface{}, error) {
+		return func(args ...interface{}) (interface{}, error) {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("❌ ERROR FATAL: '%s' requiere una lista y una cantidad", nombre)
+			}
+			n, err := comoEnteroPequeño(nombre, args[1])
+			if err != nil {
+				return nil, err
+			}
+			if n < 0 {
+				return nil, fmt.Errorf("❌ ERROR FATAL: '%s' requiere una cantidad no negativa → %d", nombre, n)
+			}
+			corte := func(largo int) (int, int) {
+				if n > largo {
+					n = largo
+				}
+				if desdeElFinal {
+					return largo - n, largo
+				}
+				return 0, n
+			}
+			if s, err := comoCadena(nombre, args[0]); err == nil {
+				runas := []rune(s)
+				desde, hasta := corte(len(runas))
+				return string(runas[desde:hasta]), nil
+			}
+			lista, err := comoLista(nombre, args[0])
+			if err != nil {
+				return nil, err
+			}
+			desde, hasta := corte(len(lista))
+			return append([]interface{}{}, lista[desde:hasta]...), nil
+		}
+	}
+	Funciones["primeros"] = extremos("primeros", false)
+	Funciones["ultimos"] = extremos("ultimos", true)
+	Funciones["cadena.primeros"] = Funciones["primeros"]
+	Funciones["cadena.ultimos"] = Funciones["ultimos"]
+
+	// Métodos: lista.mapear(f), lista.filtrar(f)...
+	for _, nombre := range []string{"mapear", "filtrar", "reducir", "ordenar_por", "ordenar", "primeros", "ultimos"} {
+		Funciones["lista."+nombre] = Funciones[nombre]
+	}
+}
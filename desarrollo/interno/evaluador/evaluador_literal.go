@@ -4,17 +4,24 @@ import (
 	"errors"
 	"go/ast"
 	"go/token"
+	"math/big"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
-// evaluarLiteral maneja valores escritos directamente: 10, 3.14, "hola".
+// evaluarLiteral maneja valores escritos directamente: 10, 3.14, 4i, "hola".
 func evaluarLiteral(n *ast.BasicLit) (interface{}, error) {
 	switch n.Kind {
 	case token.INT:
 		valor, err := strconv.Atoi(n.Value)
 		if err != nil {
-			return nil, errors.New("❌ ERROR FATAL: entero inválido → " + n.Value)
+			// Literales que no caben en 64 bits se leen como entero grande
+			grande, ok := new(big.Int).SetString(n.Value, 0)
+			if !ok {
+				return nil, errors.New("❌ ERROR FATAL: entero inválido → " + n.Value)
+			}
+			return grande, nil
 		}
 		return valor, nil
 
@@ -25,17 +32,31 @@ func evaluarLiteral(n *ast.BasicLit) (interface{}, error) {
 		}
 		return valor, nil
 
+	case token.IMAG:
+		// Parte imaginaria de un complejo: 4i, 2.5i
+		valor, err := strconv.ParseComplex(n.Value, 128)
+		if err != nil {
+			return nil, errors.New("❌ ERROR FATAL: número imaginario inválido → " + n.Value)
+		}
+		return valor, nil
+
 	case token.STRING:
+		// Las cadenas `crudas` no procesan escapes (útil en patrones: `\d+`)
+		if strings.HasPrefix(n.Value, "`") {
+			return strings.Trim(n.Value, "`"), nil
+		}
 		// Eliminamos las comillas externas
 		crudo := strings.Trim(n.Value, `"`)
 		return desescaparCadena(crudo), nil
 
 	case token.CHAR:
 		crudo := strings.TrimSpace(strings.Trim(n.Value, `'`))
-		if len(crudo) != 1 {
+		// Se cuentan runas para aceptar 'ñ' o 'á'
+		r, tamaño := utf8.DecodeRuneInString(crudo)
+		if tamaño == 0 || tamaño != len(crudo) {
 			return nil, errors.New("❌ ERROR FATAL: carácter inválido → " + n.Value)
 		}
-		return rune(crudo[0]), nil
+		return r, nil
 
 	default:
 		return nil, errors.New(ErrTipoNoSoportado.Error() + " → " + n.Kind.String())
@@ -3,7 +3,9 @@ package evaluador
 import (
 	"fmt"
 	"go/ast"
+	"math/big"
 	"strings"
+	"time"
 )
 
 // evaluarLlamada maneja llamadas a funciones (ej: seno(x)) y métodos (ej: lista.limpiar()).
@@ -11,6 +13,12 @@ func evaluarLlamada(n *ast.CallExpr, ctx *Contexto) (interface{}, error) {
 	switch fn := n.Fun.(type) {
 	case *ast.Ident:
 		nombreFuncion := strings.ToLower(fn.Name)
+		if nombreFuncion == identificadorLambda {
+			return crearLambda(n, ctx)
+		}
+		if nombreFuncion == "formatear" && len(n.Args) > 0 {
+			n.Args[0] = plantillaSinInterpolar(n.Args[0])
+		}
 		
 		argumentos, err := evaluarArgumentos(n.Args, ctx)
 		if err != nil {
@@ -19,14 +27,12 @@ func evaluarLlamada(n *ast.CallExpr, ctx *Contexto) (interface{}, error) {
 
 		fmt.Printf("desarrollo/interno/evalador_llamada.go:\nDEBUG llamada a %s con %d argumentos: %#v\n", nombreFuncion, len(argumentos), argumentos)
 		
-		f, ok := Funciones[nombreFuncion]
-		if !ok {
-			return nil, fmt.Errorf("%w → %s", ErrFuncionNoExiste, nombreFuncion)
-		}
-		
-		return f(argumentos...)
+		return llamarPorNombre(fn, argumentos, ctx)
 
 	case *ast.SelectorExpr:
+		if strings.EqualFold(fn.Sel.Name, "formatear") {
+			fn.X = plantillaSinInterpolar(fn.X)
+		}
 		objeto, err := evaluarNodo(fn.X, ctx)
 		if err != nil {
 			return nil, err
@@ -49,10 +55,39 @@ func evaluarLlamada(n *ast.CallExpr, ctx *Contexto) (interface{}, error) {
 		return f(append([]interface{}{objeto}, argumentos...)...)
 
 	default:
-		return nil, ErrFuncionNoExiste
+		// Cualquier expresión que produzca una función: (funcion(x) => x^2)(3), sumador(2)(40)
+		valor, err := evaluarNodo(n.Fun, ctx)
+		if err != nil {
+			return nil, err
+		}
+		invocable, ok := comoInvocable(valor)
+		if !ok {
+			return nil, fmt.Errorf("%w → %s", ErrNoInvocable, FormatearValor(valor))
+		}
+		argumentos, err := evaluarArgumentos(n.Args, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return invocable.Llamar(argumentos...)
 	}
 }
 
+// llamarPorNombre invoca una función registrada o una variable que guarda
+// una función (parámetro, lambda).
+func llamarPorNombre(fn *ast.Ident, argumentos []interface{}, ctx *Contexto) (interface{}, error) {
+	nombreFuncion := strings.ToLower(fn.Name)
+	f, ok := Funciones[nombreFuncion]
+	if !ok {
+		if v, err := evaluarIdentificador(fn, ctx); err == nil {
+			if invocable, esFuncion := comoInvocable(v); esFuncion {
+				return invocable.Llamar(argumentos...)
+			}
+		}
+		return nil, fmt.Errorf("%w → %s", ErrFuncionNoExiste, nombreFuncion)
+	}
+	return f(argumentos...)
+}
+
 // evaluarArgumentos evalúa cada expresión pasada como parámetro.
 func evaluarArgumentos(args []ast.Expr, ctx *Contexto) ([]interface{}, error) {
 	var valores []interface{}
@@ -81,6 +116,30 @@ func obtenerTipoEnEspañol(v interface{}) string {
 		return "caracter"
 	case []interface{}:
 		return "lista"
+	case map[string]interface{}:
+		return "diccionario"
+	case complex128:
+		return "complejo"
+	case Decimal:
+		return "decimal"
+	case *big.Int:
+		return "entero"
+	case *big.Rat:
+		return "racional"
+	case Cantidad:
+		return "cantidad"
+	case Funcion:
+		return "funcion"
+	case Polinomio:
+		return "polinomio"
+	case Simbolico:
+		return "simbolico"
+	case *Archivo:
+		return "archivo"
+	case time.Time:
+		return "fecha"
+	case Duracion, time.Duration:
+		return "duracion"
 	default:
 		return "objeto"
 	}
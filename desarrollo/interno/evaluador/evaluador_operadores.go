@@ -0,0 +1,406 @@
+package evaluador
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"math"
+	"reflect"
+)
+
+// ErrDimensiones se devuelve cuando dos matrices o vectores no son compatibles.
+var ErrDimensiones = errors.New("❌ ERROR FATAL: dimensiones incompatibles")
+
+// comoMatriz reconoce los valores que se comportan como matriz:
+// [][]float64 (tipo matriz), [][]interface{} o listas de listas.
+func comoMatriz(v interface{}) ([][]float64, bool) {
+	switch m := v.(type) {
+	case [][]float64:
+		return m, len(m) > 0
+	case [][]interface{}:
+		filas := make([][]float64, len(m))
+		for i, f := range m {
+			fila, ok := comoVector(f)
+			if !ok {
+				return nil, false
+			}
+			filas[i] = fila
+		}
+		return filas, len(filas) > 0 && esRectangular(filas)
+	case []interface{}:
+		if len(m) == 0 {
+			return nil, false
+		}
+		filas := make([][]float64, len(m))
+		for i, f := range m {
+			switch f.(type) {
+			case []interface{}, []float64, []int:
+			default:
+				return nil, false
+			}
+			fila, ok := comoVector(f)
+			if !ok {
+				return nil, false
+			}
+			filas[i] = fila
+		}
+		return filas, esRectangular(filas)
+	}
+	return nil, false
+}
+
+// comoVector reconoce listas planas de números.
+func comoVector(v interface{}) ([]float64, bool) {
+	switch l := v.(type) {
+	case []float64:
+		return l, true
+	case []int:
+		res := make([]float64, len(l))
+		for i, x := range l {
+			res[i] = float64(x)
+		}
+		return res, true
+	case []interface{}:
+		res := make([]float64, len(l))
+		for i, x := range l {
+			if esColeccion(x) {
+				return nil, false
+			}
+			f, err := ConvertirAReal(x)
+			if err != nil {
+				return nil, false
+			}
+			res[i] = f
+		}
+		return res, true
+	}
+	return nil, false
+}
+
+func esColeccion(v interface{}) bool {
+	switch v.(type) {
+	case []interface{}, []float64, []int, [][]float64, [][]interface{}:
+		return true
+	}
+	return false
+}
+
+func esRectangular(m [][]float64) bool {
+	for _, f := range m {
+		if len(f) != len(m[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// operarColecciones aplica los operadores aritméticos sobre matrices y vectores.
+// Devuelve manejado=false si ninguno de los operandos es una colección.
+func operarColecciones(op token.Token, izq, der interface{}) (interface{}, bool, error) {
+	if !esColeccion(izq) && !esColeccion(der) {
+		return nil, false, nil
+	}
+	// Texto + lista sigue siendo concatenación
+	if op == token.ADD {
+		_, esIzqString := izq.(string)
+		_, esDerString := der.(string)
+		if esIzqString || esDerString {
+			return nil, false, nil
+		}
+	}
+
+	mIzq, esMatIzq := comoMatriz(izq)
+	mDer, esMatDer := comoMatriz(der)
+	var vIzq, vDer []float64
+	var esVecIzq, esVecDer bool
+	if !esMatIzq {
+		vIzq, esVecIzq = comoVector(izq)
+	}
+	if !esMatDer {
+		vDer, esVecDer = comoVector(der)
+	}
+	escIzq, errIzq := ConvertirAReal(izq)
+	escDer, errDer := ConvertirAReal(der)
+	esEscIzq := errIzq == nil && !esColeccion(izq)
+	esEscDer := errDer == nil && !esColeccion(der)
+
+	switch op {
+	case token.ADD, token.SUB:
+		signo := 1.0
+		if op == token.SUB {
+			signo = -1.0
+		}
+		suma := func(a, b float64) float64 { return a + signo*b }
+		switch {
+		case esMatIzq && esMatDer:
+			res, err := combinarMatrices(mIzq, mDer, suma)
+			if err != nil {
+				return nil, true, err
+			}
+			return res, true, nil
+		case esVecIzq && esVecDer:
+			res, err := combinarVectores(vIzq, vDer, suma)
+			if err != nil {
+				return nil, true, err
+			}
+			return res, true, nil
+		}
+
+	case token.MUL:
+		switch {
+		case esEscIzq && esMatDer:
+			return escalarMatriz(mDer, escIzq), true, nil
+		case esMatIzq && esEscDer:
+			return escalarMatriz(mIzq, escDer), true, nil
+		case esEscIzq && esVecDer:
+			return escalarVector(vDer, escIzq), true, nil
+		case esVecIzq && esEscDer:
+			return escalarVector(vIzq, escDer), true, nil
+		case esMatIzq && esMatDer:
+			res, err := productoMatricial(mIzq, mDer)
+			if err != nil {
+				return nil, true, err
+			}
+			return res, true, nil
+		case esMatIzq && esVecDer:
+			// Matriz · vector columna → vector
+			res, err := productoMatricial(mIzq, columna(vDer))
+			if err != nil {
+				return nil, true, err
+			}
+			return aplanarColumna(res), true, nil
+		case esVecIzq && esMatDer:
+			// Vector fila · matriz → vector
+			res, err := productoMatricial([][]float64{vIzq}, mDer)
+			if err != nil {
+				return nil, true, err
+			}
+			return res[0], true, nil
+		case esVecIzq && esVecDer:
+			// Producto punto
+			if len(vIzq) != len(vDer) {
+				return nil, true, fmt.Errorf("%w: producto punto entre vectores de %d y %d elementos", ErrDimensiones, len(vIzq), len(vDer))
+			}
+			var s float64
+			for i := range vIzq {
+				s += vIzq[i] * vDer[i]
+			}
+			return s, true, nil
+		}
+
+	case token.AND_NOT: // '.*' elemento a elemento
+		producto := func(a, b float64) float64 { return a * b }
+		switch {
+		case esMatIzq && esMatDer:
+			res, err := combinarMatrices(mIzq, mDer, producto)
+			if err != nil {
+				return nil, true, err
+			}
+			return res, true, nil
+		case esVecIzq && esVecDer:
+			res, err := combinarVectores(vIzq, vDer, producto)
+			if err != nil {
+				return nil, true, err
+			}
+			return res, true, nil
+		case esEscIzq && esMatDer:
+			return escalarMatriz(mDer, escIzq), true, nil
+		case esMatIzq && esEscDer:
+			return escalarMatriz(mIzq, escDer), true, nil
+		case esEscIzq && esVecDer:
+			return escalarVector(vDer, escIzq), true, nil
+		case esVecIzq && esEscDer:
+			return escalarVector(vIzq, escDer), true, nil
+		}
+
+	case token.QUO:
+		if esEscDer && (esMatIzq || esVecIzq) {
+			if escDer == 0 {
+				return nil, true, errors.New("❌ ERROR FATAL: división por cero")
+			}
+			if esMatIzq {
+				return escalarMatriz(mIzq, 1/escDer), true, nil
+			}
+			return escalarVector(vIzq, 1/escDer), true, nil
+		}
+
+	case token.XOR: // '^' potencia
+		if esMatIzq && esEscDer {
+			res, err := potenciaMatriz(mIzq, escDer)
+			if err != nil {
+				return nil, true, err
+			}
+			return res, true, nil
+		}
+		if esVecIzq {
+			return nil, true, errors.New("❌ ERROR FATAL: la potencia de una lista no está definida, use '.*' para operar elemento a elemento")
+		}
+
+	case token.EQL, token.NEQ:
+		igual := reflect.DeepEqual(normalizarColeccion(izq), normalizarColeccion(der))
+		if op == token.EQL {
+			return igual, true, nil
+		}
+		return !igual, true, nil
+	}
+
+	return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no se puede aplicar entre %s y %s",
+		nombreOperador(op), describirOperando(izq), describirOperando(der))
+}
+
+// negarColeccion implementa el signo unario sobre matrices y vectores.
+func negarColeccion(v interface{}) (interface{}, bool) {
+	if m, ok := comoMatriz(v); ok {
+		return escalarMatriz(m, -1), true
+	}
+	if l, ok := comoVector(v); ok {
+		return escalarVector(l, -1), true
+	}
+	return nil, false
+}
+
+// --- Núcleo numérico ---
+
+func combinarMatrices(a, b [][]float64, f func(x, y float64) float64) ([][]float64, error) {
+	if len(a) != len(b) || len(a[0]) != len(b[0]) {
+		return nil, fmt.Errorf("%w: %dx%d y %dx%d", ErrDimensiones, len(a), len(a[0]), len(b), len(b[0]))
+	}
+	res := make([][]float64, len(a))
+	for i := range a {
+		res[i] = make([]float64, len(a[i]))
+		for j := range a[i] {
+			res[i][j] = f(a[i][j], b[i][j])
+		}
+	}
+	return res, nil
+}
+
+func combinarVectores(a, b []float64, f func(x, y float64) float64) ([]float64, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("%w: listas de %d y %d elementos", ErrDimensiones, len(a), len(b))
+	}
+	res := make([]float64, len(a))
+	for i := range a {
+		res[i] = f(a[i], b[i])
+	}
+	return res, nil
+}
+
+func escalarMatriz(m [][]float64, k float64) [][]float64 {
+	res := make([][]float64, len(m))
+	for i := range m {
+		res[i] = escalarVector(m[i], k)
+	}
+	return res
+}
+
+func escalarVector(v []float64, k float64) []float64 {
+	res := make([]float64, len(v))
+	for i := range v {
+		res[i] = v[i] * k
+	}
+	return res
+}
+
+func productoMatricial(a, b [][]float64) ([][]float64, error) {
+	if len(a[0]) != len(b) {
+		return nil, fmt.Errorf("%w: columnas de la izquierda (%d) no coinciden con filas de la derecha (%d)", ErrDimensiones, len(a[0]), len(b))
+	}
+	res := make([][]float64, len(a))
+	for i := range a {
+		res[i] = make([]float64, len(b[0]))
+		for k := range b {
+			for j := range b[0] {
+				res[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return res, nil
+}
+
+// potenciaMatriz eleva una matriz cuadrada a un exponente entero
+// (los negativos usan la función registrada 'inversa').
+func potenciaMatriz(m [][]float64, exponente float64) ([][]float64, error) {
+	if len(m) != len(m[0]) {
+		return nil, fmt.Errorf("%w: la potencia requiere una matriz cuadrada (recibiste %dx%d)", ErrDimensiones, len(m), len(m[0]))
+	}
+	if exponente != math.Trunc(exponente) {
+		return nil, fmt.Errorf("❌ ERROR FATAL: la potencia de una matriz requiere un exponente entero (recibiste %v)", exponente)
+	}
+	n := int(exponente)
+	base := m
+	if n < 0 {
+		inversa, ok := Funciones["inversa"]
+		if !ok {
+			return nil, fmt.Errorf("%w → inversa", ErrFuncionNoExiste)
+		}
+		inv, err := inversa(m)
+		if err != nil {
+			return nil, err
+		}
+		base = inv.([][]float64)
+		n = -n
+	}
+
+	// Exponenciación por cuadrados
+	res := make([][]float64, len(m))
+	for i := range res {
+		res[i] = make([]float64, len(m))
+		res[i][i] = 1
+	}
+	for n > 0 {
+		if n%2 == 1 {
+			res, _ = productoMatricial(res, base)
+		}
+		base, _ = productoMatricial(base, base)
+		n /= 2
+	}
+	return res, nil
+}
+
+func columna(v []float64) [][]float64 {
+	res := make([][]float64, len(v))
+	for i, x := range v {
+		res[i] = []float64{x}
+	}
+	return res
+}
+
+func aplanarColumna(m [][]float64) []float64 {
+	res := make([]float64, len(m))
+	for i := range m {
+		res[i] = m[i][0]
+	}
+	return res
+}
+
+// normalizarColeccion lleva matrices y vectores a una forma comparable.
+func normalizarColeccion(v interface{}) interface{} {
+	if m, ok := comoMatriz(v); ok {
+		return m
+	}
+	if l, ok := comoVector(v); ok {
+		return l
+	}
+	return v
+}
+
+func describirOperando(v interface{}) string {
+	if m, ok := comoMatriz(v); ok {
+		return fmt.Sprintf("matriz %dx%d", len(m), len(m[0]))
+	}
+	if l, ok := comoVector(v); ok {
+		return fmt.Sprintf("lista de %d elementos", len(l))
+	}
+	return obtenerTipoEnEspañol(v)
+}
+
+func nombreOperador(op token.Token) string {
+	switch op {
+	case token.XOR:
+		return "^"
+	case token.AND_NOT:
+		return ".*"
+	}
+	return op.String()
+}
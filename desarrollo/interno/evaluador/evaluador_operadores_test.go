@@ -0,0 +1,64 @@
+package evaluador
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestOperadoresMatriciales(t *testing.T) {
+	ctx := PrepararContextoEvaluador()
+	ctx.Variables["a"] = [][]float64{{1, 2}, {3, 4}}
+	ctx.Variables["b"] = [][]float64{{0, 1}, {1, 0}}
+	ctx.Variables["v"] = []interface{}{1, 2}
+	ctx.Variables["w"] = []float64{3, 4, 5}
+	ctx.Variables["x"] = 3.0
+
+	casos := []struct {
+		expresion string
+		esperado  string
+	}{
+		{"a + b", "[[1 3] [4 4]]"},
+		{"a - b", "[[1 1] [2 4]]"},
+		{"a * b", "[[2 1] [4 3]]"},
+		{"a .* b", "[[0 2] [3 0]]"},
+		{"2 * a", "[[2 4] [6 8]]"},
+		{"a / 2", "[[0.5 1] [1.5 2]]"},
+		{"a ^ 2", "[[7 10] [15 22]]"},
+		{"a ^ 0", "[[1 0] [0 1]]"},
+		{"a * v", "[5 11]"},
+		{"v * a", "[7 10]"},
+		{"v * v", "5"},
+		{"v + v", "[2 4]"},
+		{"v .* v", "[1 4]"},
+		{"-a", "[[-1 -2] [-3 -4]]"},
+		{"a == a", "true"},
+		{"a != b", "true"},
+		{"a*b + b*a", "[[5 5] [5 5]]"},
+		// la precedencia de los escalares no cambia
+		{"-x^2", "-9"},
+		{"2^3^2", "512"},
+		{"2*3+4*5", "26"},
+		{"10 - 2 - 3", "5"},
+		{"3.*2", "6"},
+	}
+	for _, c := range casos {
+		r, err := EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if s := fmt.Sprint(r); s != c.esperado {
+			t.Errorf("%s = %s, se esperaba %s", c.expresion, s, c.esperado)
+		}
+	}
+
+	for expresion, mensaje := range map[string]string{
+		"a + v": "no se puede aplicar",
+		"v + w": "dimensiones incompatibles",
+	} {
+		if _, err := EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
@@ -0,0 +1,516 @@
+package evaluador
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"math"
+	"math/cmplx"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Polinomio guarda coeficientes reales en orden ascendente, como poli_evaluar:
+// coef[i] acompaña a x^i. polinomio("3x^2 - 2x + 1") = {1, -2, 3}.
+type Polinomio struct {
+	coef     []float64
+	variable string
+}
+
+var ErrPolinomioInvalido = errors.New("❌ ERROR FATAL: polinomio inválido")
+
+// NuevoPolinomio construye el polinomio c0 + c1·x + c2·x² + ... y descarta los
+// ceros del grado más alto.
+func NuevoPolinomio(coef []float64, variable string) Polinomio {
+	fin := len(coef)
+	for fin > 0 && coef[fin-1] == 0 {
+		fin--
+	}
+	if variable == "" {
+		variable = "x"
+	}
+	return Polinomio{coef: append([]float64(nil), coef[:fin]...), variable: variable}
+}
+
+// ParsearPolinomio lee sumas de términos c·x^n: "3x^2 - 2x + 1", "x^3 - 1",
+// "0.5*t^2 + t".
+func ParsearPolinomio(texto string) (Polinomio, error) {
+	s := strings.ReplaceAll(texto, " ", "")
+	s = strings.ReplaceAll(s, "**", "^")
+	if s == "" {
+		return Polinomio{}, fmt.Errorf("%w: texto vacío", ErrPolinomioInvalido)
+	}
+	var coef []float64
+	variable := ""
+	for i := 0; i < len(s); {
+		// Signo
+		signo := 1.0
+		if s[i] == '+' || s[i] == '-' {
+			if s[i] == '-' {
+				signo = -1
+			}
+			i++
+		} else if i > 0 {
+			return Polinomio{}, fmt.Errorf("%w: se esperaba '+' o '-' en '%s'", ErrPolinomioInvalido, s[i:])
+		}
+		// Coeficiente
+		inicio := i
+		for i < len(s) && (esDigito(s[i]) || s[i] == '.') {
+			i++
+		}
+		valor, tieneCoef := 1.0, i > inicio
+		if tieneCoef {
+			v, err := strconv.ParseFloat(s[inicio:i], 64)
+			if err != nil {
+				return Polinomio{}, fmt.Errorf("%w: coeficiente '%s'", ErrPolinomioInvalido, s[inicio:i])
+			}
+			valor = v
+			if i < len(s) && s[i] == '*' {
+				i++
+			}
+		}
+		// Variable y exponente
+		exponente := 0
+		inicio = i
+		for i < len(s) && unicode.IsLetter(rune(s[i])) {
+			i++
+		}
+		if i > inicio {
+			if variable == "" {
+				variable = s[inicio:i]
+			} else if variable != s[inicio:i] {
+				return Polinomio{}, fmt.Errorf("%w: mezcla las variables '%s' y '%s'", ErrPolinomioInvalido, variable, s[inicio:i])
+			}
+			exponente = 1
+			if i < len(s) && s[i] == '^' {
+				i++
+				inicio = i
+				for i < len(s) && esDigito(s[i]) {
+					i++
+				}
+				n, err := strconv.Atoi(s[inicio:i])
+				if err != nil || n > 10_000 {
+					return Polinomio{}, fmt.Errorf("%w: exponente inválido en '%s'", ErrPolinomioInvalido, texto)
+				}
+				exponente = n
+			}
+		} else if !tieneCoef {
+			return Polinomio{}, fmt.Errorf("%w: término vacío en '%s'", ErrPolinomioInvalido, texto)
+		}
+		for len(coef) <= exponente {
+			coef = append(coef, 0)
+		}
+		coef[exponente] += signo * valor
+	}
+	return NuevoPolinomio(coef, variable), nil
+}
+
+// Grado del polinomio; el polinomio cero tiene grado -1.
+func (p Polinomio) Grado() int { return len(p.coef) - 1 }
+
+// Coeficientes en orden ascendente (copia).
+func (p Polinomio) Coeficientes() []float64 { return append([]float64(nil), p.coef...) }
+
+func (p Polinomio) Variable() string { return p.variable }
+
+func (p Polinomio) coeficiente(i int) float64 {
+	if i < len(p.coef) {
+		return p.coef[i]
+	}
+	return 0
+}
+
+// Evaluar calcula p(x) con el algoritmo de Horner.
+func (p Polinomio) Evaluar(x float64) float64 {
+	res := 0.0
+	for i := len(p.coef) - 1; i >= 0; i-- {
+		res = res*x + p.coef[i]
+	}
+	return res
+}
+
+func (p Polinomio) EvaluarComplejo(z complex128) complex128 {
+	var res complex128
+	for i := len(p.coef) - 1; i >= 0; i-- {
+		res = res*z + complex(p.coef[i], 0)
+	}
+	return res
+}
+
+// EvaluarMatriz calcula p(A) = c0·I + c1·A + c2·A² + ... (Horner con matrices).
+func (p Polinomio) EvaluarMatriz(a [][]float64) ([][]float64, error) {
+	n := len(a)
+	if n == 0 || len(a[0]) != n {
+		return nil, fmt.Errorf("%w: evaluar un polinomio requiere una matriz cuadrada", ErrDimensiones)
+	}
+	res := make([][]float64, n)
+	for i := range res {
+		res[i] = make([]float64, n)
+	}
+	for k := len(p.coef) - 1; k >= 0; k-- {
+		res, _ = productoMatricial(res, a)
+		for i := 0; i < n; i++ {
+			res[i][i] += p.coef[k]
+		}
+	}
+	return res, nil
+}
+
+// EvaluarValor evalúa en un real, complejo o matriz cuadrada.
+func (p Polinomio) EvaluarValor(v interface{}) (interface{}, error) {
+	if z, ok := v.(complex128); ok {
+		return p.EvaluarComplejo(z), nil
+	}
+	if m, ok := comoMatriz(v); ok {
+		return p.EvaluarMatriz(m)
+	}
+	x, err := ConvertirAReal(v)
+	if err != nil {
+		return nil, err
+	}
+	return p.Evaluar(x), nil
+}
+
+func (p Polinomio) Sumar(q Polinomio) Polinomio {
+	n := len(p.coef)
+	if len(q.coef) > n {
+		n = len(q.coef)
+	}
+	res := make([]float64, n)
+	for i := range res {
+		res[i] = p.coeficiente(i) + q.coeficiente(i)
+	}
+	return NuevoPolinomio(res, p.variable)
+}
+
+func (p Polinomio) Escalar(k float64) Polinomio {
+	res := make([]float64, len(p.coef))
+	for i, c := range p.coef {
+		res[i] = c * k
+	}
+	return NuevoPolinomio(res, p.variable)
+}
+
+func (p Polinomio) Restar(q Polinomio) Polinomio { return p.Sumar(q.Escalar(-1)) }
+
+func (p Polinomio) Multiplicar(q Polinomio) Polinomio {
+	if len(p.coef) == 0 || len(q.coef) == 0 {
+		return NuevoPolinomio(nil, p.variable)
+	}
+	res := make([]float64, len(p.coef)+len(q.coef)-1)
+	for i, a := range p.coef {
+		for j, b := range q.coef {
+			res[i+j] += a * b
+		}
+	}
+	return NuevoPolinomio(res, p.variable)
+}
+
+// Potencia eleva a un exponente entero no negativo.
+func (p Polinomio) Potencia(n int) (Polinomio, error) {
+	if n < 0 {
+		return Polinomio{}, fmt.Errorf("%w: la potencia de un polinomio requiere un exponente entero no negativo", ErrPolinomioInvalido)
+	}
+	if n*p.Grado() > 10_000 {
+		return Polinomio{}, fmt.Errorf("%w: el grado resultante es demasiado grande", ErrPolinomioInvalido)
+	}
+	res, base := NuevoPolinomio([]float64{1}, p.variable), p
+	for n > 0 {
+		if n%2 == 1 {
+			res = res.Multiplicar(base)
+		}
+		base = base.Multiplicar(base)
+		n /= 2
+	}
+	return res, nil
+}
+
+// Dividir devuelve cociente y resto: p = q·cociente + resto, grado(resto) < grado(q).
+func (p Polinomio) Dividir(q Polinomio) (Polinomio, Polinomio, error) {
+	if len(q.coef) == 0 {
+		return Polinomio{}, Polinomio{}, errors.New("❌ ERROR FATAL: división por el polinomio cero")
+	}
+	resto := p.Coeficientes()
+	gq := q.Grado()
+	if p.Grado() < gq {
+		return NuevoPolinomio(nil, p.variable), p, nil
+	}
+	cociente := make([]float64, p.Grado()-gq+1)
+	lider := q.coef[gq]
+	for k := len(cociente) - 1; k >= 0; k-- {
+		c := resto[k+gq] / lider
+		cociente[k] = c
+		for j := 0; j <= gq; j++ {
+			resto[k+j] -= c * q.coef[j]
+		}
+		resto[k+gq] = 0
+	}
+	// Limpiar el ruido de redondeo del resto
+	escala := 0.0
+	for _, c := range p.coef {
+		escala = math.Max(escala, math.Abs(c))
+	}
+	for i := range resto {
+		if math.Abs(resto[i]) <= 1e-12*escala {
+			resto[i] = 0
+		}
+	}
+	return NuevoPolinomio(cociente, p.variable), NuevoPolinomio(resto[:gq], p.variable), nil
+}
+
+func (p Polinomio) Derivar() Polinomio {
+	if len(p.coef) <= 1 {
+		return NuevoPolinomio(nil, p.variable)
+	}
+	res := make([]float64, len(p.coef)-1)
+	for i := 1; i < len(p.coef); i++ {
+		res[i-1] = float64(i) * p.coef[i]
+	}
+	return NuevoPolinomio(res, p.variable)
+}
+
+// Integrar devuelve la primitiva con constante de integración c.
+func (p Polinomio) Integrar(c float64) Polinomio {
+	res := make([]float64, len(p.coef)+1)
+	res[0] = c
+	for i, a := range p.coef {
+		res[i+1] = a / float64(i+1)
+	}
+	return NuevoPolinomio(res, p.variable)
+}
+
+// Raices encuentra todas las raíces (reales y complejas) con el método de
+// Durand-Kerner, pulidas con Newton. Las reales se devuelven como float64 y
+// van primero, en orden ascendente; después las complejas en pares conjugados.
+func (p Polinomio) Raices() ([]interface{}, error) {
+	if len(p.coef) == 0 {
+		return nil, errors.New("❌ ERROR FATAL: el polinomio cero tiene infinitas raíces")
+	}
+	// Las raíces en cero se extraen exactas
+	ceros := 0
+	for ceros < len(p.coef) && p.coef[ceros] == 0 {
+		ceros++
+	}
+	reducido := NuevoPolinomio(p.coef[ceros:], p.variable)
+	n := reducido.Grado()
+
+	raices := make([]complex128, 0, n+ceros)
+	for i := 0; i < ceros; i++ {
+		raices = append(raices, 0)
+	}
+	if n > 0 {
+		monico := make([]complex128, n+1)
+		for i, c := range reducido.coef {
+			monico[i] = complex(c/reducido.coef[n], 0)
+		}
+		horner := func(z complex128) complex128 {
+			var res complex128
+			for i := n; i >= 0; i-- {
+				res = res*z + monico[i]
+			}
+			return res
+		}
+		// Cota de Cauchy para el radio de los puntos iniciales
+		radio := 0.0
+		for _, c := range monico[:n] {
+			radio = math.Max(radio, cmplx.Abs(c))
+		}
+		radio = 1 + radio
+		z := make([]complex128, n)
+		for k := range z {
+			z[k] = cmplx.Rect(radio, 2*math.Pi*float64(k)/float64(n)+0.4)
+		}
+		for iter := 0; iter < 2000; iter++ {
+			cambio := 0.0
+			for i := range z {
+				den := complex(1, 0)
+				for j := range z {
+					if i != j {
+						den *= z[i] - z[j]
+					}
+				}
+				if den == 0 {
+					den = complex(1e-12, 0)
+				}
+				delta := horner(z[i]) / den
+				z[i] -= delta
+				cambio = math.Max(cambio, cmplx.Abs(delta)/math.Max(1, cmplx.Abs(z[i])))
+			}
+			if cambio < 1e-15 {
+				break
+			}
+		}
+		derivada := reducido.Derivar()
+		for _, r := range z {
+			// Pulido con Newton sobre el polinomio original
+			for k := 0; k < 3; k++ {
+				d := derivada.EvaluarComplejo(r)
+				if d == 0 {
+					break
+				}
+				r -= reducido.EvaluarComplejo(r) / d
+			}
+			raices = append(raices, r)
+		}
+	}
+
+	// Partes imaginarias despreciables → raíz real; una raíz casi entera que
+	// anula exactamente el polinomio se redondea (0.9999999999999997 → 1)
+	res := make([]interface{}, 0, len(raices))
+	var reales []float64
+	var superiores []complex128
+	inferiores := 0
+	for _, r := range raices {
+		switch {
+		case math.Abs(imag(r)) <= 1e-9*math.Max(1, cmplx.Abs(r)):
+			x := real(r)
+			if entero := math.Round(x); math.Abs(x-entero) < 1e-9 && p.Evaluar(entero) == 0 {
+				x = entero
+			}
+			reales = append(reales, x)
+		case imag(r) > 0:
+			superiores = append(superiores, r)
+		default:
+			inferiores++
+		}
+	}
+	sort.Float64s(reales)
+	for _, r := range reales {
+		res = append(res, r)
+	}
+	// Con coeficientes reales las complejas van en pares conjugados: a+bi, a-bi
+	if inferiores == len(superiores) {
+		sort.Slice(superiores, func(i, j int) bool { return real(superiores[i]) < real(superiores[j]) })
+		for _, c := range superiores {
+			res = append(res, c, cmplx.Conj(c))
+		}
+		return res, nil
+	}
+	for _, r := range raices {
+		if math.Abs(imag(r)) > 1e-9*math.Max(1, cmplx.Abs(r)) {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+// String muestra el polinomio de mayor a menor grado: 3x^2 - 2x + 1.
+func (p Polinomio) String() string {
+	if len(p.coef) == 0 {
+		return "0"
+	}
+	var b strings.Builder
+	for i := len(p.coef) - 1; i >= 0; i-- {
+		c := p.coef[i]
+		if c == 0 {
+			continue
+		}
+		switch {
+		case b.Len() == 0 && c < 0:
+			b.WriteString("-")
+		case b.Len() > 0 && c < 0:
+			b.WriteString(" - ")
+		case b.Len() > 0:
+			b.WriteString(" + ")
+		}
+		abs := math.Abs(c)
+		if abs != 1 || i == 0 {
+			b.WriteString(strconv.FormatFloat(abs, 'f', -1, 64))
+		}
+		if i >= 1 {
+			b.WriteString(p.variable)
+		}
+		if i > 1 {
+			b.WriteString("^" + strconv.Itoa(i))
+		}
+	}
+	return b.String()
+}
+
+// Igual compara coeficiente a coeficiente.
+func (p Polinomio) Igual(q Polinomio) bool {
+	if len(p.coef) != len(q.coef) {
+		return false
+	}
+	for i := range p.coef {
+		if p.coef[i] != q.coef[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ComoPolinomio acepta polinomios y números (polinomios constantes).
+func ComoPolinomio(v interface{}, variable string) (Polinomio, bool) {
+	if p, ok := v.(Polinomio); ok {
+		return p, true
+	}
+	if esColeccion(v) {
+		return Polinomio{}, false
+	}
+	if _, esTexto := v.(string); esTexto {
+		return Polinomio{}, false
+	}
+	x, err := ConvertirAReal(v)
+	if err != nil {
+		return Polinomio{}, false
+	}
+	return NuevoPolinomio([]float64{x}, variable), true
+}
+
+// operarPolinomios implementa + - * / % ^ == != con polinomios. La división
+// '/' entre polinomios da el cociente y '%' el resto.
+func operarPolinomios(op token.Token, izq, der interface{}) (interface{}, bool, error) {
+	pIzq, esPolIzq := izq.(Polinomio)
+	pDer, esPolDer := der.(Polinomio)
+	if !esPolIzq && !esPolDer {
+		return nil, false, nil
+	}
+	// Texto + polinomio sigue siendo concatenación
+	_, esTextoIzq := izq.(string)
+	_, esTextoDer := der.(string)
+	if op == token.ADD && (esTextoIzq || esTextoDer) {
+		return nil, false, nil
+	}
+	variable := pIzq.variable
+	if !esPolIzq {
+		variable = pDer.variable
+	}
+	a, okA := ComoPolinomio(izq, variable)
+	b, okB := ComoPolinomio(der, variable)
+
+	// p(x) ^ n: el exponente es un número, no un polinomio
+	if op == token.XOR && esPolIzq && !esPolDer {
+		n, err := ConvertirAReal(der)
+		if err != nil || n != math.Trunc(n) {
+			return nil, true, fmt.Errorf("%w: la potencia de un polinomio requiere un exponente entero no negativo", ErrPolinomioInvalido)
+		}
+		res, err := a.Potencia(int(n))
+		return res, true, err
+	}
+	if !okA || !okB {
+		return nil, true, fmt.Errorf("❌ ERROR FATAL: no se puede operar '%s' entre %s y %s", nombreOperador(op), describirOperando(izq), describirOperando(der))
+	}
+
+	switch op {
+	case token.ADD:
+		return a.Sumar(b), true, nil
+	case token.SUB:
+		return a.Restar(b), true, nil
+	case token.MUL, token.AND_NOT:
+		return a.Multiplicar(b), true, nil
+	case token.QUO:
+		cociente, _, err := a.Dividir(b)
+		return cociente, true, err
+	case token.REM:
+		_, resto, err := a.Dividir(b)
+		return resto, true, err
+	case token.EQL:
+		return a.Igual(b), true, nil
+	case token.NEQ:
+		return !a.Igual(b), true, nil
+	}
+	return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no está definido para polinomios", nombreOperador(op))
+}
@@ -0,0 +1,195 @@
+package evaluador
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// El analizador de Go nos da la estructura de las expresiones, pero algunas
+// reglas de Nepa son distintas a las de Go:
+//   - '^' es potencia (no XOR): asocia a la derecha y tiene mayor prioridad
+//     que '*' y que el signo unario (-x^2 = -(x^2)).
+//   - '.*' es el producto elemento a elemento; se traduce a '&^', que en Go
+//     tiene la misma prioridad que '*' y Nepa no usa.
+
+// prepararExpresion traduce los operadores propios de Nepa a tokens que
+// go/parser entiende, respetando el contenido de las cadenas. También
+// convierte las lambdas (funcion(x) => x^2) y las cantidades con unidad
+// ("5 m/s") en llamadas, y los diccionarios ({"x": 2}) en literales.
+func prepararExpresion(expr string) string {
+	expr = traducirLambdas(expr)
+	expr = traducirDiccionarios(expr)
+	expr = traducirCantidades(expr)
+	if !strings.Contains(expr, ".*") {
+		return expr
+	}
+	var b strings.Builder
+	comilla := byte(0)
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case comilla != 0:
+			if c == '\\' && i+1 < len(expr) {
+				b.WriteByte(c)
+				i++
+				c = expr[i]
+			} else if c == comilla {
+				comilla = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			comilla = c
+		case c == '.' && i+1 < len(expr) && expr[i+1] == '*':
+			b.WriteString("&^")
+			i++
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// elementoCadena es una pieza de una cadena aritmética aplanada:
+// un operando (con sus signos unarios) o un operador binario.
+type elementoCadena struct {
+	op       token.Token
+	unarios  []token.Token
+	operando ast.Expr
+}
+
+// reasociar reconstruye las cadenas aritméticas con la prioridad de Nepa.
+func reasociar(e ast.Expr) ast.Expr {
+	switch n := e.(type) {
+	case *ast.BinaryExpr:
+		if n.Op.Precedence() < token.ADD.Precedence() {
+			n.X = reasociar(n.X)
+			n.Y = reasociar(n.Y)
+			return n
+		}
+		return reconstruirCadena(aplanarCadena(n, nil))
+	case *ast.UnaryExpr:
+		if esUnarioAritmetico(n.Op) {
+			return reconstruirCadena(aplanarCadena(n, nil))
+		}
+		n.X = reasociar(n.X)
+		return n
+	case *ast.ParenExpr:
+		n.X = reasociar(n.X)
+	case *ast.CallExpr:
+		for i, a := range n.Args {
+			n.Args[i] = reasociar(a)
+		}
+		n.Fun = reasociar(n.Fun)
+	case *ast.SelectorExpr:
+		n.X = reasociar(n.X)
+	case *ast.IndexExpr:
+		n.X = reasociar(n.X)
+		n.Index = reasociar(n.Index)
+	case *ast.SliceExpr:
+		n.X = reasociar(n.X)
+		for _, e := range []*ast.Expr{&n.Low, &n.High, &n.Max} {
+			if *e != nil {
+				*e = reasociar(*e)
+			}
+		}
+	case *ast.CompositeLit:
+		for i, el := range n.Elts {
+			n.Elts[i] = reasociar(el)
+		}
+	case *ast.KeyValueExpr:
+		n.Key = reasociar(n.Key)
+		n.Value = reasociar(n.Value)
+	}
+	return e
+}
+
+func esUnarioAritmetico(op token.Token) bool {
+	return op == token.SUB || op == token.ADD || op == token.NOT
+}
+
+// aplanarCadena recorre en orden los operadores de prioridad aritmética
+// (niveles 4 y 5 de Go) y los signos unarios.
+func aplanarCadena(e ast.Expr, salida []elementoCadena) []elementoCadena {
+	switch n := e.(type) {
+	case *ast.BinaryExpr:
+		if n.Op.Precedence() >= token.ADD.Precedence() {
+			salida = aplanarCadena(n.X, salida)
+			salida = append(salida, elementoCadena{op: n.Op})
+			return aplanarCadena(n.Y, salida)
+		}
+	case *ast.UnaryExpr:
+		if esUnarioAritmetico(n.Op) {
+			var unarios []token.Token
+			var x ast.Expr = n
+			for {
+				u, ok := x.(*ast.UnaryExpr)
+				if !ok || !esUnarioAritmetico(u.Op) {
+					break
+				}
+				unarios = append(unarios, u.Op)
+				x = u.X
+			}
+			// El operando de un unario puede ser a su vez una cadena (-a^2 → (-a)^2 en Go)
+			sub := aplanarCadena(x, nil)
+			sub[0].unarios = append(unarios, sub[0].unarios...)
+			return append(salida, sub...)
+		}
+	}
+	return append(salida, elementoCadena{operando: reasociar(e)})
+}
+
+// prioridadNepa ordena: + - | < * / % & &^ << >> < unario < ^
+func prioridadNepa(op token.Token) int {
+	switch op {
+	case token.XOR:
+		return 3
+	case token.ADD, token.SUB, token.OR:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func reconstruirCadena(elementos []elementoCadena) ast.Expr {
+	pos := 0
+	return parsearCadena(elementos, &pos, 1)
+}
+
+// parsearCadena aplica ascenso por prioridad sobre la cadena aplanada.
+func parsearCadena(el []elementoCadena, pos *int, minimo int) ast.Expr {
+	izq := parsearUnario(el, pos)
+	for *pos < len(el) {
+		op := el[*pos].op
+		p := prioridadNepa(op)
+		if p < minimo || op == token.XOR {
+			break
+		}
+		*pos++
+		der := parsearCadena(el, pos, p+1)
+		izq = &ast.BinaryExpr{X: izq, Op: op, Y: der}
+	}
+	return izq
+}
+
+// parsearUnario aplica los signos después de la potencia: -x^2 = -(x^2).
+func parsearUnario(el []elementoCadena, pos *int) ast.Expr {
+	actual := &el[*pos]
+	if len(actual.unarios) > 0 {
+		op := actual.unarios[0]
+		actual.unarios = actual.unarios[1:]
+		return &ast.UnaryExpr{Op: op, X: parsearUnario(el, pos)}
+	}
+	return parsearPotencia(el, pos)
+}
+
+// parsearPotencia asocia '^' a la derecha; el exponente admite signo (2^-1).
+func parsearPotencia(el []elementoCadena, pos *int) ast.Expr {
+	base := el[*pos].operando
+	*pos++
+	if *pos < len(el) && el[*pos].op == token.XOR {
+		*pos++
+		exponente := parsearUnario(el, pos)
+		return &ast.BinaryExpr{X: base, Op: token.XOR, Y: exponente}
+	}
+	return base
+}
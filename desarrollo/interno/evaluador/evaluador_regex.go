@@ -0,0 +1,203 @@
+package evaluador
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// Expresiones regulares (sintaxis RE2 de Go; (?i) ignora mayúsculas):
+//   coincide("abc123", "[0-9]+")                         → verdadero
+//   buscar_todos("a1 b22 c333", "[0-9]+")                → ["1", "22", "333"]
+//   reemplazar_regex("2024-05-01", `(\d+)-(\d+)-(\d+)`, "$3/$2/$1")
+//   dividir_regex("a, b;c", `[,;]\s*`)                   → ["a", "b", "c"]
+//   capturar("Ana 31", `(?P<nombre>\w+) (?P<edad>\d+)`)  → {"nombre": "Ana", "edad": "31", ...}
+
+// Las expresiones se vuelven a analizar en cada evaluación, así que una
+// llamada se identifica por el texto de su patrón: cada patrón se compila una
+// sola vez y se reutiliza. La caché se vacía al llegar al límite para que los
+// patrones construidos dinámicamente no la hagan crecer sin fin.
+const limiteCachePatrones = 256
+
+var (
+	cachePatrones      = map[string]*regexp.Regexp{}
+	cachePatronesMutex sync.Mutex
+)
+
+// compilarPatron devuelve el patrón compilado desde la caché.
+func compilarPatron(nombre string, v interface{}) (*regexp.Regexp, error) {
+	patron, err := comoCadena(nombre, v)
+	if err != nil {
+		return nil, err
+	}
+	cachePatronesMutex.Lock()
+	defer cachePatronesMutex.Unlock()
+	if re, ok := cachePatrones[patron]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(patron)
+	if err != nil {
+		return nil, fmt.Errorf("❌ ERROR FATAL: patrón inválido en '%s' → %s (%v)", nombre, patron, err)
+	}
+	if len(cachePatrones) >= limiteCachePatrones {
+		cachePatrones = map[string]*regexp.Regexp{}
+	}
+	cachePatrones[patron] = re
+	return re, nil
+}
+
+// argumentosRegex valida (texto, patrón, extras...).
+func argumentosRegex(nombre string, args []interface{}, minimo, maximo int) (string, *regexp.Regexp, error) {
+	texto, err := argumentosCadena(nombre, args, minimo, maximo)
+	if err != nil {
+		return "", nil, err
+	}
+	re, err := compilarPatron(nombre, args[1])
+	if err != nil {
+		return "", nil, err
+	}
+	return texto, re, nil
+}
+
+// limiteOpcional lee el máximo de resultados (-1 = todos).
+func limiteOpcional(nombre string, args []interface{}, pos int) (int, error) {
+	if len(args) <= pos {
+		return -1, nil
+	}
+	return comoEnteroPequeño(nombre, args[pos])
+}
+
+// gruposComoDiccionario arma {"0": todo, "1": ..., "nombre": ...} a partir de
+// los índices de una coincidencia. Los grupos que no participan valen nulo.
+func gruposComoDiccionario(re *regexp.Regexp, texto string, indices []int) map[string]interface{} {
+	res := map[string]interface{}{}
+	nombres := re.SubexpNames()
+	for g := 0; g*2 < len(indices); g++ {
+		var valor interface{}
+		if indices[2*g] >= 0 {
+			valor = texto[indices[2*g]:indices[2*g+1]]
+		}
+		res[strconv.Itoa(g)] = valor
+		if nombres[g] != "" {
+			res[nombres[g]] = valor
+		}
+	}
+	return res
+}
+
+func listaDeTextos(textos []string) []interface{} {
+	res := make([]interface{}, len(textos))
+	for i, t := range textos {
+		res[i] = t
+	}
+	return res
+}
+
+func init() {
+	// coincide(texto, patron) -> verdadero si el patrón aparece en el texto
+	Funciones["coincide"] = func(args ...interface{}) (interface{}, error) {
+		texto, re, err := argumentosRegex("coincide", args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString(texto), nil
+	}
+
+	// buscar_todos(texto, patron, [n]) -> lista con el texto de cada coincidencia
+	Funciones["buscar_todos"] = func(args ...interface{}) (interface{}, error) {
+		texto, re, err := argumentosRegex("buscar_todos", args, 2, 3)
+		if err != nil {
+			return nil, err
+		}
+		n, err := limiteOpcional("buscar_todos", args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return listaDeTextos(re.FindAllString(texto, n)), nil
+	}
+
+	// capturar(texto, patron) -> diccionario con los grupos de la primera coincidencia, o nulo
+	Funciones["capturar"] = func(args ...interface{}) (interface{}, error) {
+		texto, re, err := argumentosRegex("capturar", args, 2, 2)
+		if err != nil {
+			return nil, err
+		}
+		indices := re.FindStringSubmatchIndex(texto)
+		if indices == nil {
+			return nil, nil
+		}
+		return gruposComoDiccionario(re, texto, indices), nil
+	}
+
+	// capturar_todos(texto, patron, [n]) -> un diccionario de grupos por coincidencia
+	Funciones["capturar_todos"] = func(args ...interface{}) (interface{}, error) {
+		texto, re, err := argumentosRegex("capturar_todos", args, 2, 3)
+		if err != nil {
+			return nil, err
+		}
+		n, err := limiteOpcional("capturar_todos", args, 2)
+		if err != nil {
+			return nil, err
+		}
+		res := []interface{}{}
+		for _, indices := range re.FindAllStringSubmatchIndex(texto, n) {
+			res = append(res, gruposComoDiccionario(re, texto, indices))
+		}
+		return res, nil
+	}
+
+	// reemplazar_regex(texto, patron, reemplazo) -> $1, ${nombre} hacen referencia
+	// a los grupos; el reemplazo también puede ser una función que recibe la
+	// coincidencia y devuelve el texto nuevo.
+	Funciones["reemplazar_regex"] = func(args ...interface{}) (interface{}, error) {
+		texto, re, err := argumentosRegex("reemplazar_regex", args, 3, 3)
+		if err != nil {
+			return nil, err
+		}
+		if f, ok := ComoFuncion(args[2]); ok {
+			var errF error
+			res := re.ReplaceAllStringFunc(texto, func(coincidencia string) string {
+				if errF != nil {
+					return coincidencia
+				}
+				v, err := f.Llamar(coincidencia)
+				if err != nil {
+					errF = fmt.Errorf("❌ ERROR FATAL: 'reemplazar_regex' falló con '%s': %w", coincidencia, err)
+					return coincidencia
+				}
+				if s, err := comoCadena("reemplazar_regex", v); err == nil {
+					return s
+				}
+				return FormatearValor(v)
+			})
+			if errF != nil {
+				return nil, errF
+			}
+			return res, nil
+		}
+		reemplazo, err := comoCadena("reemplazar_regex", args[2])
+		if err != nil {
+			return nil, err
+		}
+		return re.ReplaceAllString(texto, reemplazo), nil
+	}
+
+	// dividir_regex(texto, patron, [n]) -> partes entre las coincidencias
+	Funciones["dividir_regex"] = func(args ...interface{}) (interface{}, error) {
+		texto, re, err := argumentosRegex("dividir_regex", args, 2, 3)
+		if err != nil {
+			return nil, err
+		}
+		n, err := limiteOpcional("dividir_regex", args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return listaDeTextos(re.Split(texto, n)), nil
+	}
+
+	// Métodos: "texto".coincide(patron), "a1b2".buscar_todos("[0-9]")...
+	for _, nombre := range []string{"coincide", "buscar_todos", "capturar", "capturar_todos", "reemplazar_regex", "dividir_regex"} {
+		Funciones["cadena."+nombre] = Funciones[nombre]
+	}
+}
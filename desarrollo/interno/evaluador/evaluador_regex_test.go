@@ -0,0 +1,51 @@
+package evaluador
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpresionesRegulares(t *testing.T) {
+	ctx := PrepararContextoEvaluador()
+	ctx.Variables["t"] = "Ana 31, Luis 45, Sofía 28"
+
+	casos := []struct {
+		expresion string
+		esperado  string
+	}{
+		{`coincide("abc123", "[0-9]+")`, "verdadero"},
+		{`coincide("abc", "^[0-9]+$")`, "falso"},
+		{`"ÁRBOL".coincide("(?i)árbol")`, "verdadero"},
+		{`buscar_todos("a1 b22 c333", "[0-9]+")`, "[1, 22, 333]"},
+		{`buscar_todos("a1 b22 c333", "[0-9]+", 2)`, "[1, 22]"},
+		{"buscar_todos(t, `\\p{L}+`)", "[Ana, Luis, Sofía]"},
+		{"reemplazar_regex(\"2024-05-01\", `(\\d+)-(\\d+)-(\\d+)`, \"$3/$2/$1\")", "01/05/2024"},
+		{"reemplazar_regex(\"2024-05-01\", `(?P<a>\\d+)-(?P<m>\\d+)-(?P<d>\\d+)`, \"${d}.${m}.${a}\")", "01.05.2024"},
+		{"reemplazar_regex(\"precio 10 y 20\", `\\d+`, funcion(x) => x + x)", "precio 1010 y 2020"},
+		{"dividir_regex(\"a, b;c\", `[,;]\\s*`)", "[a, b, c]"},
+		{"\"a1b2c\".dividir_regex(`\\d`, 2)", "[a, b2c]"},
+		{"capturar(t, `(?P<nombre>\\p{L}+) (?P<edad>\\d+)`)", `{"0": "Ana 31", "1": "Ana", "2": "31", "edad": "31", "nombre": "Ana"}`},
+		{"capturar_todos(t, `(?P<nombre>\\p{L}+) \\d+`)", `[{"0": "Ana 31", "1": "Ana", "nombre": "Ana"}, {"0": "Luis 45", "1": "Luis", "nombre": "Luis"}, {"0": "Sofía 28", "1": "Sofía", "nombre": "Sofía"}]`},
+		{`capturar("x", "y")`, "nulo"},
+		{"capturar(\"ab\", `(a)(z)?b`)", `{"0": "ab", "1": "a", "2": nulo}`},
+	}
+	for _, c := range casos {
+		r, err := EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if s := FormatearValor(r); s != c.esperado {
+			t.Errorf("%s = %s, se esperaba %s", c.expresion, s, c.esperado)
+		}
+	}
+
+	for expresion, mensaje := range map[string]string{
+		`coincide("a", "(")`: "patrón inválido en 'coincide'",
+		`coincide(3, "a")`:   "requiere una cadena",
+	} {
+		if _, err := EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
@@ -0,0 +1,490 @@
+package evaluador
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Simbolico guarda una expresión sin evaluar: simbolico("x^2*seno(x)") se
+// puede simplificar, derivar, expandir o sustituir antes de calcularla. Los
+// números del árbol son racionales exactos, así x/3 + x/3 da 2*x/3.
+type Simbolico struct {
+	raiz *nodoSimbolico
+}
+
+type claseNodo int
+
+const (
+	nodoNumero claseNodo = iota
+	nodoVariable
+	nodoOperacion // binaria: + - * / ^
+	nodoNegativo
+	nodoLlamada
+)
+
+type nodoSimbolico struct {
+	clase  claseNodo
+	numero *big.Rat
+	nombre string // variable, operador o función
+	args   []*nodoSimbolico
+}
+
+var (
+	ErrSimbolicoInvalido = errors.New("❌ ERROR FATAL: expresión simbólica inválida")
+	ErrVariableLibre     = errors.New("❌ ERROR FATAL: la expresión tiene variables sin valor")
+)
+
+// Nombres habituales que se aceptan al escribir fórmulas: sin(x) = seno(x).
+var aliasSimbolicos = map[string]string{
+	"sin": "seno", "cos": "coseno", "tan": "tangente",
+	"asin": "arcoseno", "acos": "arcocoseno", "atan": "arcotangente",
+	"sinh": "seno_h", "cosh": "coseno_h", "tanh": "tangente_h",
+	"ln": "logaritmo", "log": "logaritmo", "log10": "logaritmo10", "log2": "logaritmo2",
+	"sqrt": "raiz", "cbrt": "raiz_cubica", "abs": "absoluto",
+}
+
+func numeroSimbolico(r *big.Rat) *nodoSimbolico {
+	return &nodoSimbolico{clase: nodoNumero, numero: r}
+}
+
+func enteroSimbolico(n int64) *nodoSimbolico {
+	return numeroSimbolico(big.NewRat(n, 1))
+}
+
+func variableSimbolica(nombre string) *nodoSimbolico {
+	return &nodoSimbolico{clase: nodoVariable, nombre: nombre}
+}
+
+func operacionSimbolica(op string, a, b *nodoSimbolico) *nodoSimbolico {
+	return &nodoSimbolico{clase: nodoOperacion, nombre: op, args: []*nodoSimbolico{a, b}}
+}
+
+func negativoSimbolico(a *nodoSimbolico) *nodoSimbolico {
+	return &nodoSimbolico{clase: nodoNegativo, args: []*nodoSimbolico{a}}
+}
+
+func llamadaSimbolica(nombre string, args ...*nodoSimbolico) *nodoSimbolico {
+	return &nodoSimbolico{clase: nodoLlamada, nombre: nombre, args: args}
+}
+
+func (n *nodoSimbolico) esNumero(v int64) bool {
+	return n.clase == nodoNumero && n.numero.IsInt() && n.numero.Num().IsInt64() && n.numero.Num().Int64() == v
+}
+
+// ParsearSimbolico lee una fórmula en sintaxis nepa. Se admite la
+// multiplicación implícita de los textos de álgebra: 3x^2 = 3*x^2.
+func ParsearSimbolico(texto string) (Simbolico, error) {
+	texto = strings.TrimSpace(texto)
+	if texto == "" {
+		return Simbolico{}, fmt.Errorf("%w: texto vacío", ErrSimbolicoInvalido)
+	}
+	arbol, err := parser.ParseExpr(multiplicacionImplicita(texto))
+	if err != nil {
+		return Simbolico{}, fmt.Errorf("%w: %v", ErrSimbolicoInvalido, err)
+	}
+	raiz, err := desdeAST(reasociar(arbol))
+	if err != nil {
+		return Simbolico{}, err
+	}
+	return Simbolico{raiz: raiz}, nil
+}
+
+// multiplicacionImplicita inserta '*' entre un número o ')' y lo que le sigue
+// (letra, número o '('), respetando los exponentes de 1e5.
+func multiplicacionImplicita(texto string) string {
+	var b strings.Builder
+	enNumero := false
+	for i := 0; i < len(texto); i++ {
+		c := texto[i]
+		if i > 0 {
+			previo := texto[i-1]
+			letra := c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+			exponente := (c == 'e' || c == 'E') && enNumero && i+1 < len(texto) &&
+				(esDigito(texto[i+1]) || (texto[i+1] == '-' || texto[i+1] == '+') && i+2 < len(texto) && esDigito(texto[i+2]))
+			if (enNumero && (letra || c == '(') && !exponente) || (previo == ')' && (letra || esDigito(c) || c == '(')) {
+				b.WriteByte('*')
+			}
+			if exponente {
+				b.WriteByte(c)
+				i++
+				b.WriteByte(texto[i])
+				continue
+			}
+		}
+		switch {
+		case esDigito(c) || (c == '.' && enNumero):
+			enNumero = enNumero || i == 0 || !esParteIdentificador(texto[i-1])
+		default:
+			enNumero = false
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func desdeAST(e ast.Expr) (*nodoSimbolico, error) {
+	switch n := e.(type) {
+	case *ast.BasicLit:
+		if n.Kind != token.INT && n.Kind != token.FLOAT {
+			return nil, fmt.Errorf("%w: literal %s", ErrSimbolicoInvalido, n.Value)
+		}
+		r, ok := new(big.Rat).SetString(n.Value)
+		if !ok {
+			return nil, fmt.Errorf("%w: número %s", ErrSimbolicoInvalido, n.Value)
+		}
+		return numeroSimbolico(r), nil
+	case *ast.Ident:
+		return variableSimbolica(n.Name), nil
+	case *ast.ParenExpr:
+		return desdeAST(n.X)
+	case *ast.UnaryExpr:
+		x, err := desdeAST(n.X)
+		if err != nil {
+			return nil, err
+		}
+		switch n.Op {
+		case token.SUB:
+			return negativoSimbolico(x), nil
+		case token.ADD:
+			return x, nil
+		}
+	case *ast.BinaryExpr:
+		op, ok := map[token.Token]string{token.ADD: "+", token.SUB: "-", token.MUL: "*", token.QUO: "/", token.XOR: "^"}[n.Op]
+		if !ok {
+			return nil, fmt.Errorf("%w: operador '%s'", ErrSimbolicoInvalido, nombreOperador(n.Op))
+		}
+		a, err := desdeAST(n.X)
+		if err != nil {
+			return nil, err
+		}
+		b, err := desdeAST(n.Y)
+		if err != nil {
+			return nil, err
+		}
+		return operacionSimbolica(op, a, b), nil
+	case *ast.CallExpr:
+		fn, ok := n.Fun.(*ast.Ident)
+		if !ok {
+			break
+		}
+		nombre := strings.ToLower(fn.Name)
+		if alias, ok := aliasSimbolicos[nombre]; ok {
+			nombre = alias
+		}
+		args := make([]*nodoSimbolico, len(n.Args))
+		for i, a := range n.Args {
+			arg, err := desdeAST(a)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = arg
+		}
+		if nombre == "potencia" && len(args) == 2 {
+			return operacionSimbolica("^", args[0], args[1]), nil
+		}
+		// x(x + 1) en un texto de álgebra es un producto, no una llamada
+		if _, existe := Funciones[nombre]; !existe && len(args) == 1 {
+			return operacionSimbolica("*", variableSimbolica(fn.Name), args[0]), nil
+		}
+		return llamadaSimbolica(nombre, args...), nil
+	}
+	return nil, fmt.Errorf("%w: no se admite '%T'", ErrSimbolicoInvalido, e)
+}
+
+// --- IMPRESIÓN EN SINTAXIS NEPA ---
+
+// precedencia: 1 suma, 2 producto, 3 signo, 4 potencia, 5 átomo.
+func (n *nodoSimbolico) precedencia() int {
+	switch n.clase {
+	case nodoNumero:
+		if n.numero.Sign() < 0 {
+			return 3
+		}
+		if !decimalFinito(n.numero) {
+			return 2 // se imprime como fracción: 1/3
+		}
+	case nodoOperacion:
+		switch n.nombre {
+		case "+", "-":
+			return 1
+		case "*", "/":
+			return 2
+		}
+		return 4
+	case nodoNegativo:
+		if n.args[0].precedencia() == 2 {
+			return 2 // se imprime -a*b
+		}
+		return 3
+	}
+	return 5
+}
+
+// decimalFinito indica si el racional tiene expresión decimal exacta (1/4 = 0.25).
+func decimalFinito(r *big.Rat) bool {
+	d := new(big.Int).Set(r.Denom())
+	for _, p := range []int64{2, 5} {
+		primo := big.NewInt(p)
+		m := new(big.Int)
+		for {
+			q, resto := new(big.Int).QuoRem(d, primo, m)
+			if resto.Sign() != 0 {
+				break
+			}
+			d = q
+		}
+	}
+	return d.Cmp(big.NewInt(1)) == 0
+}
+
+func formatearNumeroSimbolico(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	if decimalFinito(r) {
+		// Se busca la menor cantidad de decimales que lo representa exacto
+		for decimales := 1; ; decimales++ {
+			texto := r.FloatString(decimales)
+			if exacto, _ := new(big.Rat).SetString(texto); exacto.Cmp(r) == 0 {
+				return texto
+			}
+		}
+	}
+	return r.String()
+}
+
+func (n *nodoSimbolico) String() string {
+	envolver := func(hijo *nodoSimbolico, minimo int) string {
+		if hijo.precedencia() < minimo {
+			return "(" + hijo.String() + ")"
+		}
+		return hijo.String()
+	}
+	switch n.clase {
+	case nodoNumero:
+		return formatearNumeroSimbolico(n.numero)
+	case nodoVariable:
+		return n.nombre
+	case nodoNegativo:
+		// -a*b vale lo mismo que -(a*b): solo las sumas necesitan paréntesis
+		return "-" + envolver(n.args[0], 2)
+	case nodoLlamada:
+		partes := make([]string, len(n.args))
+		for i, a := range n.args {
+			partes[i] = a.String()
+		}
+		return n.nombre + "(" + strings.Join(partes, ", ") + ")"
+	}
+	a, b := n.args[0], n.args[1]
+	switch n.nombre {
+	case "+":
+		return envolver(a, 1) + " + " + envolver(b, 1)
+	case "-":
+		return envolver(a, 1) + " - " + envolver(b, 2)
+	case "*":
+		return envolver(a, 2) + "*" + envolver(b, 2)
+	case "/":
+		return envolver(a, 2) + "/" + envolver(b, 3)
+	}
+	// Potencia: asocia a la derecha y la base con signo va entre paréntesis
+	return envolver(a, 5) + "^" + envolver(b, 4)
+}
+
+func (s Simbolico) String() string {
+	if s.raiz == nil {
+		return "0"
+	}
+	return s.raiz.String()
+}
+
+// --- VARIABLES, SUSTITUCIÓN Y EVALUACIÓN ---
+
+// esConstanteConocida: pi y e tienen valor aunque no se sustituyan.
+func esConstanteConocida(nombre string) bool {
+	return nombre == "pi" || nombre == "e"
+}
+
+func (n *nodoSimbolico) recolectarVariables(vistas map[string]bool) {
+	if n.clase == nodoVariable && !esConstanteConocida(n.nombre) {
+		vistas[n.nombre] = true
+	}
+	for _, a := range n.args {
+		a.recolectarVariables(vistas)
+	}
+}
+
+// Variables devuelve las variables libres en orden alfabético.
+func (s Simbolico) Variables() []string {
+	vistas := map[string]bool{}
+	if s.raiz != nil {
+		s.raiz.recolectarVariables(vistas)
+	}
+	res := make([]string, 0, len(vistas))
+	for v := range vistas {
+		res = append(res, v)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (n *nodoSimbolico) depende(variable string) bool {
+	if n.clase == nodoVariable {
+		return n.nombre == variable
+	}
+	for _, a := range n.args {
+		if a.depende(variable) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *nodoSimbolico) reemplazar(valores map[string]*nodoSimbolico) *nodoSimbolico {
+	if n.clase == nodoVariable {
+		if v, ok := valores[n.nombre]; ok {
+			return v
+		}
+		return n
+	}
+	if len(n.args) == 0 {
+		return n
+	}
+	copia := *n
+	copia.args = make([]*nodoSimbolico, len(n.args))
+	for i, a := range n.args {
+		copia.args[i] = a.reemplazar(valores)
+	}
+	return &copia
+}
+
+// ComoSimbolico acepta expresiones, números exactos y texto ("x^2 + 1").
+func ComoSimbolico(v interface{}) (Simbolico, error) {
+	switch x := v.(type) {
+	case Simbolico:
+		return x, nil
+	case string:
+		return ParsearSimbolico(x)
+	case Polinomio:
+		return ParsearSimbolico(x.String())
+	}
+	if esColeccion(v) {
+		return Simbolico{}, fmt.Errorf("%w: no se puede convertir %v", ErrSimbolicoInvalido, FormatearValor(v))
+	}
+	r, ok := ComoRacional(v)
+	if !ok {
+		return Simbolico{}, fmt.Errorf("%w: no se puede convertir %v", ErrSimbolicoInvalido, FormatearValor(v))
+	}
+	return Simbolico{raiz: numeroSimbolico(new(big.Rat).Set(r))}, nil
+}
+
+// Sustituir reemplaza variables por números u otras expresiones y simplifica.
+func (s Simbolico) Sustituir(valores map[string]interface{}) (Simbolico, error) {
+	nodos := make(map[string]*nodoSimbolico, len(valores))
+	for nombre, v := range valores {
+		e, err := ComoSimbolico(v)
+		if err != nil {
+			return Simbolico{}, err
+		}
+		nodos[nombre] = e.raiz
+	}
+	return Simbolico{raiz: s.raiz.reemplazar(nodos)}.Simplificar(), nil
+}
+
+// Evaluar calcula el valor numérico; todas las variables libres deben tener
+// valor en 'valores'.
+func (s Simbolico) Evaluar(valores map[string]interface{}) (interface{}, error) {
+	return evaluarSimbolico(s.raiz, valores)
+}
+
+func evaluarSimbolico(n *nodoSimbolico, valores map[string]interface{}) (interface{}, error) {
+	switch n.clase {
+	case nodoNumero:
+		if n.numero.IsInt() && n.numero.Num().IsInt64() {
+			return int(n.numero.Num().Int64()), nil
+		}
+		f, _ := n.numero.Float64()
+		return f, nil
+	case nodoVariable:
+		if v, ok := valores[n.nombre]; ok {
+			return v, nil
+		}
+		switch n.nombre {
+		case "pi":
+			return math.Pi, nil
+		case "e":
+			return math.E, nil
+		}
+		return nil, fmt.Errorf("%w → %s", ErrVariableLibre, n.nombre)
+	case nodoNegativo:
+		x, err := evaluarSimbolico(n.args[0], valores)
+		if err != nil {
+			return nil, err
+		}
+		return aplicarOperacion(token.SUB, 0, x)
+	case nodoLlamada:
+		f, ok := Funciones[n.nombre]
+		if !ok {
+			return nil, fmt.Errorf("%w → %s", ErrFuncionNoExiste, n.nombre)
+		}
+		args := make([]interface{}, len(n.args))
+		for i, a := range n.args {
+			v, err := evaluarSimbolico(a, valores)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = v
+		}
+		return f(args...)
+	}
+	a, err := evaluarSimbolico(n.args[0], valores)
+	if err != nil {
+		return nil, err
+	}
+	b, err := evaluarSimbolico(n.args[1], valores)
+	if err != nil {
+		return nil, err
+	}
+	op := map[string]token.Token{"+": token.ADD, "-": token.SUB, "*": token.MUL, "/": token.QUO, "^": token.XOR}[n.nombre]
+	return aplicarOperacion(op, a, b)
+}
+
+// --- OPERADORES: simbolico("x") * 2 + simbolico("y") ---
+
+func operarSimbolicos(op token.Token, izq, der interface{}) (interface{}, bool, error) {
+	_, esSimIzq := izq.(Simbolico)
+	_, esSimDer := der.(Simbolico)
+	if !esSimIzq && !esSimDer {
+		return nil, false, nil
+	}
+	_, esTextoIzq := izq.(string)
+	_, esTextoDer := der.(string)
+	if op == token.ADD && (esTextoIzq || esTextoDer) {
+		return nil, false, nil
+	}
+	a, err := ComoSimbolico(izq)
+	if err != nil {
+		return nil, true, err
+	}
+	b, err := ComoSimbolico(der)
+	if err != nil {
+		return nil, true, err
+	}
+	switch op {
+	case token.EQL:
+		return a.Simplificar().String() == b.Simplificar().String(), true, nil
+	case token.NEQ:
+		return a.Simplificar().String() != b.Simplificar().String(), true, nil
+	}
+	simbolo, ok := map[token.Token]string{token.ADD: "+", token.SUB: "-", token.MUL: "*", token.AND_NOT: "*", token.QUO: "/", token.XOR: "^"}[op]
+	if !ok {
+		return nil, true, fmt.Errorf("❌ ERROR FATAL: el operador '%s' no está definido para expresiones simbólicas", nombreOperador(op))
+	}
+	return Simbolico{raiz: operacionSimbolica(simbolo, a.raiz, b.raiz)}.Simplificar(), true, nil
+}
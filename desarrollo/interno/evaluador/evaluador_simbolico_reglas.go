@@ -0,0 +1,814 @@
+package evaluador
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+)
+
+// Reglas de manipulación simbólica. La simplificación lleva cada suma y cada
+// producto a una forma canónica: los productos se descomponen en coeficiente
+// racional × factores base^exponente y las sumas en términos coeficiente ×
+// monomio, de modo que x*x = x^2, 2*x + x = 3*x y x/x = 1.
+
+// factorSimbolico es base^exponente dentro de un producto.
+type factorSimbolico struct {
+	base      *nodoSimbolico
+	exponente *nodoSimbolico
+	clave     string
+}
+
+// terminoSimbolico es coeficiente × producto de factores dentro de una suma.
+type terminoSimbolico struct {
+	coef     *big.Rat
+	factores []factorSimbolico
+	clave    string
+}
+
+// Simplificar aplica las reglas hasta que la expresión deja de cambiar.
+func (s Simbolico) Simplificar() Simbolico {
+	raiz := s.raiz
+	anterior := raiz.String()
+	for i := 0; i < 8; i++ {
+		raiz = simplificarNodo(raiz)
+		actual := raiz.String()
+		if actual == anterior {
+			break
+		}
+		anterior = actual
+	}
+	return Simbolico{raiz: raiz}
+}
+
+func simplificarNodo(n *nodoSimbolico) *nodoSimbolico {
+	if len(n.args) == 0 {
+		return n
+	}
+	copia := *n
+	copia.args = make([]*nodoSimbolico, len(n.args))
+	for i, a := range n.args {
+		copia.args[i] = simplificarNodo(a)
+	}
+	n = &copia
+
+	switch n.clase {
+	case nodoLlamada:
+		return simplificarLlamada(n)
+	case nodoNegativo:
+		if n.args[0].clase == nodoNumero {
+			return numeroSimbolico(new(big.Rat).Neg(n.args[0].numero))
+		}
+		return normalizarProducto(n)
+	}
+	switch n.nombre {
+	case "+", "-":
+		return normalizarSuma(n)
+	case "^":
+		base, exp := n.args[0], n.args[1]
+		switch {
+		case exp.esNumero(0):
+			return enteroSimbolico(1)
+		case exp.esNumero(1):
+			return base
+		case base.esNumero(1):
+			return base
+		case base.esNumero(0) && exp.clase == nodoNumero && exp.numero.Sign() > 0:
+			return base
+		case base.clase == nodoNumero && exp.clase == nodoNumero:
+			if r, ok := potenciaExacta(base.numero, exp.numero); ok {
+				return numeroSimbolico(r)
+			}
+			return n
+		}
+	}
+	return normalizarProducto(n)
+}
+
+// potenciaExacta calcula a^b exacto cuando b es entero (y moderado).
+func potenciaExacta(a, b *big.Rat) (*big.Rat, bool) {
+	if !b.IsInt() || !b.Num().IsInt64() {
+		return nil, false
+	}
+	k := b.Num().Int64()
+	if k > 512 || k < -512 || (k < 0 && a.Sign() == 0) {
+		return nil, false
+	}
+	num := new(big.Int).Exp(a.Num(), big.NewInt(absEntero(k)), nil)
+	den := new(big.Int).Exp(a.Denom(), big.NewInt(absEntero(k)), nil)
+	if k < 0 {
+		num, den = den, num
+	}
+	return new(big.Rat).SetFrac(num, den), true
+}
+
+func absEntero(k int64) int64 {
+	if k < 0 {
+		return -k
+	}
+	return k
+}
+
+// simplificarLlamada evalúa funciones de argumentos numéricos cuando el
+// resultado es entero (seno(0) = 0, exp(0) = 1) y deshace exp(logaritmo(u)).
+func simplificarLlamada(n *nodoSimbolico) *nodoSimbolico {
+	if len(n.args) == 1 {
+		interior := n.args[0]
+		if interior.clase == nodoLlamada && len(interior.args) == 1 {
+			if (n.nombre == "exp" && interior.nombre == "logaritmo") || (n.nombre == "logaritmo" && interior.nombre == "exp") {
+				return interior.args[0]
+			}
+		}
+	}
+	f, ok := Funciones[n.nombre]
+	if !ok {
+		return n
+	}
+	args := make([]interface{}, len(n.args))
+	for i, a := range n.args {
+		if a.clase != nodoNumero {
+			return n
+		}
+		args[i], _ = a.numero.Float64()
+	}
+	v, err := f(args...)
+	if err != nil {
+		return n
+	}
+	x, err := ConvertirAReal(v)
+	if err != nil || math.IsInf(x, 0) || math.IsNaN(x) || x != math.Round(x) || math.Abs(x) > 1e15 {
+		return n
+	}
+	return enteroSimbolico(int64(x))
+}
+
+// --- PRODUCTOS ---
+
+func descomponerProducto(n *nodoSimbolico, coef *big.Rat, factores []factorSimbolico, exponente *big.Rat) []factorSimbolico {
+	switch {
+	case n.clase == nodoNumero:
+		if r, ok := potenciaExacta(n.numero, exponente); ok {
+			coef.Mul(coef, r)
+			return factores
+		}
+	case n.clase == nodoNegativo:
+		if exponente.IsInt() && exponente.Num().Bit(0) == 1 {
+			coef.Neg(coef)
+			return descomponerProducto(n.args[0], coef, factores, exponente)
+		}
+		if exponente.IsInt() {
+			return descomponerProducto(n.args[0], coef, factores, exponente)
+		}
+	case n.clase == nodoOperacion && n.nombre == "*":
+		factores = descomponerProducto(n.args[0], coef, factores, exponente)
+		return descomponerProducto(n.args[1], coef, factores, exponente)
+	case n.clase == nodoOperacion && n.nombre == "/":
+		if n.args[1].clase == nodoNumero && n.args[1].numero.Sign() == 0 {
+			break // x/0 se conserva tal cual
+		}
+		factores = descomponerProducto(n.args[0], coef, factores, exponente)
+		return descomponerProducto(n.args[1], coef, factores, new(big.Rat).Neg(exponente))
+	case n.clase == nodoOperacion && n.nombre == "^" && n.args[1].clase == nodoNumero:
+		// (x^2)^3 = x^6 y (2x)^2 = 4x^2 valen para exponentes enteros
+		total := new(big.Rat).Mul(exponente, n.args[1].numero)
+		if exponente.IsInt() {
+			return descomponerProducto(n.args[0], coef, factores, total)
+		}
+	}
+	if exponente.Cmp(big.NewRat(1, 1)) == 0 && n.clase == nodoOperacion && n.nombre == "^" {
+		return append(factores, factorSimbolico{base: n.args[0], exponente: n.args[1]})
+	}
+	return append(factores, factorSimbolico{base: n, exponente: numeroSimbolico(new(big.Rat).Set(exponente))})
+}
+
+// combinarFactores junta las bases repetidas sumando exponentes y ordena:
+// variables, luego funciones y al final el resto (sumas entre paréntesis).
+func combinarFactores(factores []factorSimbolico) []factorSimbolico {
+	porClave := map[string]int{}
+	var res []factorSimbolico
+	for _, f := range factores {
+		f.clave = f.base.String()
+		if i, ok := porClave[f.clave]; ok {
+			suma := operacionSimbolica("+", res[i].exponente, f.exponente)
+			if res[i].exponente.clase == nodoNumero && f.exponente.clase == nodoNumero {
+				suma = numeroSimbolico(new(big.Rat).Add(res[i].exponente.numero, f.exponente.numero))
+			} else {
+				suma = simplificarNodo(suma)
+			}
+			res[i].exponente = suma
+			continue
+		}
+		porClave[f.clave] = len(res)
+		res = append(res, f)
+	}
+	filtrados := res[:0]
+	for _, f := range res {
+		if !f.exponente.esNumero(0) {
+			filtrados = append(filtrados, f)
+		}
+	}
+	rango := func(n *nodoSimbolico) int {
+		switch n.clase {
+		case nodoVariable:
+			return 0
+		case nodoLlamada:
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(filtrados, func(i, j int) bool {
+		ri, rj := rango(filtrados[i].base), rango(filtrados[j].base)
+		if ri != rj {
+			return ri < rj
+		}
+		return filtrados[i].clave < filtrados[j].clave
+	})
+	return filtrados
+}
+
+// reconstruirProducto arma coef × factores con los exponentes negativos en
+// el denominador: 2*x^2/(3*y).
+func reconstruirProducto(coef *big.Rat, factores []factorSimbolico) *nodoSimbolico {
+	if coef.Sign() == 0 {
+		return enteroSimbolico(0)
+	}
+	var numerador, denominador *nodoSimbolico
+	multiplicar := func(acumulado, x *nodoSimbolico) *nodoSimbolico {
+		if acumulado == nil {
+			return x
+		}
+		return operacionSimbolica("*", acumulado, x)
+	}
+	// Un coeficiente racional se reparte: x/2, 2*x/3; una constante sola
+	// queda como número (0.25)
+	abs := new(big.Rat).Abs(coef)
+	num := new(big.Rat).SetInt(abs.Num())
+	den := new(big.Rat).SetInt(abs.Denom())
+	if len(factores) == 0 {
+		num, den = abs, big.NewRat(1, 1)
+	}
+	if num.Cmp(big.NewRat(1, 1)) != 0 || len(factores) == 0 {
+		numerador = numeroSimbolico(num)
+	}
+	if den.Cmp(big.NewRat(1, 1)) != 0 {
+		denominador = numeroSimbolico(den)
+	}
+	for _, f := range factores {
+		potencia := func(exp *nodoSimbolico) *nodoSimbolico {
+			if exp.esNumero(1) {
+				return f.base
+			}
+			return operacionSimbolica("^", f.base, exp)
+		}
+		if f.exponente.clase == nodoNumero && f.exponente.numero.Sign() < 0 {
+			denominador = multiplicar(denominador, potencia(numeroSimbolico(new(big.Rat).Neg(f.exponente.numero))))
+		} else {
+			numerador = multiplicar(numerador, potencia(f.exponente))
+		}
+	}
+	if numerador == nil {
+		numerador = enteroSimbolico(1)
+	}
+	res := numerador
+	if denominador != nil {
+		res = operacionSimbolica("/", numerador, denominador)
+	}
+	if coef.Sign() < 0 {
+		if res.clase == nodoNumero {
+			return numeroSimbolico(new(big.Rat).Neg(res.numero))
+		}
+		return negativoSimbolico(res)
+	}
+	return res
+}
+
+func normalizarProducto(n *nodoSimbolico) *nodoSimbolico {
+	coef := big.NewRat(1, 1)
+	factores := combinarFactores(descomponerProducto(n, coef, nil, big.NewRat(1, 1)))
+	return reconstruirProducto(coef, factores)
+}
+
+// --- SUMAS ---
+
+func descomponerSuma(n *nodoSimbolico, signo int, terminos []terminoSimbolico) []terminoSimbolico {
+	switch {
+	case n.clase == nodoOperacion && (n.nombre == "+" || n.nombre == "-"):
+		terminos = descomponerSuma(n.args[0], signo, terminos)
+		if n.nombre == "-" {
+			return descomponerSuma(n.args[1], -signo, terminos)
+		}
+		return descomponerSuma(n.args[1], signo, terminos)
+	case n.clase == nodoNegativo:
+		return descomponerSuma(n.args[0], -signo, terminos)
+	}
+	coef := big.NewRat(int64(signo), 1)
+	factores := combinarFactores(descomponerProducto(n, coef, nil, big.NewRat(1, 1)))
+	return append(terminos, terminoSimbolico{coef: coef, factores: factores})
+}
+
+// gradosTermino da el exponente numérico de cada variable del término.
+func gradosTermino(t terminoSimbolico) map[string]float64 {
+	grados := map[string]float64{}
+	for _, f := range t.factores {
+		if f.base.clase == nodoVariable && f.exponente.clase == nodoNumero {
+			grados[f.base.nombre], _ = f.exponente.numero.Float64()
+		}
+	}
+	return grados
+}
+
+// precedeTermino ordena por grado total y, a igual grado, en orden
+// lexicográfico de variables: a^3 + 3*a^2*b + 3*a*b^2 + b^3.
+func precedeTermino(a, b terminoSimbolico) bool {
+	if (a.clave == "") != (b.clave == "") {
+		return b.clave == "" // la constante al final
+	}
+	ga, gb := gradosTermino(a), gradosTermino(b)
+	total := func(g map[string]float64) float64 {
+		s := 0.0
+		for _, e := range g {
+			s += e
+		}
+		return s
+	}
+	if ta, tb := total(ga), total(gb); ta != tb {
+		return ta > tb
+	}
+	nombres := make([]string, 0, len(ga)+len(gb))
+	for v := range ga {
+		nombres = append(nombres, v)
+	}
+	for v := range gb {
+		nombres = append(nombres, v)
+	}
+	sort.Strings(nombres)
+	for _, v := range nombres {
+		if ga[v] != gb[v] {
+			return ga[v] > gb[v]
+		}
+	}
+	return a.clave < b.clave
+}
+
+func combinarSumandos(terminos []terminoSimbolico) []terminoSimbolico {
+	porClave := map[string]int{}
+	var res []terminoSimbolico
+	for _, t := range terminos {
+		t.clave = reconstruirProducto(big.NewRat(1, 1), t.factores).String()
+		if len(t.factores) == 0 {
+			t.clave = ""
+		}
+		if i, ok := porClave[t.clave]; ok {
+			res[i].coef = new(big.Rat).Add(res[i].coef, t.coef)
+			continue
+		}
+		porClave[t.clave] = len(res)
+		res = append(res, t)
+	}
+	filtrados := res[:0]
+	for _, t := range res {
+		if t.coef.Sign() != 0 {
+			filtrados = append(filtrados, t)
+		}
+	}
+	sort.SliceStable(filtrados, func(i, j int) bool { return precedeTermino(filtrados[i], filtrados[j]) })
+	return filtrados
+}
+
+func reconstruirSuma(terminos []terminoSimbolico) *nodoSimbolico {
+	if len(terminos) == 0 {
+		return enteroSimbolico(0)
+	}
+	res := reconstruirProducto(terminos[0].coef, terminos[0].factores)
+	for _, t := range terminos[1:] {
+		if t.coef.Sign() < 0 {
+			res = operacionSimbolica("-", res, reconstruirProducto(new(big.Rat).Neg(t.coef), t.factores))
+		} else {
+			res = operacionSimbolica("+", res, reconstruirProducto(t.coef, t.factores))
+		}
+	}
+	return res
+}
+
+func normalizarSuma(n *nodoSimbolico) *nodoSimbolico {
+	return reconstruirSuma(combinarSumandos(descomponerSuma(n, 1, nil)))
+}
+
+// --- DERIVADAS ---
+
+// Derivar calcula la derivada respecto de 'variable' aplicando las reglas de
+// la suma, el producto, el cociente, la potencia y la cadena.
+func (s Simbolico) Derivar(variable string) (Simbolico, error) {
+	d, err := derivarNodo(s.raiz, variable)
+	if err != nil {
+		return Simbolico{}, err
+	}
+	return Simbolico{raiz: d}.Simplificar(), nil
+}
+
+// derivadasExternas da f'(u) para las funciones de una variable.
+var derivadasExternas = map[string]func(u *nodoSimbolico) *nodoSimbolico{
+	"seno":   func(u *nodoSimbolico) *nodoSimbolico { return llamadaSimbolica("coseno", u) },
+	"coseno": func(u *nodoSimbolico) *nodoSimbolico { return negativoSimbolico(llamadaSimbolica("seno", u)) },
+	"tangente": func(u *nodoSimbolico) *nodoSimbolico {
+		return operacionSimbolica("/", enteroSimbolico(1), operacionSimbolica("^", llamadaSimbolica("coseno", u), enteroSimbolico(2)))
+	},
+	"exp":       func(u *nodoSimbolico) *nodoSimbolico { return llamadaSimbolica("exp", u) },
+	"logaritmo": func(u *nodoSimbolico) *nodoSimbolico { return operacionSimbolica("/", enteroSimbolico(1), u) },
+	"raiz": func(u *nodoSimbolico) *nodoSimbolico {
+		return operacionSimbolica("/", enteroSimbolico(1), operacionSimbolica("*", enteroSimbolico(2), llamadaSimbolica("raiz", u)))
+	},
+	"raiz_cubica": func(u *nodoSimbolico) *nodoSimbolico {
+		return operacionSimbolica("/", enteroSimbolico(1), operacionSimbolica("*", enteroSimbolico(3), operacionSimbolica("^", llamadaSimbolica("raiz_cubica", u), enteroSimbolico(2))))
+	},
+	"arcoseno": func(u *nodoSimbolico) *nodoSimbolico {
+		return operacionSimbolica("/", enteroSimbolico(1), llamadaSimbolica("raiz", operacionSimbolica("-", enteroSimbolico(1), operacionSimbolica("^", u, enteroSimbolico(2)))))
+	},
+	"arcocoseno": func(u *nodoSimbolico) *nodoSimbolico {
+		return negativoSimbolico(operacionSimbolica("/", enteroSimbolico(1), llamadaSimbolica("raiz", operacionSimbolica("-", enteroSimbolico(1), operacionSimbolica("^", u, enteroSimbolico(2))))))
+	},
+	"arcotangente": func(u *nodoSimbolico) *nodoSimbolico {
+		return operacionSimbolica("/", enteroSimbolico(1), operacionSimbolica("+", enteroSimbolico(1), operacionSimbolica("^", u, enteroSimbolico(2))))
+	},
+	"seno_h":   func(u *nodoSimbolico) *nodoSimbolico { return llamadaSimbolica("coseno_h", u) },
+	"coseno_h": func(u *nodoSimbolico) *nodoSimbolico { return llamadaSimbolica("seno_h", u) },
+	"tangente_h": func(u *nodoSimbolico) *nodoSimbolico {
+		return operacionSimbolica("-", enteroSimbolico(1), operacionSimbolica("^", llamadaSimbolica("tangente_h", u), enteroSimbolico(2)))
+	},
+	"logaritmo10": func(u *nodoSimbolico) *nodoSimbolico {
+		return operacionSimbolica("/", enteroSimbolico(1), operacionSimbolica("*", u, llamadaSimbolica("logaritmo", enteroSimbolico(10))))
+	},
+	"logaritmo2": func(u *nodoSimbolico) *nodoSimbolico {
+		return operacionSimbolica("/", enteroSimbolico(1), operacionSimbolica("*", u, llamadaSimbolica("logaritmo", enteroSimbolico(2))))
+	},
+	"exp2": func(u *nodoSimbolico) *nodoSimbolico {
+		return operacionSimbolica("*", llamadaSimbolica("exp2", u), llamadaSimbolica("logaritmo", enteroSimbolico(2)))
+	},
+	"absoluto": func(u *nodoSimbolico) *nodoSimbolico {
+		return operacionSimbolica("/", u, llamadaSimbolica("absoluto", u))
+	},
+}
+
+func derivarNodo(n *nodoSimbolico, v string) (*nodoSimbolico, error) {
+	if !n.depende(v) {
+		return enteroSimbolico(0), nil
+	}
+	switch n.clase {
+	case nodoVariable:
+		return enteroSimbolico(1), nil
+	case nodoNegativo:
+		d, err := derivarNodo(n.args[0], v)
+		if err != nil {
+			return nil, err
+		}
+		return negativoSimbolico(d), nil
+	case nodoLlamada:
+		externa, ok := derivadasExternas[n.nombre]
+		if !ok || len(n.args) != 1 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: no se sabe derivar la función '%s'", n.nombre)
+		}
+		du, err := derivarNodo(n.args[0], v)
+		if err != nil {
+			return nil, err
+		}
+		return operacionSimbolica("*", externa(n.args[0]), du), nil
+	}
+
+	f, g := n.args[0], n.args[1]
+	df, err := derivarNodo(f, v)
+	if err != nil {
+		return nil, err
+	}
+	dg, err := derivarNodo(g, v)
+	if err != nil {
+		return nil, err
+	}
+	switch n.nombre {
+	case "+", "-":
+		return operacionSimbolica(n.nombre, df, dg), nil
+	case "*":
+		return operacionSimbolica("+", operacionSimbolica("*", df, g), operacionSimbolica("*", f, dg)), nil
+	case "/":
+		numerador := operacionSimbolica("-", operacionSimbolica("*", df, g), operacionSimbolica("*", f, dg))
+		return operacionSimbolica("/", numerador, operacionSimbolica("^", g, enteroSimbolico(2))), nil
+	}
+	// Potencia
+	switch {
+	case !g.depende(v):
+		// (f^c)' = c*f^(c-1)*f'
+		return operacionSimbolica("*", operacionSimbolica("*", g, operacionSimbolica("^", f, operacionSimbolica("-", g, enteroSimbolico(1)))), df), nil
+	case !f.depende(v):
+		// (c^g)' = c^g*ln(c)*g'
+		return operacionSimbolica("*", operacionSimbolica("*", n, llamadaSimbolica("logaritmo", f)), dg), nil
+	}
+	// (f^g)' = f^g*(g'*ln(f) + g*f'/f)
+	interior := operacionSimbolica("+", operacionSimbolica("*", dg, llamadaSimbolica("logaritmo", f)), operacionSimbolica("/", operacionSimbolica("*", g, df), f))
+	return operacionSimbolica("*", n, interior), nil
+}
+
+// --- EXPANSIÓN ---
+
+// maxExponenteExpansion limita (a + b)^n para no generar árboles enormes.
+const maxExponenteExpansion = 30
+
+// Expandir distribuye productos y potencias enteras sobre las sumas:
+// (x + 1)^2 = x^2 + 2*x + 1.
+func (s Simbolico) Expandir() Simbolico {
+	return Simbolico{raiz: expandirNodo(simplificarNodo(s.raiz))}.Simplificar()
+}
+
+func sumandos(n *nodoSimbolico) []*nodoSimbolico {
+	var res []*nodoSimbolico
+	for _, t := range descomponerSuma(n, 1, nil) {
+		res = append(res, reconstruirProducto(t.coef, t.factores))
+	}
+	return res
+}
+
+func distribuir(a, b *nodoSimbolico) *nodoSimbolico {
+	var res *nodoSimbolico
+	for _, x := range sumandos(a) {
+		for _, y := range sumandos(b) {
+			p := normalizarProducto(operacionSimbolica("*", x, y))
+			if res == nil {
+				res = p
+			} else {
+				res = operacionSimbolica("+", res, p)
+			}
+		}
+	}
+	return normalizarSuma(res)
+}
+
+func expandirNodo(n *nodoSimbolico) *nodoSimbolico {
+	if len(n.args) == 0 {
+		return n
+	}
+	copia := *n
+	copia.args = make([]*nodoSimbolico, len(n.args))
+	for i, a := range n.args {
+		copia.args[i] = expandirNodo(a)
+	}
+	n = &copia
+	switch {
+	case n.clase == nodoNegativo:
+		return normalizarSuma(n)
+	case n.clase != nodoOperacion:
+		return n
+	case n.nombre == "*":
+		return distribuir(n.args[0], n.args[1])
+	case n.nombre == "/":
+		// (a + b)/c = a/c + b/c
+		var res *nodoSimbolico
+		for _, x := range sumandos(n.args[0]) {
+			q := normalizarProducto(operacionSimbolica("/", x, n.args[1]))
+			if res == nil {
+				res = q
+			} else {
+				res = operacionSimbolica("+", res, q)
+			}
+		}
+		return normalizarSuma(res)
+	case n.nombre == "^":
+		exp := n.args[1]
+		if exp.clase != nodoNumero || !exp.numero.IsInt() || exp.numero.Sign() <= 0 || exp.numero.Cmp(big.NewRat(maxExponenteExpansion, 1)) > 0 || len(sumandos(n.args[0])) < 2 {
+			return n
+		}
+		res := n.args[0]
+		for i := int64(1); i < exp.numero.Num().Int64(); i++ {
+			res = distribuir(res, n.args[0])
+		}
+		return res
+	}
+	return normalizarSuma(n)
+}
+
+// --- FACTORIZACIÓN ---
+
+// Factorizar saca el factor común (numérico y de variables) y, si lo que
+// queda es un polinomio de una variable, separa sus raíces racionales:
+// 2*x^3 - 2*x = 2*x*(x - 1)*(x + 1).
+func (s Simbolico) Factorizar() Simbolico {
+	expandido := Simbolico{raiz: expandirNodo(simplificarNodo(s.raiz))}.Simplificar().raiz
+	terminos := combinarSumandos(descomponerSuma(expandido, 1, nil))
+	if len(terminos) < 2 {
+		return Simbolico{raiz: expandido}
+	}
+
+	// Factor común numérico: mcd de numeradores / mcm de denominadores
+	mcd, mcm := new(big.Int), big.NewInt(1)
+	for _, t := range terminos {
+		mcd.GCD(nil, nil, mcd, new(big.Int).Abs(t.coef.Num()))
+		den := t.coef.Denom()
+		mcm.Div(new(big.Int).Mul(mcm, den), new(big.Int).GCD(nil, nil, mcm, den))
+	}
+	contenido := new(big.Rat).SetFrac(mcd, mcm)
+	if terminos[0].coef.Sign() < 0 {
+		contenido.Neg(contenido)
+	}
+
+	// Factor común de variables: el menor exponente numérico positivo de cada base
+	comunes := map[string]factorSimbolico{}
+	for _, f := range terminos[0].factores {
+		if f.exponente.clase == nodoNumero && f.exponente.numero.Sign() > 0 {
+			comunes[f.clave] = f
+		}
+	}
+	for _, t := range terminos[1:] {
+		presentes := map[string]bool{}
+		for _, f := range t.factores {
+			c, ok := comunes[f.clave]
+			if !ok || f.exponente.clase != nodoNumero || f.exponente.numero.Sign() <= 0 {
+				continue
+			}
+			presentes[f.clave] = true
+			if f.exponente.numero.Cmp(c.exponente.numero) < 0 {
+				comunes[f.clave] = f
+			}
+		}
+		for clave := range comunes {
+			if !presentes[clave] {
+				delete(comunes, clave)
+			}
+		}
+	}
+
+	// Lo que queda al dividir cada término por el factor común
+	restantes := make([]terminoSimbolico, len(terminos))
+	for i, t := range terminos {
+		coef := new(big.Rat).Quo(t.coef, contenido)
+		var factores []factorSimbolico
+		for _, f := range t.factores {
+			if c, ok := comunes[f.clave]; ok {
+				exp := new(big.Rat).Sub(f.exponente.numero, c.exponente.numero)
+				f = factorSimbolico{base: f.base, exponente: numeroSimbolico(exp), clave: f.clave}
+			}
+			factores = append(factores, f)
+		}
+		restantes[i] = terminoSimbolico{coef: coef, factores: combinarFactores(factores)}
+	}
+
+	factores := []factorSimbolico{}
+	for _, c := range comunes {
+		factores = append(factores, c)
+	}
+	factores = append(factores, factorizarPolinomio(restantes)...)
+	res := reconstruirProducto(contenido, combinarFactores(factores))
+	return Simbolico{raiz: res}
+}
+
+// factorizarPolinomio separa las raíces racionales de un polinomio de una
+// variable con coeficientes racionales; si no lo es, devuelve la suma entera.
+func factorizarPolinomio(terminos []terminoSimbolico) []factorSimbolico {
+	suma := factorSimbolico{base: reconstruirSuma(combinarSumandos(terminos)), exponente: enteroSimbolico(1)}
+	variable := ""
+	grado := 0
+	for _, t := range terminos {
+		if len(t.factores) > 1 {
+			return []factorSimbolico{suma}
+		}
+		for _, f := range t.factores {
+			e := f.exponente
+			if f.base.clase != nodoVariable || e.clase != nodoNumero || !e.numero.IsInt() || e.numero.Sign() < 0 || !e.numero.Num().IsInt64() {
+				return []factorSimbolico{suma}
+			}
+			if variable != "" && variable != f.base.nombre {
+				return []factorSimbolico{suma}
+			}
+			variable = f.base.nombre
+			if g := int(e.numero.Num().Int64()); g > grado {
+				grado = g
+			}
+		}
+	}
+	if variable == "" || grado < 2 || grado > 64 {
+		return []factorSimbolico{suma}
+	}
+
+	// Coeficientes enteros en orden ascendente
+	coef := make([]*big.Rat, grado+1)
+	for i := range coef {
+		coef[i] = new(big.Rat)
+	}
+	for _, t := range terminos {
+		g := 0
+		if len(t.factores) == 1 {
+			g = int(t.factores[0].exponente.numero.Num().Int64())
+		}
+		coef[g].Add(coef[g], t.coef)
+	}
+
+	x := variableSimbolica(variable)
+	var factores []factorSimbolico
+	for len(coef) > 2 {
+		raiz, ok := raizRacional(coef)
+		if !ok {
+			break
+		}
+		// Factor (q*x - p) con coeficientes enteros
+		p, q := raiz.Num(), raiz.Denom()
+		lineal := reconstruirSuma(combinarSumandos([]terminoSimbolico{
+			{coef: new(big.Rat).SetInt(q), factores: []factorSimbolico{{base: x, exponente: enteroSimbolico(1), clave: variable}}},
+			{coef: new(big.Rat).SetInt(new(big.Int).Neg(p))},
+		}))
+		factores = append(factores, factorSimbolico{base: lineal, exponente: enteroSimbolico(1)})
+		coef = dividirLineal(coef, raiz, q)
+	}
+	if len(factores) == 0 {
+		return []factorSimbolico{suma}
+	}
+	// El cociente que queda (lineal o sin raíces racionales)
+	var resto []terminoSimbolico
+	for g, c := range coef {
+		if c.Sign() == 0 {
+			continue
+		}
+		t := terminoSimbolico{coef: c}
+		if g > 0 {
+			t.factores = []factorSimbolico{{base: x, exponente: enteroSimbolico(int64(g)), clave: variable}}
+		}
+		resto = append(resto, t)
+	}
+	cociente := reconstruirSuma(combinarSumandos(resto))
+	if !cociente.esNumero(1) {
+		factores = append(factores, factorSimbolico{base: cociente, exponente: enteroSimbolico(1)})
+	}
+	return factores
+}
+
+// raizRacional busca p/q con p | a0 y q | an (teorema de la raíz racional).
+func raizRacional(coef []*big.Rat) (*big.Rat, bool) {
+	if coef[0].Sign() == 0 {
+		return new(big.Rat), true
+	}
+	// Escalar a enteros
+	mcm := big.NewInt(1)
+	for _, c := range coef {
+		mcm.Div(new(big.Int).Mul(mcm, c.Denom()), new(big.Int).GCD(nil, nil, mcm, c.Denom()))
+	}
+	escalar := func(c *big.Rat) *big.Int {
+		return new(big.Int).Div(new(big.Int).Mul(c.Num(), mcm), c.Denom())
+	}
+	a0, an := escalar(coef[0]), escalar(coef[len(coef)-1])
+	divA0, divAn := divisores(a0), divisores(an)
+	if divA0 == nil || divAn == nil {
+		return nil, false
+	}
+	for _, q := range divAn {
+		for _, p := range divA0 {
+			for _, signo := range []int64{1, -1} {
+				r := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(signo), p), q)
+				if evaluarCoeficientes(coef, r).Sign() == 0 {
+					return r, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// divisores positivos de n; nil si n es demasiado grande para enumerarlos.
+func divisores(n *big.Int) []*big.Int {
+	n = new(big.Int).Abs(n)
+	if !n.IsInt64() || n.Int64() > 1_000_000_000_000 {
+		return nil
+	}
+	v := n.Int64()
+	var res []*big.Int
+	for d := int64(1); d*d <= v; d++ {
+		if v%d == 0 {
+			res = append(res, big.NewInt(d))
+			if d*d != v {
+				res = append(res, big.NewInt(v/d))
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Cmp(res[j]) < 0 })
+	return res
+}
+
+func evaluarCoeficientes(coef []*big.Rat, x *big.Rat) *big.Rat {
+	res := new(big.Rat)
+	for i := len(coef) - 1; i >= 0; i-- {
+		res.Mul(res, x)
+		res.Add(res, coef[i])
+	}
+	return res
+}
+
+// dividirLineal divide por (q*x - p) con raíz r = p/q (división sintética).
+func dividirLineal(coef []*big.Rat, r *big.Rat, q *big.Int) []*big.Rat {
+	n := len(coef) - 1
+	cociente := make([]*big.Rat, n)
+	acumulado := new(big.Rat)
+	for i := n; i >= 1; i-- {
+		acumulado = new(big.Rat).Add(new(big.Rat).Mul(acumulado, r), coef[i])
+		cociente[i-1] = acumulado
+	}
+	// Entre (x - r) → entre (q*x - p): se divide el cociente por q
+	qRat := new(big.Rat).SetInt(q)
+	for i := range cociente {
+		cociente[i] = new(big.Rat).Quo(cociente[i], qRat)
+	}
+	return cociente
+}
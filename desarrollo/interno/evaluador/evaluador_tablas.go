@@ -0,0 +1,454 @@
+package evaluador
+
+import (
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Tablas alineadas para matrices, listas de registros y diccionarios:
+//   imprimir tabla(m)                          → columnas alineadas
+//   imprimir tabla(empleados, "caja")          → bordes con caracteres de caja
+//   imprimir tabla(empleados, "markdown", "nombre", "sueldo")
+//   imprimir tabla(precios, "csv", 2)          → reales con 2 decimales
+//   configurar_tabla("caja", verdadero)        → estilo por defecto; con
+//                                                verdadero, imprimir muestra
+//                                                matrices y registros como tabla
+//
+// Las opciones van después del valor en cualquier orden: el primer texto es
+// el estilo, una lista de textos (o los textos que siguen al estilo) son las
+// columnas (en registros elige y ordena las claves; en matrices da los
+// encabezados) y un entero fija los decimales.
+
+var estilosTabla = map[string]bool{"simple": true, "caja": true, "markdown": true, "csv": true}
+
+// ConfigTabla guarda el estilo por defecto y si imprimir tabula solo.
+var ConfigTabla = struct {
+	Estilo     string
+	Automatico bool
+}{Estilo: "simple"}
+
+// opcionesTabla son las opciones ya leídas de tabla(valor, ...).
+type opcionesTabla struct {
+	estilo    string
+	columnas  []string
+	decimales int
+}
+
+// tablaDatos es una tabla ya convertida a texto: encabezados (puede no haber)
+// y, por columna, si todas sus celdas son números.
+type tablaDatos struct {
+	encabezados []string
+	filas       [][]string
+	numerica    []bool
+}
+
+func leerOpcionesTabla(nombre string, args []interface{}) (opcionesTabla, error) {
+	op := opcionesTabla{estilo: ConfigTabla.Estilo, decimales: -1}
+	leyoEstilo := false
+	for _, a := range args {
+		switch x := a.(type) {
+		case string:
+			if leyoEstilo {
+				op.columnas = append(op.columnas, x)
+				continue
+			}
+			leyoEstilo = true
+			estilo := strings.ToLower(strings.TrimSpace(x))
+			if !estilosTabla[estilo] {
+				return op, fmt.Errorf("❌ ERROR FATAL: estilo de tabla desconocido '%s' en '%s' (use simple, caja, markdown o csv)", x, nombre)
+			}
+			op.estilo = estilo
+		case []interface{}:
+			op.columnas = nil
+			for _, c := range x {
+				texto, err := comoCadena(nombre, c)
+				if err != nil {
+					return op, err
+				}
+				op.columnas = append(op.columnas, texto)
+			}
+		default:
+			n, err := comoEnteroPequeño(nombre, a)
+			if err != nil || n < 0 {
+				return op, fmt.Errorf("❌ ERROR FATAL: opción inválida en '%s': %v (use un estilo, una lista de columnas o los decimales)", nombre, FormatearValor(a))
+			}
+			op.decimales = n
+		}
+	}
+	return op, nil
+}
+
+// EsTabulable indica si el valor se puede mostrar como tabla: matrices,
+// listas de listas, listas de registros y diccionarios.
+func EsTabulable(v interface{}) bool {
+	switch x := v.(type) {
+	case [][]float64:
+		return len(x) > 0
+	case map[string]interface{}:
+		return len(x) > 0
+	case []interface{}:
+		if len(x) == 0 {
+			return false
+		}
+		for _, e := range x {
+			switch e.(type) {
+			case []interface{}, map[string]interface{}:
+			default:
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// celdaTabla convierte un valor a texto de una sola línea.
+func celdaTabla(v interface{}, decimales int) string {
+	var texto string
+	switch v.(type) {
+	case nil:
+		return ""
+	case float64, Decimal:
+		if decimales >= 0 {
+			if t, err := FormatearConEspecificacion(v, fmt.Sprintf(".%df", decimales)); err == nil {
+				texto = t
+				break
+			}
+		}
+		texto = formatearSinEspecificacion(v)
+	default:
+		texto = FormatearValor(v)
+	}
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ").Replace(texto)
+}
+
+// filasDeDatos pasa una matriz, una lista de registros, una lista de listas o
+// un diccionario a filas de valores con sus encabezados (puede no haberlos).
+// En los registros, columnas elige y ordena las claves; si no se indica, se
+// usa la unión ordenada de todas. En las matrices, columnas son los encabezados.
+func filasDeDatos(nombre string, v interface{}, columnas []string) ([]string, [][]interface{}, error) {
+	var crudas [][]interface{}
+
+	switch x := v.(type) {
+	case [][]float64:
+		for _, fila := range x {
+			celdas := make([]interface{}, len(fila))
+			for i, n := range fila {
+				celdas[i] = n
+			}
+			crudas = append(crudas, celdas)
+		}
+		return columnas, crudas, nil
+
+	case map[string]interface{}:
+		// Un diccionario (o la instancia de una estructura) es una tabla clave/valor.
+		claves := columnas
+		if claves == nil {
+			for k := range x {
+				claves = append(claves, k)
+			}
+			sort.Strings(claves)
+		}
+		for _, k := range claves {
+			crudas = append(crudas, []interface{}{k, x[k]})
+		}
+		return []string{"clave", "valor"}, crudas, nil
+
+	case []interface{}:
+		if esListaDeRegistros(x) {
+			// Lista de registros: las columnas son la unión de las claves.
+			if columnas == nil {
+				vistas := map[string]bool{}
+				for _, e := range x {
+					for k := range e.(map[string]interface{}) {
+						if !vistas[k] {
+							vistas[k] = true
+							columnas = append(columnas, k)
+						}
+					}
+				}
+				sort.Strings(columnas)
+			}
+			for _, e := range x {
+				registro := e.(map[string]interface{})
+				celdas := make([]interface{}, len(columnas))
+				for i, c := range columnas {
+					celdas[i] = registro[c]
+				}
+				crudas = append(crudas, celdas)
+			}
+			return columnas, crudas, nil
+		}
+		// Lista de listas (matriz) o lista simple (una sola columna).
+		for _, e := range x {
+			if fila, ok := e.([]interface{}); ok {
+				crudas = append(crudas, fila)
+			} else {
+				crudas = append(crudas, []interface{}{e})
+			}
+		}
+		return columnas, crudas, nil
+	}
+	return nil, nil, fmt.Errorf("❌ ERROR FATAL: '%s' requiere una matriz, una lista o un diccionario, recibió %s", nombre, obtenerTipoEnEspañol(v))
+}
+
+// esListaDeRegistros indica si todos los elementos son diccionarios.
+func esListaDeRegistros(lista []interface{}) bool {
+	for _, e := range lista {
+		if _, ok := e.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return len(lista) > 0
+}
+
+// armarTabla pasa el valor a filas de texto según su forma.
+func armarTabla(nombre string, v interface{}, op opcionesTabla) (tablaDatos, error) {
+	var t tablaDatos
+	encabezados, crudas, err := filasDeDatos(nombre, v, op.columnas)
+	if err != nil {
+		return t, err
+	}
+	t.encabezados = encabezados
+
+	ancho := len(t.encabezados)
+	for _, fila := range crudas {
+		if len(fila) > ancho {
+			ancho = len(fila)
+		}
+	}
+	t.numerica = make([]bool, ancho)
+	vacia := make([]bool, ancho)
+	for i := range t.numerica {
+		t.numerica[i] = true
+		vacia[i] = true
+	}
+	for _, fila := range crudas {
+		textos := make([]string, ancho)
+		for i := range textos {
+			if i >= len(fila) || fila[i] == nil {
+				continue
+			}
+			vacia[i] = false
+			if !esNumero(fila[i]) {
+				t.numerica[i] = false
+			}
+			textos[i] = celdaTabla(fila[i], op.decimales)
+		}
+		t.filas = append(t.filas, textos)
+	}
+	for i := range t.numerica {
+		t.numerica[i] = t.numerica[i] && !vacia[i]
+	}
+	if len(t.encabezados) > 0 && len(t.encabezados) < ancho {
+		for i := len(t.encabezados); i < ancho; i++ {
+			t.encabezados = append(t.encabezados, "")
+		}
+	}
+	return t, nil
+}
+
+// alinearCelda rellena a la derecha (texto) o a la izquierda (números); el
+// ancho se mide en caracteres, no en bytes.
+func alinearCelda(texto string, ancho int, derecha bool) string {
+	faltan := ancho - utf8.RuneCountInString(texto)
+	if faltan <= 0 {
+		return texto
+	}
+	if derecha {
+		return strings.Repeat(" ", faltan) + texto
+	}
+	return texto + strings.Repeat(" ", faltan)
+}
+
+func (t tablaDatos) anchos() []int {
+	anchos := make([]int, len(t.numerica))
+	for i, e := range t.encabezados {
+		anchos[i] = utf8.RuneCountInString(e)
+	}
+	for _, fila := range t.filas {
+		for i, c := range fila {
+			if n := utf8.RuneCountInString(c); n > anchos[i] {
+				anchos[i] = n
+			}
+		}
+	}
+	return anchos
+}
+
+func (t tablaDatos) alinear(fila []string, anchos []int) []string {
+	res := make([]string, len(fila))
+	for i, c := range fila {
+		res[i] = alinearCelda(c, anchos[i], t.numerica[i])
+	}
+	return res
+}
+
+// renderSimple: columnas separadas por dos espacios y los encabezados
+// subrayados con guiones.
+func (t tablaDatos) renderSimple() string {
+	anchos := t.anchos()
+	var lineas []string
+	if len(t.encabezados) > 0 {
+		lineas = append(lineas, strings.Join(t.alinear(t.encabezados, anchos), "  "))
+		guiones := make([]string, len(anchos))
+		for i, a := range anchos {
+			guiones[i] = strings.Repeat("-", a)
+		}
+		lineas = append(lineas, strings.Join(guiones, "  "))
+	}
+	for _, fila := range t.filas {
+		lineas = append(lineas, strings.Join(t.alinear(fila, anchos), "  "))
+	}
+	for i, l := range lineas {
+		lineas[i] = strings.TrimRight(l, " ")
+	}
+	return strings.Join(lineas, "\n")
+}
+
+func (t tablaDatos) renderCaja() string {
+	anchos := t.anchos()
+	borde := func(izq, medio, der string) string {
+		partes := make([]string, len(anchos))
+		for i, a := range anchos {
+			partes[i] = strings.Repeat("─", a+2)
+		}
+		return izq + strings.Join(partes, medio) + der
+	}
+	fila := func(celdas []string) string {
+		return "│ " + strings.Join(t.alinear(celdas, anchos), " │ ") + " │"
+	}
+	lineas := []string{borde("┌", "┬", "┐")}
+	if len(t.encabezados) > 0 {
+		lineas = append(lineas, fila(t.encabezados), borde("├", "┼", "┤"))
+	}
+	for _, f := range t.filas {
+		lineas = append(lineas, fila(f))
+	}
+	lineas = append(lineas, borde("└", "┴", "┘"))
+	return strings.Join(lineas, "\n")
+}
+
+// renderMarkdown: Markdown exige encabezados; sin ellos se numeran las
+// columnas. Las columnas numéricas se alinean a la derecha con "---:".
+func (t tablaDatos) renderMarkdown() string {
+	encabezados := t.encabezados
+	if len(encabezados) == 0 {
+		for i := range t.numerica {
+			encabezados = append(encabezados, fmt.Sprintf("%d", i))
+		}
+	}
+	escapar := strings.NewReplacer("|", `\|`)
+	escapadas := func(celdas []string) []string {
+		res := make([]string, len(celdas))
+		for i, c := range celdas {
+			res[i] = escapar.Replace(c)
+		}
+		return res
+	}
+	cuerpo := tablaDatos{encabezados: escapadas(encabezados), numerica: t.numerica}
+	for _, f := range t.filas {
+		cuerpo.filas = append(cuerpo.filas, escapadas(f))
+	}
+	anchos := cuerpo.anchos()
+	separadores := make([]string, len(anchos))
+	for i, a := range anchos {
+		if a < 3 {
+			anchos[i], a = 3, 3
+		}
+		if t.numerica[i] {
+			separadores[i] = strings.Repeat("-", a-1) + ":"
+		} else {
+			separadores[i] = strings.Repeat("-", a)
+		}
+	}
+	fila := func(celdas []string) string {
+		return "| " + strings.Join(cuerpo.alinear(celdas, anchos), " | ") + " |"
+	}
+	lineas := []string{fila(cuerpo.encabezados), "| " + strings.Join(separadores, " | ") + " |"}
+	for _, f := range cuerpo.filas {
+		lineas = append(lineas, fila(f))
+	}
+	return strings.Join(lineas, "\n")
+}
+
+func (t tablaDatos) renderCSV() (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	if len(t.encabezados) > 0 {
+		if err := w.Write(t.encabezados); err != nil {
+			return "", err
+		}
+	}
+	if err := w.WriteAll(t.filas); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// FormatearTabla muestra el valor como tabla; opciones como en tabla().
+func FormatearTabla(v interface{}, opciones ...interface{}) (string, error) {
+	op, err := leerOpcionesTabla("tabla", opciones)
+	if err != nil {
+		return "", err
+	}
+	t, err := armarTabla("tabla", v, op)
+	if err != nil {
+		return "", err
+	}
+	switch op.estilo {
+	case "caja":
+		return t.renderCaja(), nil
+	case "markdown":
+		return t.renderMarkdown(), nil
+	case "csv":
+		return t.renderCSV()
+	}
+	return t.renderSimple(), nil
+}
+
+func init() {
+	// tabla(valor, [estilo], [columnas...], [decimales]) -> texto con la tabla
+	Funciones["tabla"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'tabla' requiere una matriz, una lista o un diccionario")
+		}
+		return FormatearTabla(args[0], args[1:]...)
+	}
+	// Para pipes: imprimir empleados | ordenar() | formatear_tabla("caja")
+	Funciones["formatear_tabla"] = Funciones["tabla"]
+
+	// configurar_tabla(estilo, [automatico]) -> estilo por defecto de tabla();
+	// con automatico, imprimir muestra matrices, registros y diccionarios como tabla.
+	Funciones["configurar_tabla"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'configurar_tabla' requiere un estilo y opcionalmente verdadero/falso, ej. configurar_tabla(\"caja\", verdadero)")
+		}
+		op, err := leerOpcionesTabla("configurar_tabla", args[:1])
+		if err != nil {
+			return nil, err
+		}
+		if _, esTexto := args[0].(string); !esTexto {
+			return nil, fmt.Errorf("❌ ERROR FATAL: 'configurar_tabla' requiere el nombre de un estilo (simple, caja, markdown o csv)")
+		}
+		automatico := ConfigTabla.Automatico
+		if len(args) == 2 {
+			b, ok := args[1].(bool)
+			if !ok {
+				return nil, fmt.Errorf("❌ ERROR FATAL: 'configurar_tabla' espera verdadero o falso como segundo argumento")
+			}
+			automatico = b
+		}
+		ConfigTabla.Estilo = op.estilo
+		ConfigTabla.Automatico = automatico
+		return true, nil
+	}
+
+	// Métodos: empleados.tabla("caja"), m.tabla()
+	for _, tipo := range []string{"lista", "diccionario", "objeto"} {
+		Funciones[tipo+".tabla"] = Funciones["tabla"]
+	}
+}
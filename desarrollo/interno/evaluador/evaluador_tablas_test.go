@@ -0,0 +1,91 @@
+package evaluador
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTablas(t *testing.T) {
+	ctx := PrepararContextoEvaluador()
+	ctx.Variables["m"] = [][]float64{{1, 2.5, -3}, {10, 200, 0.125}}
+	ctx.Variables["emp"] = []interface{}{
+		map[string]interface{}{"nombre": "Ána", "edad": 31, "sueldo": 1234.5},
+		map[string]interface{}{"nombre": "Bartolomé | B", "edad": 4, "ciudad": "León"},
+	}
+	ctx.Variables["cols"] = []interface{}{"sueldo", "nombre"}
+	ctx.Variables["d"] = map[string]interface{}{"x": 1, "nombre": "Óscar"}
+
+	casos := []struct {
+		expresion string
+		esperado  []string
+	}{
+		{"tabla(m)", []string{
+			" 1  2.5     -3",
+			"10  200  0.125",
+		}},
+		{`tabla(m, "caja", "a", "b", "c")`, []string{
+			"┌────┬─────┬───────┐",
+			"│  a │   b │     c │",
+			"├────┼─────┼───────┤",
+			"│  1 │ 2.5 │    -3 │",
+			"│ 10 │ 200 │ 0.125 │",
+			"└────┴─────┴───────┘",
+		}},
+		// los registros toman como columnas la unión de sus claves; los
+		// números se alinean a la derecha y los decimales se fijan si se pide
+		{`tabla(emp, "caja", 2)`, []string{
+			"┌────────┬──────┬───────────────┬─────────┐",
+			"│ ciudad │ edad │ nombre        │  sueldo │",
+			"├────────┼──────┼───────────────┼─────────┤",
+			"│        │   31 │ Ána           │ 1234.50 │",
+			"│ León   │    4 │ Bartolomé | B │         │",
+			"└────────┴──────┴───────────────┴─────────┘",
+		}},
+		{`tabla(emp, "markdown", cols)`, []string{
+			"| sueldo | nombre         |",
+			"| -----: | -------------- |",
+			"| 1234.5 | Ána            |",
+			"|        | Bartolomé \\| B |",
+		}},
+		{`tabla(emp, "csv", "nombre", "sueldo")`, []string{
+			"nombre,sueldo",
+			"Ána,1234.5",
+			"Bartolomé | B,",
+		}},
+		{`tabla(d, "caja")`, []string{
+			"┌────────┬───────┐",
+			"│ clave  │ valor │",
+			"├────────┼───────┤",
+			"│ nombre │ Óscar │",
+			"│ x      │ 1     │",
+			"└────────┴───────┘",
+		}},
+	}
+	for _, c := range casos {
+		r, err := EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if esperado := strings.Join(c.esperado, "\n"); strings.TrimRight(r.(string), "\n") != esperado {
+			t.Errorf("%s =\n%s\nse esperaba\n%s", c.expresion, r, esperado)
+		}
+	}
+
+	// formatear_tabla en una tubería y el método .tabla() dan lo mismo que tabla()
+	directa, _ := EvalConContexto(`tabla(emp, "markdown")`, ctx)
+	for _, expresion := range []string{`emp | formatear_tabla("markdown")`, `emp.tabla("markdown")`} {
+		if r, err := EvalConContexto(expresion, ctx); err != nil || r != directa {
+			t.Errorf("%s = %v, %v", expresion, r, err)
+		}
+	}
+
+	for expresion, mensaje := range map[string]string{
+		"tabla(5)":         "requiere una matriz, una lista o un diccionario",
+		`tabla(m, "rara")`: "estilo de tabla desconocido 'rara'",
+	} {
+		if _, err := EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
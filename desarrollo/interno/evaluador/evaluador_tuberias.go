@@ -0,0 +1,54 @@
+package evaluador
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// Tuberías: el valor de la izquierda de '|' entra como primer argumento de
+// la función de la derecha, y las etapas se encadenan de izquierda a derecha:
+//   datos | ordenar() | primeros(5)     → primeros(ordenar(datos), 5)
+//   nombre | mayusculas                 → mayusculas(nombre)
+//   precios | funcion(p) => p * 1.16    → la lambda recibe el valor
+// Si la derecha no es una función es un error: el o bit a bit de enteros
+// está en bit_o().
+
+// aplicarTuberia resuelve valor | etapa. manejado es falso cuando la etapa no
+// es una función.
+func aplicarTuberia(valor interface{}, etapa ast.Expr, ctx *Contexto) (interface{}, bool, error) {
+	switch e := etapa.(type) {
+	case *ast.CallExpr:
+		fn, ok := e.Fun.(*ast.Ident)
+		if !ok {
+			return nil, false, nil
+		}
+		if strings.ToLower(fn.Name) == identificadorLambda {
+			lambda, err := crearLambda(e, ctx)
+			if err != nil {
+				return nil, true, err
+			}
+			invocable, _ := comoInvocable(lambda)
+			res, err := invocable.Llamar(valor)
+			return res, true, err
+		}
+		argumentos, err := evaluarArgumentos(e.Args, ctx)
+		if err != nil {
+			return nil, true, err
+		}
+		res, err := llamarPorNombre(fn, append([]interface{}{valor}, argumentos...), ctx)
+		return res, true, err
+
+	case *ast.Ident:
+		v, err := evaluarIdentificador(e, ctx)
+		if err != nil {
+			return nil, false, nil
+		}
+		invocable, esFuncion := comoInvocable(v)
+		if !esFuncion {
+			return nil, false, nil
+		}
+		res, err := invocable.Llamar(valor)
+		return res, true, err
+	}
+	return nil, false, nil
+}
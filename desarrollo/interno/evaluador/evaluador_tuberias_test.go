@@ -0,0 +1,45 @@
+package evaluador
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTuberias(t *testing.T) {
+	ctx := PrepararContextoEvaluador()
+	ctx.Variables["datos"] = []interface{}{5, 3, 9, 1}
+	ctx.Variables["nombre"] = "ana"
+
+	casos := []struct {
+		expresion string
+		esperado  interface{}
+	}{
+		{"nombre | mayusculas", "ANA"},
+		{"nombre | mayusculas()", "ANA"},
+		{"datos | ordenar() | primeros(2)", []interface{}{1, 3}},
+		{"datos | mapear(funcion(x) => x * 2) | primeros(1)", []interface{}{10}},
+		{"3 | funcion(x) => x + 1", 4},
+		{"2 + 1 | funcion(x) => x * 10", 30},
+	}
+	for _, c := range casos {
+		r, err := EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if !reflect.DeepEqual(r, c.esperado) {
+			t.Errorf("%s = %#v, se esperaba %#v", c.expresion, r, c.esperado)
+		}
+	}
+
+	for _, expresion := range []string{"6 | 3", "datos | 1", `nombre | "x"`} {
+		_, err := EvalConContexto(expresion, ctx)
+		if err == nil || !strings.Contains(err.Error(), "lado derecho de |") || !strings.Contains(err.Error(), "bit_o") {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+	if _, err := EvalConContexto("datos | no_existe", ctx); err == nil {
+		t.Error("una etapa que no existe debía fallar")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"math/big"
+	"time"
 )
 
 // evaluarUnario maneja expresiones de un solo operando como -a, +5 o !booleano.
@@ -17,9 +19,33 @@ func evaluarUnario(n *ast.UnaryExpr, ctx *Contexto) (interface{}, error) {
 
 	switch n.Op {
 	case token.ADD: // Caso: +x
+		switch v := valor.(type) {
+		case complex128, Decimal, Cantidad, Duracion, int, int64, *big.Int, *big.Rat:
+			return v, nil
+		}
 		return ConvertirAReal(valor)
 
 	case token.SUB: // Caso: -x
+		if negado, ok := negarColeccion(valor); ok {
+			return negado, nil
+		}
+		switch v := valor.(type) {
+		case complex128:
+			return -v, nil
+		case Decimal:
+			return v.Negar(), nil
+		case int, int64, *big.Int:
+			b, _ := ComoEnteroGrande(v)
+			return NormalizarEntero(new(big.Int).Neg(b)), nil
+		case *big.Rat:
+			return new(big.Rat).Neg(v), nil
+		case Cantidad:
+			return v.Negar(), nil
+		case Duracion:
+			return v.Negar(), nil
+		case time.Duration:
+			return Duracion{Exacta: -v}, nil
+		}
 		f, err := ConvertirAReal(valor)
 		if err != nil {
 			return nil, err
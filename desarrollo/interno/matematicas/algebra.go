@@ -3,19 +3,29 @@ package matematicas
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"nepa/desarrollo/interno/evaluador"
 )
 
+// mcdGrande devuelve el máximo común divisor (siempre no negativo).
+func mcdGrande(a, b *big.Int) *big.Int {
+	return new(big.Int).GCD(nil, nil, new(big.Int).Abs(a), new(big.Int).Abs(b))
+}
+
 func inyectarAlgebraGlobal() {
 
 	// --- 1. RESOLUCIÓN DE ECUACIONES Y RAÍCES ---
 
 	// resolver_cuadratica(a, b, c) -> Retorna [x1, x2] usando la fórmula general
+	// (con discriminante negativo las raíces son complejas conjugadas)
 	evaluador.Funciones["resolver_cuadratica"] = func(args ...interface{}) (interface{}, error) {
 		a, b, c, err := validar3("resolver_cuadratica", args); if err != nil { return nil, err }
+		if a == 0 { return nil, fmt.Errorf("❌ ERROR: 'resolver_cuadratica' requiere a ≠ 0") }
 		disc := (b * b) - (4 * a * c)
 		if disc < 0 {
-			return nil, fmt.Errorf("❌ ERROR: Discriminante negativo (%f). Raíces imaginarias no soportadas", disc)
+			re := -b / (2 * a)
+			im := math.Sqrt(-disc) / (2 * math.Abs(a))
+			return []interface{}{complex(re, im), complex(re, -im)}, nil
 		}
 		x1 := (-b + math.Sqrt(disc)) / (2 * a)
 		x2 := (-b - math.Sqrt(disc)) / (2 * a)
@@ -30,40 +40,42 @@ func inyectarAlgebraGlobal() {
 
 	// --- 2. TEORÍA DE NÚMEROS (Criptografía y Algoritmia) ---
 
-	// mcd(a, b) -> Máximo Común Divisor (Algoritmo de Euclides)
+	// mcd(a, b) -> Máximo Común Divisor (Algoritmo de Euclides, enteros de cualquier tamaño)
 	evaluador.Funciones["mcd"] = func(args ...interface{}) (interface{}, error) {
-		a, b, err := validar2("mcd", args); if err != nil { return nil, err }
-		ia, ib := int64(math.Abs(a)), int64(math.Abs(b))
-		for ib != 0 {
-			ia, ib = ib, ia%ib
-		}
-		return float64(ia), nil
+		a, b, err := validarEnteros2("mcd", args); if err != nil { return nil, err }
+		return evaluador.NormalizarEntero(mcdGrande(a, b)), nil
 	}
 
 	// mcm(a, b) -> Mínimo Común Múltiplo
 	evaluador.Funciones["mcm"] = func(args ...interface{}) (interface{}, error) {
-		a, b, err := validar2("mcm", args); if err != nil { return nil, err }
-		if a == 0 || b == 0 { return 0.0, nil }
-		ia, ib := int64(math.Abs(a)), int64(math.Abs(b))
+		a, b, err := validarEnteros2("mcm", args); if err != nil { return nil, err }
+		if a.Sign() == 0 || b.Sign() == 0 { return 0, nil }
 		// MCM(a,b) = |a*b| / MCD(a,b)
-		tempA, tempB := ia, ib
-		for tempB != 0 {
-			tempA, tempB = tempB, tempA%tempB
-		}
-		return float64((ia * ib) / tempA), nil
+		producto := new(big.Int).Abs(new(big.Int).Mul(a, b))
+		return evaluador.NormalizarEntero(producto.Quo(producto, mcdGrande(a, b))), nil
 	}
 
-	// es_primo(n) -> Test de primalidad optimizado
+	// es_primo(n) -> Miller-Rabin + Baillie-PSW (exacto para n < 2^64)
 	evaluador.Funciones["es_primo"] = func(args ...interface{}) (interface{}, error) {
-		n, err := validar1("es_primo", args); if err != nil { return nil, err }
-		num := int64(n)
-		if num <= 1 { return false, nil }
-		if num <= 3 { return true, nil }
-		if num%2 == 0 || num%3 == 0 { return false, nil }
-		for i := int64(5); i*i <= num; i += 6 {
-			if num%i == 0 || num%(i+2) == 0 { return false, nil }
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'es_primo' requiere 1 argumento") }
+		n, err := validarEntero("es_primo", args[0]); if err != nil { return nil, err }
+		return n.Sign() > 0 && n.ProbablyPrime(20), nil
+	}
+
+	// potencia_modular(base, exponente, modulo) -> base^exponente mod modulo, sin desbordes
+	evaluador.Funciones["potencia_modular"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 3 { return nil, fmt.Errorf("❌ ERROR: 'potencia_modular' requiere 3 argumentos: base, exponente, modulo") }
+		b, err := validarEntero("potencia_modular", args[0]); if err != nil { return nil, err }
+		e, err := validarEntero("potencia_modular", args[1]); if err != nil { return nil, err }
+		m, err := validarEntero("potencia_modular", args[2]); if err != nil { return nil, err }
+		if m.Sign() <= 0 { return nil, fmt.Errorf("❌ ERROR: en 'potencia_modular' el módulo debe ser positivo") }
+		if e.Sign() < 0 {
+			// Exponente negativo: se usa el inverso modular de la base
+			inv := new(big.Int).ModInverse(b, m)
+			if inv == nil { return nil, fmt.Errorf("❌ ERROR: %s no tiene inverso módulo %s", b, m) }
+			return evaluador.NormalizarEntero(new(big.Int).Exp(inv, new(big.Int).Neg(e), m)), nil
 		}
-		return true, nil
+		return evaluador.NormalizarEntero(new(big.Int).Exp(b, e, m)), nil
 	}
 
 	// --- 3. FUNCIONES ESPECIALES Y DE PRECISIÓN (Tu Aporte + Mejoras) ---
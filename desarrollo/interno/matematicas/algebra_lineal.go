@@ -0,0 +1,655 @@
+package matematicas
+
+import (
+	"fmt"
+	"math"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// Tolerancias del motor de álgebra lineal
+const (
+	tolSingular       = 1e-12 // Pivote relativo por debajo del cual la matriz es singular
+	limiteCondicion   = 1e12  // Número de condición a partir del cual avisamos
+	maxIteracionesJac = 100   // Barridos máximos del método de Jacobi
+)
+
+func inyectarAlgebraLinealGlobal() {
+
+	// --- 1. MATRICES ESPECIALES ---
+
+	// identidad(n) -> Matriz identidad de n x n
+	evaluador.Funciones["identidad"] = func(args ...interface{}) (interface{}, error) {
+		v, err := validar1("identidad", args); if err != nil { return nil, err }
+		n := int(v)
+		if n < 1 { return nil, fmt.Errorf("❌ ERROR: 'identidad' requiere un tamaño mayor a 0") }
+		return matrizIdentidad(n), nil
+	}
+
+	// traza(M) -> Suma de la diagonal principal
+	evaluador.Funciones["traza"] = func(args ...interface{}) (interface{}, error) {
+		m, err := validarMatrizCuadrada("traza", args); if err != nil { return nil, err }
+		var t float64
+		for i := range m { t += m[i][i] }
+		return finalizar("traza", t)
+	}
+
+	// --- 2. DETERMINANTE, INVERSA Y RANGO (N x N) ---
+
+	// determinante(M) -> Determinante por descomposición LU
+	evaluador.Funciones["determinante"] = func(args ...interface{}) (interface{}, error) {
+		m, err := validarMatrizCuadrada("determinante", args); if err != nil { return nil, err }
+		lu, err := descomponerLU(m)
+		if err != nil {
+			// Una matriz singular tiene determinante cero, no es un error
+			return 0.0, nil
+		}
+		return finalizar("determinante", lu.determinante())
+	}
+
+	// inversa(M) -> M^-1 (falla si M es singular)
+	evaluador.Funciones["inversa"] = func(args ...interface{}) (interface{}, error) {
+		m, err := validarMatrizCuadrada("inversa", args); if err != nil { return nil, err }
+		inv, err := invertirMatriz("inversa", m)
+		if err != nil { return nil, err }
+		return inv, nil
+	}
+
+	// resolver_sistema(A, b) -> x tal que A·x = b
+	// b puede ser una lista (un sistema) o una matriz (varios lados derechos)
+	evaluador.Funciones["resolver_sistema"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'resolver_sistema' requiere (A, b)") }
+		a, err := convertirAMatriz("resolver_sistema", args[0]); if err != nil { return nil, err }
+		if !esCuadrada(a) { return nil, fmt.Errorf("❌ ERROR: en 'resolver_sistema' A debe ser cuadrada (%dx%d)", len(a), len(a[0])) }
+
+		b, esVector, err := convertirLadoDerecho(args[1])
+		if err != nil { return nil, err }
+		if len(b) != len(a) {
+			return nil, fmt.Errorf("❌ ERROR: en 'resolver_sistema' b tiene %d filas y A tiene %d", len(b), len(a))
+		}
+
+		lu, err := descomponerLU(a)
+		if err != nil { return nil, fmt.Errorf("%v: el sistema no tiene solución única", err) }
+		avisarCondicion("resolver_sistema", a, lu)
+
+		x := lu.resolver(b)
+		if esVector {
+			col := make([]float64, len(x))
+			for i := range x { col[i] = x[i][0] }
+			return col, nil
+		}
+		return x, nil
+	}
+
+	// rango(M) -> Número de filas linealmente independientes
+	evaluador.Funciones["rango"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'rango' requiere una matriz") }
+		m, err := convertirAMatriz("rango", args[0]); if err != nil { return nil, err }
+		return float64(rangoMatriz(m)), nil
+	}
+
+	// condicion(M) -> Número de condición en norma 1 (‖M‖·‖M^-1‖)
+	evaluador.Funciones["condicion"] = func(args ...interface{}) (interface{}, error) {
+		m, err := validarMatrizCuadrada("condicion", args); if err != nil { return nil, err }
+		lu, err := descomponerLU(m)
+		if err != nil { return math.Inf(1), nil }
+		return numeroCondicion(m, lu), nil
+	}
+
+	// --- 3. DESCOMPOSICIONES ---
+
+	// descomposicion_lu(M) -> {"L": ..., "U": ..., "P": ...} con P·M = L·U
+	evaluador.Funciones["descomposicion_lu"] = func(args ...interface{}) (interface{}, error) {
+		m, err := validarMatrizCuadrada("descomposicion_lu", args); if err != nil { return nil, err }
+		lu, err := descomponerLU(m)
+		if err != nil { return nil, err }
+		l, u, p := lu.factores()
+		return map[string]interface{}{"L": l, "U": u, "P": p}, nil
+	}
+
+	// descomposicion_qr(M) -> {"Q": ..., "R": ...} con M = Q·R (Householder)
+	evaluador.Funciones["descomposicion_qr"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'descomposicion_qr' requiere una matriz") }
+		m, err := convertirAMatriz("descomposicion_qr", args[0]); if err != nil { return nil, err }
+		if len(m) < len(m[0]) {
+			return nil, fmt.Errorf("❌ ERROR: 'descomposicion_qr' requiere filas >= columnas (%dx%d)", len(m), len(m[0]))
+		}
+		q, r := descomponerQR(m)
+		return map[string]interface{}{"Q": q, "R": r}, nil
+	}
+
+	// --- 4. VALORES Y VECTORES PROPIOS (Matrices simétricas, Jacobi) ---
+
+	// valores_propios(M) -> Lista ordenada de mayor a menor
+	evaluador.Funciones["valores_propios"] = func(args ...interface{}) (interface{}, error) {
+		m, err := validarMatrizSimetrica("valores_propios", args); if err != nil { return nil, err }
+		valores, _, err := jacobiSimetrica(m)
+		if err != nil { return nil, err }
+		return valores, nil
+	}
+
+	// vectores_propios(M) -> {"valores": [...], "vectores": matriz con un vector por columna}
+	evaluador.Funciones["vectores_propios"] = func(args ...interface{}) (interface{}, error) {
+		m, err := validarMatrizSimetrica("vectores_propios", args); if err != nil { return nil, err }
+		valores, vectores, err := jacobiSimetrica(m)
+		if err != nil { return nil, err }
+		return map[string]interface{}{"valores": valores, "vectores": vectores}, nil
+	}
+
+	// --- 5. OPERACIONES ELEMENTO A ELEMENTO ---
+
+	evaluador.Funciones["matriz_restar"] = func(args ...interface{}) (interface{}, error) {
+		return operarElementos("matriz_restar", args, func(a, b float64) float64 { return a - b })
+	}
+
+	evaluador.Funciones["matriz_multiplicar_elementos"] = func(args ...interface{}) (interface{}, error) {
+		return operarElementos("matriz_multiplicar_elementos", args, func(a, b float64) float64 { return a * b })
+	}
+
+	evaluador.Funciones["matriz_dividir_elementos"] = func(args ...interface{}) (interface{}, error) {
+		res, err := operarElementos("matriz_dividir_elementos", args, func(a, b float64) float64 { return a / b })
+		if err != nil { return nil, err }
+		return finalizarMatriz("matriz_dividir_elementos", res.([][]float64))
+	}
+
+	// matriz_escalar(M, k) -> k·M
+	evaluador.Funciones["matriz_escalar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'matriz_escalar' requiere (matriz, escalar)") }
+		m, err := convertirAMatriz("matriz_escalar", args[0]); if err != nil { return nil, err }
+		k, err := evaluador.ConvertirAReal(args[1]); if err != nil { return nil, err }
+		return aplicarElementos(m, func(v float64) float64 { return v * k }), nil
+	}
+
+	// matriz_potencia_elementos(M, p) -> Cada elemento elevado a p
+	evaluador.Funciones["matriz_potencia_elementos"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'matriz_potencia_elementos' requiere (matriz, exponente)") }
+		m, err := convertirAMatriz("matriz_potencia_elementos", args[0]); if err != nil { return nil, err }
+		p, err := evaluador.ConvertirAReal(args[1]); if err != nil { return nil, err }
+		return finalizarMatriz("matriz_potencia_elementos", aplicarElementos(m, func(v float64) float64 { return math.Pow(v, p) }))
+	}
+}
+
+// --- CONVERSIÓN Y VALIDACIÓN ---
+
+// convertirAMatriz acepta [][]float64, [][]interface{} o una lista de filas
+// y devuelve una matriz rectangular no vacía.
+func convertirAMatriz(nombre string, v interface{}) ([][]float64, error) {
+	var filas [][]float64
+	switch m := v.(type) {
+	case [][]float64:
+		filas = m
+	case [][]interface{}:
+		for _, f := range m {
+			fila, err := convertirFila(f)
+			if err != nil { return nil, fmt.Errorf("❌ ERROR en '%s': %v", nombre, err) }
+			filas = append(filas, fila)
+		}
+	case []interface{}:
+		for _, f := range m {
+			fila, err := convertirFila(f)
+			if err != nil { return nil, fmt.Errorf("❌ ERROR en '%s': %v", nombre, err) }
+			filas = append(filas, fila)
+		}
+	case []float64:
+		// Un vector se interpreta como matriz columna
+		for _, x := range m { filas = append(filas, []float64{x}) }
+	default:
+		return nil, fmt.Errorf("❌ ERROR: '%s' requiere una matriz, recibiste %T", nombre, v)
+	}
+
+	if len(filas) == 0 || len(filas[0]) == 0 {
+		return nil, fmt.Errorf("❌ ERROR: '%s' recibió una matriz vacía", nombre)
+	}
+	for i, f := range filas {
+		if len(f) != len(filas[0]) {
+			return nil, fmt.Errorf("❌ ERROR: en '%s' la fila %d tiene %d columnas y se esperaban %d", nombre, i+1, len(f), len(filas[0]))
+		}
+	}
+	return filas, nil
+}
+
+func convertirFila(v interface{}) ([]float64, error) {
+	if f, ok := v.([]float64); ok {
+		return append([]float64(nil), f...), nil
+	}
+	return evaluador.ConvertirAListaReal(v)
+}
+
+// convertirLadoDerecho interpreta b como vector (columna) o como matriz.
+func convertirLadoDerecho(v interface{}) ([][]float64, bool, error) {
+	switch b := v.(type) {
+	case [][]float64, [][]interface{}:
+		m, err := convertirAMatriz("resolver_sistema", b)
+		return m, false, err
+	}
+	nums, err := evaluador.ConvertirAListaReal(v)
+	if err != nil {
+		// Lista de filas: [[1],[2]]
+		m, errM := convertirAMatriz("resolver_sistema", v)
+		return m, false, errM
+	}
+	col := make([][]float64, len(nums))
+	for i, x := range nums { col[i] = []float64{x} }
+	return col, true, nil
+}
+
+func validarMatrizCuadrada(nombre string, args []interface{}) ([][]float64, error) {
+	if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: '%s' requiere una matriz", nombre) }
+	m, err := convertirAMatriz(nombre, args[0])
+	if err != nil { return nil, err }
+	if !esCuadrada(m) {
+		return nil, fmt.Errorf("❌ ERROR: '%s' requiere una matriz cuadrada (recibiste %dx%d)", nombre, len(m), len(m[0]))
+	}
+	return m, nil
+}
+
+func validarMatrizSimetrica(nombre string, args []interface{}) ([][]float64, error) {
+	m, err := validarMatrizCuadrada(nombre, args)
+	if err != nil { return nil, err }
+	escala := normaMaxima(m)
+	for i := range m {
+		for j := i + 1; j < len(m); j++ {
+			if math.Abs(m[i][j]-m[j][i]) > 1e-9*math.Max(1, escala) {
+				return nil, fmt.Errorf("❌ ERROR: '%s' solo soporta matrices simétricas (M[%d][%d] ≠ M[%d][%d])", nombre, i, j, j, i)
+			}
+		}
+	}
+	return m, nil
+}
+
+func esCuadrada(m [][]float64) bool { return len(m) > 0 && len(m) == len(m[0]) }
+
+func finalizarMatriz(nombre string, m [][]float64) (interface{}, error) {
+	for _, fila := range m {
+		for _, v := range fila {
+			if _, err := finalizar(nombre, v); err != nil { return nil, err }
+		}
+	}
+	return m, nil
+}
+
+// --- UTILERÍA BÁSICA ---
+
+func matrizIdentidad(n int) [][]float64 {
+	m := crearMatrizVacia(n, n)
+	for i := 0; i < n; i++ { m[i][i] = 1 }
+	return m
+}
+
+func copiarMatriz(m [][]float64) [][]float64 {
+	c := make([][]float64, len(m))
+	for i := range m {
+		c[i] = append([]float64(nil), m[i]...)
+	}
+	return c
+}
+
+func multiplicarMatrices(a, b [][]float64) [][]float64 {
+	res := crearMatrizVacia(len(a), len(b[0]))
+	for i := range a {
+		for k := range b {
+			if a[i][k] == 0 { continue }
+			for j := range b[0] {
+				res[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return res
+}
+
+func aplicarElementos(m [][]float64, f func(float64) float64) [][]float64 {
+	res := crearMatrizVacia(len(m), len(m[0]))
+	for i := range m {
+		for j := range m[i] { res[i][j] = f(m[i][j]) }
+	}
+	return res
+}
+
+func operarElementos(nombre string, args []interface{}, f func(a, b float64) float64) (interface{}, error) {
+	if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: '%s' requiere 2 matrices", nombre) }
+	m1, err := convertirAMatriz(nombre, args[0]); if err != nil { return nil, err }
+	m2, err := convertirAMatriz(nombre, args[1]); if err != nil { return nil, err }
+	if len(m1) != len(m2) || len(m1[0]) != len(m2[0]) {
+		return nil, fmt.Errorf("❌ ERROR: en '%s' las dimensiones no coinciden (%dx%d vs %dx%d)", nombre, len(m1), len(m1[0]), len(m2), len(m2[0]))
+	}
+	res := crearMatrizVacia(len(m1), len(m1[0]))
+	for i := range m1 {
+		for j := range m1[i] { res[i][j] = f(m1[i][j], m2[i][j]) }
+	}
+	return res, nil
+}
+
+// normaMaxima devuelve el mayor valor absoluto de la matriz (escala de tolerancias).
+func normaMaxima(m [][]float64) float64 {
+	var max float64
+	for _, fila := range m {
+		for _, v := range fila {
+			if math.Abs(v) > max { max = math.Abs(v) }
+		}
+	}
+	return max
+}
+
+// normaUno devuelve la máxima suma absoluta por columna.
+func normaUno(m [][]float64) float64 {
+	var max float64
+	for j := range m[0] {
+		var s float64
+		for i := range m { s += math.Abs(m[i][j]) }
+		if s > max { max = s }
+	}
+	return max
+}
+
+// --- DESCOMPOSICIÓN LU (Pivoteo parcial) ---
+
+// factorizacionLU guarda L y U compactas en una sola matriz y la permutación de filas.
+type factorizacionLU struct {
+	lu    [][]float64
+	perm  []int
+	signo float64
+}
+
+func descomponerLU(m [][]float64) (*factorizacionLU, error) {
+	n := len(m)
+	lu := copiarMatriz(m)
+	perm := make([]int, n)
+	for i := range perm { perm[i] = i }
+	signo := 1.0
+	escala := normaMaxima(m)
+	if escala == 0 {
+		return nil, fmt.Errorf("❌ ERROR: la matriz es singular (todos sus elementos son cero)")
+	}
+
+	for k := 0; k < n; k++ {
+		// Elegimos el pivote de mayor magnitud en la columna k
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu[i][k]) > math.Abs(lu[p][k]) { p = i }
+		}
+		if math.Abs(lu[p][k]) <= tolSingular*escala {
+			return nil, fmt.Errorf("❌ ERROR: la matriz es singular (pivote nulo en la columna %d)", k+1)
+		}
+		if p != k {
+			lu[p], lu[k] = lu[k], lu[p]
+			perm[p], perm[k] = perm[k], perm[p]
+			signo = -signo
+		}
+		for i := k + 1; i < n; i++ {
+			lu[i][k] /= lu[k][k]
+			for j := k + 1; j < n; j++ {
+				lu[i][j] -= lu[i][k] * lu[k][j]
+			}
+		}
+	}
+	return &factorizacionLU{lu: lu, perm: perm, signo: signo}, nil
+}
+
+func (f *factorizacionLU) determinante() float64 {
+	det := f.signo
+	for i := range f.lu { det *= f.lu[i][i] }
+	return det
+}
+
+// resolver aplica sustitución hacia adelante y hacia atrás para cada columna de b.
+func (f *factorizacionLU) resolver(b [][]float64) [][]float64 {
+	n := len(f.lu)
+	cols := len(b[0])
+	x := crearMatrizVacia(n, cols)
+	for c := 0; c < cols; c++ {
+		y := make([]float64, n)
+		for i := 0; i < n; i++ {
+			s := b[f.perm[i]][c]
+			for j := 0; j < i; j++ { s -= f.lu[i][j] * y[j] }
+			y[i] = s
+		}
+		for i := n - 1; i >= 0; i-- {
+			s := y[i]
+			for j := i + 1; j < n; j++ { s -= f.lu[i][j] * x[j][c] }
+			x[i][c] = s / f.lu[i][i]
+		}
+	}
+	return x
+}
+
+// factores separa la forma compacta en L, U y la matriz de permutación P.
+func (f *factorizacionLU) factores() ([][]float64, [][]float64, [][]float64) {
+	n := len(f.lu)
+	l := matrizIdentidad(n)
+	u := crearMatrizVacia(n, n)
+	p := crearMatrizVacia(n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if j < i {
+				l[i][j] = f.lu[i][j]
+			} else {
+				u[i][j] = f.lu[i][j]
+			}
+		}
+		p[i][f.perm[i]] = 1
+	}
+	return l, u, p
+}
+
+func invertirMatriz(nombre string, m [][]float64) ([][]float64, error) {
+	lu, err := descomponerLU(m)
+	if err != nil { return nil, err }
+	avisarCondicion(nombre, m, lu)
+	return lu.resolver(matrizIdentidad(len(m))), nil
+}
+
+func numeroCondicion(m [][]float64, lu *factorizacionLU) float64 {
+	inv := lu.resolver(matrizIdentidad(len(m)))
+	return normaUno(m) * normaUno(inv)
+}
+
+// estimarCondicion aproxima ‖M‖·‖M^-1‖ en norma 1 sin formar la inversa:
+// el método de Hager busca la columna de M^-1 de mayor suma con unas pocas
+// resoluciones (O(n²) cada una) sobre la LU ya calculada. Da una cota
+// inferior que casi siempre acierta el orden de magnitud.
+func estimarCondicion(m [][]float64, lu *factorizacionLU) float64 {
+	n := len(m)
+	x := make([]float64, n)
+	for i := range x { x[i] = 1 / float64(n) }
+	var estimacion float64
+	for iter := 0; iter < 5; iter++ {
+		y := lu.resolverVector(x)
+		signos := make([]float64, n)
+		estimacion = 0
+		for i, v := range y {
+			estimacion += math.Abs(v)
+			signos[i] = 1
+			if v < 0 { signos[i] = -1 }
+		}
+		z := lu.resolverTranspuesta(signos)
+		j, producto := 0, 0.0
+		for i := range z {
+			producto += z[i] * x[i]
+			if math.Abs(z[i]) > math.Abs(z[j]) { j = i }
+		}
+		if math.Abs(z[j]) <= producto { break }
+		x = make([]float64, n)
+		x[j] = 1
+	}
+	return normaUno(m) * estimacion
+}
+
+// resolverVector resuelve M·x = b para un solo lado derecho.
+func (f *factorizacionLU) resolverVector(b []float64) []float64 {
+	col := make([][]float64, len(b))
+	for i, v := range b { col[i] = []float64{v} }
+	x := f.resolver(col)
+	res := make([]float64, len(x))
+	for i := range x { res[i] = x[i][0] }
+	return res
+}
+
+// resolverTranspuesta resuelve Mᵀ·x = b: como PM = LU, Mᵀ = Uᵀ·Lᵀ·P.
+func (f *factorizacionLU) resolverTranspuesta(b []float64) []float64 {
+	n := len(f.lu)
+	w := make([]float64, n)
+	for i := 0; i < n; i++ {
+		s := b[i]
+		for j := 0; j < i; j++ { s -= f.lu[j][i] * w[j] }
+		w[i] = s / f.lu[i][i]
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ { w[i] -= f.lu[j][i] * w[j] }
+	}
+	x := make([]float64, n)
+	for i := range w { x[f.perm[i]] = w[i] }
+	return x
+}
+
+// avisarCondicion deja un aviso (lo muestra el comando, ver evaluador.Avisar)
+// cuando el resultado puede perder muchos dígitos de precisión.
+func avisarCondicion(nombre string, m [][]float64, lu *factorizacionLU) {
+	if c := estimarCondicion(m, lu); c > limiteCondicion {
+		evaluador.Avisar("en '%s': matriz mal condicionada (condición ≈ %.3g), el resultado puede ser impreciso", nombre, c)
+	}
+}
+
+// --- RANGO (Eliminación gaussiana con tolerancia) ---
+
+func rangoMatriz(m [][]float64) int {
+	a := copiarMatriz(m)
+	filas, cols := len(a), len(a[0])
+	tol := tolSingular * math.Max(1, normaMaxima(a)) * float64(max(filas, cols))
+	rango := 0
+	for c := 0; c < cols && rango < filas; c++ {
+		p := rango
+		for i := rango + 1; i < filas; i++ {
+			if math.Abs(a[i][c]) > math.Abs(a[p][c]) { p = i }
+		}
+		if math.Abs(a[p][c]) <= tol { continue }
+		a[p], a[rango] = a[rango], a[p]
+		for i := rango + 1; i < filas; i++ {
+			factor := a[i][c] / a[rango][c]
+			for j := c; j < cols; j++ { a[i][j] -= factor * a[rango][j] }
+		}
+		rango++
+	}
+	return rango
+}
+
+// --- DESCOMPOSICIÓN QR (Reflexiones de Householder) ---
+
+func descomponerQR(m [][]float64) ([][]float64, [][]float64) {
+	filas, cols := len(m), len(m[0])
+	r := copiarMatriz(m)
+	q := matrizIdentidad(filas)
+
+	for k := 0; k < cols && k < filas-1; k++ {
+		// Vector de Householder para anular r[k+1:][k]
+		var norma float64
+		for i := k; i < filas; i++ { norma += r[i][k] * r[i][k] }
+		norma = math.Sqrt(norma)
+		if norma == 0 { continue }
+
+		alfa := -math.Copysign(norma, r[k][k])
+		v := make([]float64, filas)
+		v[k] = r[k][k] - alfa
+		for i := k + 1; i < filas; i++ { v[i] = r[i][k] }
+		var vv float64
+		for i := k; i < filas; i++ { vv += v[i] * v[i] }
+		if vv == 0 { continue }
+
+		// R = H·R
+		for j := 0; j < cols; j++ {
+			var s float64
+			for i := k; i < filas; i++ { s += v[i] * r[i][j] }
+			s = 2 * s / vv
+			for i := k; i < filas; i++ { r[i][j] -= s * v[i] }
+		}
+		// Q = Q·H
+		for i := 0; i < filas; i++ {
+			var s float64
+			for j := k; j < filas; j++ { s += q[i][j] * v[j] }
+			s = 2 * s / vv
+			for j := k; j < filas; j++ { q[i][j] -= s * v[j] }
+		}
+	}
+
+	// Limpiamos el ruido numérico bajo la diagonal
+	for i := 1; i < filas; i++ {
+		for j := 0; j < i && j < cols; j++ { r[i][j] = 0 }
+	}
+	return q, r
+}
+
+// --- VALORES PROPIOS (Método de Jacobi cíclico) ---
+
+func jacobiSimetrica(m [][]float64) ([]float64, [][]float64, error) {
+	n := len(m)
+	a := copiarMatriz(m)
+	v := matrizIdentidad(n)
+
+	for barrido := 0; barrido < maxIteracionesJac; barrido++ {
+		var fueraDiagonal float64
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ { fueraDiagonal += a[i][j] * a[i][j] }
+		}
+		if math.Sqrt(fueraDiagonal) < 1e-12*math.Max(1, normaMaxima(a)) {
+			valores := ordenarPropios(a, v)
+			normalizarSignos(v)
+			return valores, v, nil
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 { continue }
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for k := 0; k < n; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p] = c*akp - s*akq
+					a[k][q] = s*akp + c*akq
+				}
+				for k := 0; k < n; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k] = c*apk - s*aqk
+					a[q][k] = s*apk + c*aqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p] = c*vkp - s*vkq
+					v[k][q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("❌ ERROR: el método de Jacobi no convergió en %d barridos", maxIteracionesJac)
+}
+
+// ordenarPropios reordena (en el sitio) las columnas de v según los valores propios descendentes.
+func ordenarPropios(a, v [][]float64) []float64 {
+	n := len(a)
+	valores := make([]float64, n)
+	for i := range valores { valores[i] = a[i][i] }
+	for i := 0; i < n; i++ {
+		k := i
+		for j := i + 1; j < n; j++ {
+			if valores[j] > valores[k] { k = j }
+		}
+		if k == i { continue }
+		valores[i], valores[k] = valores[k], valores[i]
+		for f := 0; f < n; f++ { v[f][i], v[f][k] = v[f][k], v[f][i] }
+	}
+	return valores
+}
+
+// normalizarSignos deja positivo el primer componente no nulo de cada vector propio.
+func normalizarSignos(v [][]float64) {
+	n := len(v)
+	for c := 0; c < n; c++ {
+		for f := 0; f < n; f++ {
+			if math.Abs(v[f][c]) < 1e-12 { continue }
+			if v[f][c] < 0 {
+				for g := 0; g < n; g++ { v[g][c] = -v[g][c] }
+			}
+			break
+		}
+	}
+}
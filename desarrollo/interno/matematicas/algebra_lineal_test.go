@@ -0,0 +1,198 @@
+package matematicas
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func matricesCercanas(a, b [][]float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if math.Abs(a[i][j]-b[i][j]) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func transpuesta(m [][]float64) [][]float64 {
+	t := crearMatrizVacia(len(m[0]), len(m))
+	for i := range m {
+		for j := range m[i] {
+			t[j][i] = m[i][j]
+		}
+	}
+	return t
+}
+
+func TestDescomponerLU(t *testing.T) {
+	casos := []struct {
+		nombre string
+		m      [][]float64
+		det    float64
+	}{
+		{"2x2", [][]float64{{4, 3}, {6, 3}}, -6},
+		{"requiere pivoteo", [][]float64{{0, 1}, {1, 0}}, -1},
+		{"3x3", [][]float64{{2, -1, 0}, {-1, 2, -1}, {0, -1, 2}}, 4},
+		{"4x4", [][]float64{{1, 2, 3, 4}, {2, 1, 4, 3}, {3, 4, 1, 2}, {4, 3, 2, 1}}, 0},
+	}
+	for _, c := range casos {
+		lu, err := descomponerLU(c.m)
+		if c.det == 0 {
+			if err == nil {
+				t.Errorf("%s: la matriz es singular y no falló", c.nombre)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", c.nombre, err)
+			continue
+		}
+		if d := lu.determinante(); math.Abs(d-c.det) > 1e-9 {
+			t.Errorf("%s: determinante %v, se esperaba %v", c.nombre, d, c.det)
+		}
+		l, u, p := lu.factores()
+		if !matricesCercanas(multiplicarMatrices(p, c.m), multiplicarMatrices(l, u), 1e-12) {
+			t.Errorf("%s: P·A ≠ L·U", c.nombre)
+		}
+		inv := lu.resolver(matrizIdentidad(len(c.m)))
+		if !matricesCercanas(multiplicarMatrices(c.m, inv), matrizIdentidad(len(c.m)), 1e-12) {
+			t.Errorf("%s: A·A⁻¹ ≠ I", c.nombre)
+		}
+	}
+}
+
+func TestResolverSistemaMalCondicionado(t *testing.T) {
+	evaluador.TomarAvisos()
+	hilbert := crearMatrizVacia(10, 10)
+	for i := range hilbert {
+		for j := range hilbert[i] {
+			hilbert[i][j] = 1 / float64(i+j+1)
+		}
+	}
+	if _, err := invertirMatriz("inversa", hilbert); err != nil {
+		t.Fatal(err)
+	}
+	avisos := evaluador.TomarAvisos()
+	if len(avisos) != 1 || !strings.Contains(avisos[0], "mal condicionada") {
+		t.Errorf("se esperaba un aviso de matriz mal condicionada, hubo %q", avisos)
+	}
+
+	if _, err := invertirMatriz("inversa", [][]float64{{2, 1}, {1, 3}}); err != nil {
+		t.Fatal(err)
+	}
+	if avisos := evaluador.TomarAvisos(); len(avisos) != 0 {
+		t.Errorf("una matriz bien condicionada no debía avisar: %q", avisos)
+	}
+}
+
+func TestDescomponerQR(t *testing.T) {
+	for _, m := range [][][]float64{
+		{{12, -51, 4}, {6, 167, -68}, {-4, 24, -41}},
+		{{1, 2}, {3, 4}, {5, 6}},
+		{{2, 0}, {0, 0}},
+	} {
+		q, r := descomponerQR(m)
+		if !matricesCercanas(multiplicarMatrices(q, r), m, 1e-9) {
+			t.Errorf("%v: Q·R ≠ A", m)
+		}
+		if !matricesCercanas(multiplicarMatrices(transpuesta(q), q), matrizIdentidad(len(q)), 1e-12) {
+			t.Errorf("%v: Q no es ortogonal", m)
+		}
+		for i := 1; i < len(r); i++ {
+			for j := 0; j < i && j < len(r[i]); j++ {
+				if r[i][j] != 0 {
+					t.Errorf("%v: R[%d][%d] = %v bajo la diagonal", m, i, j, r[i][j])
+				}
+			}
+		}
+	}
+}
+
+func TestJacobiSimetrica(t *testing.T) {
+	casos := []struct {
+		m       [][]float64
+		valores []float64
+	}{
+		{[][]float64{{2, 1}, {1, 2}}, []float64{3, 1}},
+		{[][]float64{{2, -1, 0}, {-1, 2, -1}, {0, -1, 2}}, []float64{2 + math.Sqrt2, 2, 2 - math.Sqrt2}},
+		{[][]float64{{5, 0, 0}, {0, -1, 0}, {0, 0, 3}}, []float64{5, 3, -1}},
+	}
+	for _, c := range casos {
+		valores, vectores, err := jacobiSimetrica(c.m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range c.valores {
+			if math.Abs(valores[i]-v) > 1e-10 {
+				t.Errorf("%v: valores propios %v, se esperaba %v", c.m, valores, c.valores)
+				break
+			}
+		}
+		// A·v = λ·v para cada columna
+		av := multiplicarMatrices(c.m, vectores)
+		for j, l := range valores {
+			for i := range c.m {
+				if math.Abs(av[i][j]-l*vectores[i][j]) > 1e-9 {
+					t.Errorf("%v: la columna %d no es un vector propio de %v", c.m, j, l)
+					break
+				}
+			}
+		}
+	}
+}
+
+// La estimación no forma la inversa pero debe dar el orden de magnitud del
+// número de condición exacto.
+func TestEstimarCondicion(t *testing.T) {
+	hilbert := func(n int) [][]float64 {
+		h := crearMatrizVacia(n, n)
+		for i := range h {
+			for j := range h[i] {
+				h[i][j] = 1 / float64(i+j+1)
+			}
+		}
+		return h
+	}
+	for _, m := range [][][]float64{
+		{{2, 1}, {1, 3}},
+		{{0, 2, 1}, {4, -1, 3}, {1, 1, 7}},
+		{{1, 1}, {1, 1 + 1e-10}},
+		hilbert(6),
+		hilbert(10),
+	} {
+		lu, err := descomponerLU(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exacto, estimado := numeroCondicion(m, lu), estimarCondicion(m, lu)
+		if estimado > exacto*(1+1e-6) || estimado < exacto/10 {
+			t.Errorf("%v: condición estimada %.4g, exacta %.4g", m, estimado, exacto)
+		}
+	}
+
+	// Mᵀ·x = b con la misma factorización
+	m := [][]float64{{0, 2, 1}, {4, -1, 3}, {1, 1, 7}}
+	lu, _ := descomponerLU(m)
+	b := []float64{1, -2, 5}
+	x := lu.resolverTranspuesta(b)
+	for j := range m {
+		var s float64
+		for i := range m {
+			s += m[i][j] * x[i]
+		}
+		if math.Abs(s-b[j]) > 1e-12 {
+			t.Errorf("(Mᵀx)[%d] = %v, se esperaba %v", j, s, b[j])
+		}
+	}
+}
@@ -0,0 +1,744 @@
+package matematicas
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// Los métodos numéricos reciben la función a trabajar como valor: una función
+// nativa (seno), una función de usuario (funcion f(real x):) o una lambda.
+
+func validarFuncion(nombre string, v interface{}) (evaluador.Funcion, error) {
+	f, ok := evaluador.ComoFuncion(v)
+	if !ok {
+		return evaluador.Funcion{}, fmt.Errorf("❌ ERROR en '%s': el primer argumento debe ser una función → %v", nombre, v)
+	}
+	return f, nil
+}
+
+// funcionReal adapta f a func(float64) y antepone el nombre del método a los errores.
+func funcionReal(nombre string, f evaluador.Funcion) func(x float64) (float64, error) {
+	return func(x float64) (float64, error) {
+		y, err := f.LlamarReal(x)
+		if err != nil {
+			return 0, fmt.Errorf("❌ ERROR en '%s' al evaluar %s en %v: %w", nombre, f.Etiqueta(), x, err)
+		}
+		return y, nil
+	}
+}
+
+// validarLimite admite números y los textos "infinito" / "-infinito".
+func validarLimite(nombre string, v interface{}) (float64, error) {
+	if s, ok := v.(string); ok {
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "infinito", "+infinito":
+			return math.Inf(1), nil
+		case "-infinito":
+			return math.Inf(-1), nil
+		}
+	}
+	x, err := evaluador.ConvertirAReal(v)
+	if err != nil {
+		return 0, fmt.Errorf("❌ ERROR en '%s': límite inválido → %v", nombre, v)
+	}
+	return x, nil
+}
+
+// --- 1. DERIVADAS (extrapolación de Ridders) ---
+
+// derivadaRidders estima f'(x) con diferencias centrales cada vez más finas
+// extrapoladas al límite h → 0 (Numerical Recipes, dfridr).
+func derivadaRidders(f func(float64) (float64, error), x float64) (float64, error) {
+	const (
+		con    = 1.4
+		con2   = con * con
+		ntab   = 10
+		seguro = 2.0
+	)
+	h := 0.1 * math.Max(1, math.Abs(x))
+	var a [ntab][ntab]float64
+	central := func(h float64) (float64, error) {
+		f1, err := f(x + h); if err != nil { return 0, err }
+		f2, err := f(x - h); if err != nil { return 0, err }
+		return (f1 - f2) / (2 * h), nil
+	}
+
+	var err error
+	a[0][0], err = central(h); if err != nil { return 0, err }
+	mejor, errMin := a[0][0], math.Inf(1)
+	for i := 1; i < ntab; i++ {
+		h /= con
+		a[0][i], err = central(h); if err != nil { return 0, err }
+		fac := con2
+		for j := 1; j <= i; j++ {
+			a[j][i] = (a[j-1][i]*fac - a[j-1][i-1]) / (fac - 1)
+			fac *= con2
+			e := math.Max(math.Abs(a[j][i]-a[j-1][i]), math.Abs(a[j][i]-a[j-1][i-1]))
+			if e <= errMin {
+				errMin, mejor = e, a[j][i]
+			}
+		}
+		// Si el error crece, seguir refinando solo acumula redondeo
+		if math.Abs(a[i][i]-a[i-1][i-1]) >= seguro*errMin {
+			break
+		}
+	}
+	return mejor, nil
+}
+
+// --- 2. INTEGRALES (Gauss-Kronrod 7-15 adaptativo) ---
+
+var (
+	nodosKronrod = [8]float64{
+		0.991455371120812639206854697526329, 0.949107912342758524526189684047851,
+		0.864864423359769072789712788640926, 0.741531185599394439863864773280788,
+		0.586087235467691130294144845693013, 0.405845151377397166906606412076961,
+		0.207784955007898467600689403773245, 0,
+	}
+	pesosKronrod = [8]float64{
+		0.022935322010529224963732008058970, 0.063092092629978553290700663189204,
+		0.104790010322250183839876322541518, 0.140653259715525918745189590510238,
+		0.169004726639267902826583426598550, 0.190350578064785409913256402421014,
+		0.204432940075298892414161999234649, 0.209482141084727828012999174891714,
+	}
+	// Pesos de Gauss de 7 puntos sobre los nodos impares de Kronrod
+	pesosGauss = [4]float64{
+		0.129484966168869693270611432679082, 0.279705391489276667901467771423780,
+		0.381830050505118944950369775488975, 0.417959183673469387755102040816327,
+	}
+)
+
+// gaussKronrod15 integra en [a, b] y estima el error como |K15 - G7|.
+func gaussKronrod15(f func(float64) (float64, error), a, b float64) (float64, float64, error) {
+	c, h := (a+b)/2, (b-a)/2
+	fc, err := f(c); if err != nil { return 0, 0, err }
+	kronrod, gauss := fc*pesosKronrod[7], fc*pesosGauss[3]
+	for j := 0; j < 7; j++ {
+		dx := h * nodosKronrod[j]
+		f1, err := f(c - dx); if err != nil { return 0, 0, err }
+		f2, err := f(c + dx); if err != nil { return 0, 0, err }
+		kronrod += pesosKronrod[j] * (f1 + f2)
+		if j%2 == 1 {
+			gauss += pesosGauss[j/2] * (f1 + f2)
+		}
+	}
+	return kronrod * h, math.Abs(kronrod-gauss) * h, nil
+}
+
+// maxEvaluacionesIntegral limita el trabajo de integrar: con un integrando
+// que oscila o no converge, el refinamiento se corta ahí y se devuelve la
+// mejor estimación con un aviso.
+const maxEvaluacionesIntegral = 200_000
+
+// presupuestoIntegral lleva las evaluaciones que quedan y el error de los
+// tramos que ya no se pudieron refinar.
+type presupuestoIntegral struct {
+	restantes int
+	agotado   bool
+	errEst    float64
+}
+
+func integrarAdaptativo(f func(float64) (float64, error), a, b, tol float64, profundidad int, p *presupuestoIntegral) (float64, error) {
+	p.restantes -= 15
+	res, errEst, err := gaussKronrod15(f, a, b); if err != nil { return 0, err }
+	if math.IsNaN(res) || math.IsInf(res, 0) {
+		return 0, fmt.Errorf("la función no es integrable en [%v, %v]", a, b)
+	}
+	if errEst <= math.Max(tol, 1e-15*math.Abs(res)) {
+		return res, nil
+	}
+	if profundidad == 0 {
+		return 0, fmt.Errorf("la integral no converge cerca de [%v, %v]", a, b)
+	}
+	if p.restantes < 2*15 {
+		p.agotado = true
+		p.errEst += errEst
+		return res, nil
+	}
+	m := (a + b) / 2
+	izq, err := integrarAdaptativo(f, a, m, tol/2, profundidad-1, p); if err != nil { return 0, err }
+	der, err := integrarAdaptativo(f, m, b, tol/2, profundidad-1, p); if err != nil { return 0, err }
+	return izq + der, nil
+}
+
+// integral resuelve límites infinitos con x = t/(1-t²) sobre (-1, 1) o
+// x = a + t/(1-t) sobre [0, 1).
+func integral(f func(float64) (float64, error), a, b, tol float64) (float64, error) {
+	const profundidad = 50
+	if a == b {
+		return 0, nil
+	}
+	if a > b {
+		r, err := integral(f, b, a, tol)
+		return -r, err
+	}
+	g, desde, hasta := f, a, b
+	switch {
+	case math.IsInf(a, -1) && math.IsInf(b, 1):
+		g = func(t float64) (float64, error) {
+			d := 1 - t*t
+			y, err := f(t / d); if err != nil { return 0, err }
+			return y * (1 + t*t) / (d * d), nil
+		}
+		desde, hasta = -1, 1
+	case math.IsInf(b, 1):
+		g = func(t float64) (float64, error) {
+			y, err := f(a + t/(1-t)); if err != nil { return 0, err }
+			return y / ((1 - t) * (1 - t)), nil
+		}
+		desde, hasta = 0, 1
+	case math.IsInf(a, -1):
+		g = func(t float64) (float64, error) {
+			y, err := f(b - t/(1-t)); if err != nil { return 0, err }
+			return y / ((1 - t) * (1 - t)), nil
+		}
+		desde, hasta = 0, 1
+	}
+	p := &presupuestoIntegral{restantes: maxEvaluacionesIntegral}
+	res, err := integrarAdaptativo(g, desde, hasta, tol, profundidad, p)
+	if err == nil && p.agotado {
+		evaluador.Avisar("en 'integrar': se alcanzó el límite de %d evaluaciones sin llegar a la tolerancia; el resultado es una estimación (error ≈ %.3g)", maxEvaluacionesIntegral, p.errEst)
+	}
+	return res, err
+}
+
+// --- 3. RAÍCES (bisección, Brent, Newton) ---
+
+const maxIteraciones = 200
+
+func raizBiseccion(f func(float64) (float64, error), a, b float64) (float64, error) {
+	fa, err := f(a); if err != nil { return 0, err }
+	for i := 0; i < maxIteraciones; i++ {
+		m := a + (b-a)/2
+		fm, err := f(m); if err != nil { return 0, err }
+		if fm == 0 || math.Abs(b-a) <= 4e-16*math.Max(1, math.Abs(m)) {
+			return m, nil
+		}
+		if (fm < 0) == (fa < 0) {
+			a, fa = m, fm
+		} else {
+			b = m
+		}
+	}
+	return a + (b-a)/2, nil
+}
+
+// raizBrent combina bisección, secante e interpolación cuadrática inversa
+// (algoritmo zeroin de Brent): siempre converge y suele hacerlo rápido.
+func raizBrent(f func(float64) (float64, error), a, b float64) (float64, error) {
+	fa, err := f(a); if err != nil { return 0, err }
+	fb, err := f(b); if err != nil { return 0, err }
+	c, fc := a, fa
+	d := b - a
+	e := d
+	for i := 0; i < maxIteraciones; i++ {
+		if (fb > 0) == (fc > 0) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol := 2*2.2e-16*math.Abs(b) + 1e-15
+		m := (c - b) / 2
+		if math.Abs(m) <= tol || fb == 0 {
+			return b, nil
+		}
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p, q = 2*m*s, 1-s
+			} else {
+				qa, r := fa/fc, fb/fc
+				p = s * (2*m*qa*(qa-r) - (b-a)*(r-1))
+				q = (qa - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*m*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e, d = d, p/q
+			} else {
+				d, e = m, m
+			}
+		} else {
+			d, e = m, m
+		}
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else {
+			b += math.Copysign(tol, m)
+		}
+		fb, err = f(b); if err != nil { return 0, err }
+	}
+	return b, nil
+}
+
+func raizNewton(f func(float64) (float64, error), x float64) (float64, error) {
+	for i := 0; i < maxIteraciones; i++ {
+		fx, err := f(x); if err != nil { return 0, err }
+		if fx == 0 {
+			return x, nil
+		}
+		h := 1e-7 * math.Max(1, math.Abs(x))
+		f1, err := f(x + h); if err != nil { return 0, err }
+		f2, err := f(x - h); if err != nil { return 0, err }
+		derivada := (f1 - f2) / (2 * h)
+		if derivada == 0 || math.IsNaN(derivada) {
+			return 0, fmt.Errorf("derivada nula en x = %v; pruebe otro punto inicial o un intervalo", x)
+		}
+		paso := fx / derivada
+		x -= paso
+		if math.Abs(paso) <= 1e-14*math.Max(1, math.Abs(x)) {
+			return x, nil
+		}
+	}
+	return 0, fmt.Errorf("Newton no converge en %d iteraciones", maxIteraciones)
+}
+
+// --- 4. MINIMIZACIÓN (sección áurea en 1D, Nelder-Mead en varias variables) ---
+
+const razonAurea = 0.3819660112501051 // 2 - φ
+
+func minimizar1D(f func(float64) (float64, error), x0, paso float64) (float64, error) {
+	// Acotar el mínimo avanzando cuesta abajo con pasos crecientes
+	a, b := x0, x0+paso
+	fa, err := f(a); if err != nil { return 0, err }
+	fb, err := f(b); if err != nil { return 0, err }
+	if fb > fa {
+		a, b, fa, fb = b, a, fb, fa
+	}
+	c := b + 1.618034*(b-a)
+	fc, err := f(c); if err != nil { return 0, err }
+	for i := 0; fb > fc; i++ {
+		if i == maxIteraciones || math.IsInf(c, 0) {
+			return 0, fmt.Errorf("la función no tiene mínimo acotado (decrece sin límite)")
+		}
+		a, b, fa, fb = b, c, fb, fc
+		c = b + 1.618034*(b-a)
+		fc, err = f(c); if err != nil { return 0, err }
+	}
+	if a > c {
+		a, c = c, a
+	}
+
+	// Sección áurea dentro de [a, c] con b como punto interior
+	x := b
+	fx := fb
+	for i := 0; i < maxIteraciones && math.Abs(c-a) > 1e-10*math.Max(1, math.Abs(x)); i++ {
+		var u float64
+		if x-a > c-x {
+			u = x - razonAurea*(x-a)
+		} else {
+			u = x + razonAurea*(c-x)
+		}
+		fu, err := f(u); if err != nil { return 0, err }
+		if fu < fx {
+			if u < x {
+				c = x
+			} else {
+				a = x
+			}
+			x, fx = u, fu
+		} else if u < x {
+			a = u
+		} else {
+			c = u
+		}
+	}
+	return x, nil
+}
+
+func nelderMead(f func([]float64) (float64, error), x0 []float64, paso float64) ([]float64, error) {
+	n := len(x0)
+	simplex := make([][]float64, n+1)
+	valores := make([]float64, n+1)
+	for i := range simplex {
+		simplex[i] = append([]float64(nil), x0...)
+		if i > 0 {
+			simplex[i][i-1] += paso
+		}
+		v, err := f(simplex[i]); if err != nil { return nil, err }
+		valores[i] = v
+	}
+	punto := func(centro, hacia []float64, t float64) []float64 {
+		p := make([]float64, n)
+		for j := range p {
+			p[j] = centro[j] + t*(hacia[j]-centro[j])
+		}
+		return p
+	}
+
+	for iter := 0; iter < 500*n; iter++ {
+		// Ordenar de mejor a peor
+		for i := 1; i <= n; i++ {
+			for j := i; j > 0 && valores[j] < valores[j-1]; j-- {
+				valores[j], valores[j-1] = valores[j-1], valores[j]
+				simplex[j], simplex[j-1] = simplex[j-1], simplex[j]
+			}
+		}
+		if math.Abs(valores[n]-valores[0]) <= 1e-12*(math.Abs(valores[0])+1e-12) {
+			break
+		}
+		centroide := make([]float64, n)
+		for _, p := range simplex[:n] {
+			for j := range centroide {
+				centroide[j] += p[j] / float64(n)
+			}
+		}
+		reflejado := punto(centroide, simplex[n], -1)
+		fr, err := f(reflejado); if err != nil { return nil, err }
+		switch {
+		case fr < valores[0]:
+			expandido := punto(centroide, simplex[n], -2)
+			fe, err := f(expandido); if err != nil { return nil, err }
+			if fe < fr {
+				simplex[n], valores[n] = expandido, fe
+			} else {
+				simplex[n], valores[n] = reflejado, fr
+			}
+		case fr < valores[n-1]:
+			simplex[n], valores[n] = reflejado, fr
+		default:
+			contraido := punto(centroide, simplex[n], 0.5)
+			fc, err := f(contraido); if err != nil { return nil, err }
+			if fc < valores[n] {
+				simplex[n], valores[n] = contraido, fc
+				continue
+			}
+			// Encoger todo el simplex hacia el mejor punto
+			for i := 1; i <= n; i++ {
+				simplex[i] = punto(simplex[0], simplex[i], 0.5)
+				valores[i], err = f(simplex[i]); if err != nil { return nil, err }
+			}
+		}
+	}
+	return simplex[0], nil
+}
+
+// --- 5. ECUACIONES DIFERENCIALES (RK4 y Dormand-Prince RK45) ---
+
+// derivadaEDO evalúa f(t, y) para una ecuación (y real) o un sistema (y lista).
+func derivadaEDO(nombre string, f evaluador.Funcion, sistema bool) func(t float64, y []float64) ([]float64, error) {
+	return func(t float64, y []float64) ([]float64, error) {
+		var arg interface{} = y[0]
+		if sistema {
+			lista := make([]interface{}, len(y))
+			for i, v := range y {
+				lista[i] = v
+			}
+			arg = lista
+		}
+		res, err := f.Llamar(t, arg)
+		if err != nil {
+			return nil, fmt.Errorf("❌ ERROR en '%s' al evaluar %s en t = %v: %w", nombre, f.Etiqueta(), t, err)
+		}
+		if !sistema {
+			v, err := evaluador.ConvertirAReal(res); if err != nil { return nil, err }
+			return []float64{v}, nil
+		}
+		dy, err := evaluador.ConvertirAListaReal(res); if err != nil { return nil, err }
+		if len(dy) != len(y) {
+			return nil, fmt.Errorf("❌ ERROR en '%s': la función devolvió %d derivadas para %d ecuaciones", nombre, len(dy), len(y))
+		}
+		return dy, nil
+	}
+}
+
+// combinar devuelve y + h·Σ cᵢ·kᵢ.
+func combinar(y []float64, h float64, coef []float64, ks [][]float64) []float64 {
+	res := append([]float64(nil), y...)
+	for i, c := range coef {
+		if c == 0 {
+			continue
+		}
+		for j := range res {
+			res[j] += h * c * ks[i][j]
+		}
+	}
+	return res
+}
+
+func pasoRK4(f func(float64, []float64) ([]float64, error), t float64, y []float64, h float64) ([]float64, error) {
+	k1, err := f(t, y); if err != nil { return nil, err }
+	k2, err := f(t+h/2, combinar(y, h, []float64{0.5}, [][]float64{k1})); if err != nil { return nil, err }
+	k3, err := f(t+h/2, combinar(y, h, []float64{0.5}, [][]float64{k2})); if err != nil { return nil, err }
+	k4, err := f(t+h, combinar(y, h, []float64{1}, [][]float64{k3})); if err != nil { return nil, err }
+	return combinar(y, h, []float64{1.0 / 6, 1.0 / 3, 1.0 / 3, 1.0 / 6}, [][]float64{k1, k2, k3, k4}), nil
+}
+
+// Tabla de Butcher de Dormand-Prince 5(4)
+var (
+	dpC = [7]float64{0, 1.0 / 5, 3.0 / 10, 4.0 / 5, 8.0 / 9, 1, 1}
+	dpA = [7][]float64{
+		{},
+		{1.0 / 5},
+		{3.0 / 40, 9.0 / 40},
+		{44.0 / 45, -56.0 / 15, 32.0 / 9},
+		{19372.0 / 6561, -25360.0 / 2187, 64448.0 / 6561, -212.0 / 729},
+		{9017.0 / 3168, -355.0 / 33, 46732.0 / 5247, 49.0 / 176, -5103.0 / 18656},
+		{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84},
+	}
+	dpB5 = []float64{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84, 0}
+	dpB4 = []float64{5179.0 / 57600, 0, 7571.0 / 16695, 393.0 / 640, -92097.0 / 339200, 187.0 / 2100, 1.0 / 40}
+)
+
+// pasoRK45 da un paso de orden 5 y la norma del error respecto al de orden 4.
+func pasoRK45(f func(float64, []float64) ([]float64, error), t float64, y []float64, h, tol float64) ([]float64, float64, error) {
+	ks := make([][]float64, 7)
+	for i := range ks {
+		k, err := f(t+dpC[i]*h, combinar(y, h, dpA[i], ks[:i])); if err != nil { return nil, 0, err }
+		ks[i] = k
+	}
+	y5 := combinar(y, h, dpB5, ks)
+	y4 := combinar(y, h, dpB4, ks)
+	errNorma := 0.0
+	for j := range y5 {
+		escala := tol * (1 + math.Max(math.Abs(y[j]), math.Abs(y5[j])))
+		errNorma = math.Max(errNorma, math.Abs(y5[j]-y4[j])/escala)
+	}
+	return y5, errNorma, nil
+}
+
+// puntoEDO arma el par [t, y] que se devuelve en la trayectoria.
+func puntoEDO(t float64, y []float64, sistema bool) interface{} {
+	if !sistema {
+		return []interface{}{t, y[0]}
+	}
+	lista := make([]interface{}, len(y))
+	for i, v := range y {
+		lista[i] = v
+	}
+	return []interface{}{t, lista}
+}
+
+func resolverEDO(nombre string, f evaluador.Funcion, y0 []float64, sistema bool, t0, t1, paso, tol float64, metodo string) ([]interface{}, error) {
+	g := derivadaEDO(nombre, f, sistema)
+	trayectoria := []interface{}{puntoEDO(t0, y0, sistema)}
+	y, t := y0, t0
+
+	switch metodo {
+	case "rk4":
+		n := int(math.Ceil(math.Abs(t1-t0)/paso - 1e-9))
+		if n > 1_000_000 {
+			return nil, fmt.Errorf("❌ ERROR en '%s': demasiados pasos (%d); aumente el paso", nombre, n)
+		}
+		h := (t1 - t0) / float64(n)
+		for i := 1; i <= n; i++ {
+			var err error
+			y, err = pasoRK4(g, t, y, h); if err != nil { return nil, err }
+			t = t0 + float64(i)*h
+			trayectoria = append(trayectoria, puntoEDO(t, y, sistema))
+		}
+	case "rk45":
+		h := math.Copysign(paso, t1-t0)
+		for pasos := 0; (t1-t)*h > 0; pasos++ {
+			if pasos == 100_000 || math.Abs(h) < 1e-14*math.Max(1, math.Abs(t)) {
+				return nil, fmt.Errorf("❌ ERROR en '%s': el paso se volvió demasiado pequeño en t = %v (¿ecuación rígida o singular?)", nombre, t)
+			}
+			if (t+h-t1)*h > 0 {
+				h = t1 - t
+			}
+			yNuevo, e, err := pasoRK45(g, t, y, h, tol); if err != nil { return nil, err }
+			if e <= 1 {
+				t, y = t+h, yNuevo
+				trayectoria = append(trayectoria, puntoEDO(t, y, sistema))
+			}
+			// Ajuste del paso con factor de seguridad 0.9 y límites [0.2, 5]
+			factor := 5.0
+			if e > 0 {
+				factor = math.Min(5, math.Max(0.2, 0.9*math.Pow(e, -0.2)))
+			}
+			h *= factor
+		}
+	default:
+		return nil, fmt.Errorf("❌ ERROR en '%s': método desconocido '%s' (use \"rk4\" o \"rk45\")", nombre, metodo)
+	}
+	return trayectoria, nil
+}
+
+func inyectarCalculoGlobal() {
+
+	// --- 1. DERIVADAS ---
+
+	// derivar(f, x, [orden]) -> f'(x); derivar(seno, 0) = 1, derivar(f, 2, 2) = f''(2)
+	evaluador.Funciones["derivar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 {
+			return nil, fmt.Errorf("❌ ERROR: 'derivar' requiere: función, x y [orden]")
+		}
+		f, err := validarFuncion("derivar", args[0]); if err != nil { return nil, err }
+		x, err := validar1("derivar", args[1:2]); if err != nil { return nil, err }
+		orden := 1.0
+		if len(args) == 3 {
+			orden, err = validar1("derivar", args[2:]); if err != nil { return nil, err }
+		}
+		if orden != math.Trunc(orden) || orden < 1 || orden > 3 {
+			return nil, fmt.Errorf("❌ ERROR en 'derivar': el orden debe ser 1, 2 o 3")
+		}
+		// Las derivadas de orden superior se anidan: f'' = (f')'
+		g := funcionReal("derivar", f)
+		for i := 1; i < int(orden); i++ {
+			anterior := g
+			g = func(x float64) (float64, error) { return derivadaRidders(anterior, x) }
+		}
+		res, err := derivadaRidders(g, x); if err != nil { return nil, err }
+		return finalizar("derivar", res)
+	}
+
+	// --- 2. INTEGRALES ---
+
+	// integrar(f, a, b, [tolerancia]) -> ∫ f(x) dx en [a, b]; admite "infinito"
+	evaluador.Funciones["integrar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 3 || len(args) > 4 {
+			return nil, fmt.Errorf("❌ ERROR: 'integrar' requiere: función, a, b y [tolerancia]")
+		}
+		f, err := validarFuncion("integrar", args[0]); if err != nil { return nil, err }
+		a, err := validarLimite("integrar", args[1]); if err != nil { return nil, err }
+		b, err := validarLimite("integrar", args[2]); if err != nil { return nil, err }
+		tol := 1e-10
+		if len(args) == 4 {
+			tol, err = validar1("integrar", args[3:]); if err != nil { return nil, err }
+			if tol <= 0 { return nil, fmt.Errorf("❌ ERROR en 'integrar': la tolerancia debe ser positiva") }
+		}
+		res, err := integral(funcionReal("integrar", f), a, b, tol)
+		if err != nil { return nil, fmt.Errorf("❌ ERROR en 'integrar': %w", err) }
+		return finalizar("integrar", res)
+	}
+
+	// --- 3. RAÍCES ---
+
+	// raiz_de(f, a, b, [metodo]) -> x con f(x) = 0 en [a, b] ("brent" por defecto o "biseccion")
+	// raiz_de(f, x0) -> Newton desde x0
+	evaluador.Funciones["raiz_de"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 4 {
+			return nil, fmt.Errorf("❌ ERROR: 'raiz_de' requiere: función, a, [b] y [metodo]")
+		}
+		f, err := validarFuncion("raiz_de", args[0]); if err != nil { return nil, err }
+		puntos := args[1:]
+		metodo := ""
+		if m, ok := puntos[len(puntos)-1].(string); ok {
+			metodo = strings.ToLower(strings.TrimSpace(m))
+			puntos = puntos[:len(puntos)-1]
+		}
+		valores, err := validarN("raiz_de", puntos); if err != nil { return nil, err }
+		if len(valores) < 1 || len(valores) > 2 {
+			return nil, fmt.Errorf("❌ ERROR en 'raiz_de': indique un punto inicial o un intervalo [a, b]")
+		}
+		if metodo == "" {
+			metodo = "brent"
+			if len(valores) == 1 {
+				metodo = "newton"
+			}
+		}
+		g := funcionReal("raiz_de", f)
+
+		var res float64
+		switch metodo {
+		case "newton":
+			x0 := valores[0]
+			if len(valores) == 2 {
+				x0 = (valores[0] + valores[1]) / 2
+			}
+			res, err = raizNewton(g, x0)
+		case "brent", "biseccion":
+			if len(valores) != 2 {
+				return nil, fmt.Errorf("❌ ERROR en 'raiz_de': el método '%s' requiere un intervalo [a, b]", metodo)
+			}
+			a, b := valores[0], valores[1]
+			fa, errA := g(a); if errA != nil { return nil, errA }
+			fb, errB := g(b); if errB != nil { return nil, errB }
+			if fa == 0 { return a, nil }
+			if fb == 0 { return b, nil }
+			if (fa < 0) == (fb < 0) {
+				return nil, fmt.Errorf("❌ ERROR en 'raiz_de': f(a) y f(b) deben tener signos opuestos (f(%v) = %v, f(%v) = %v)", a, fa, b, fb)
+			}
+			if metodo == "brent" {
+				res, err = raizBrent(g, a, b)
+			} else {
+				res, err = raizBiseccion(g, a, b)
+			}
+		default:
+			return nil, fmt.Errorf("❌ ERROR en 'raiz_de': método desconocido '%s' (use \"brent\", \"biseccion\" o \"newton\")", metodo)
+		}
+		if err != nil { return nil, fmt.Errorf("❌ ERROR en 'raiz_de': %w", err) }
+		return finalizar("raiz_de", res)
+	}
+
+	// --- 4. MINIMIZACIÓN ---
+
+	// minimizar(f, x0, [paso]) -> x que minimiza f cerca de x0
+	// Con x0 lista ([1, 2]) minimiza f(x, y, ...) y devuelve la lista de coordenadas.
+	evaluador.Funciones["minimizar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 {
+			return nil, fmt.Errorf("❌ ERROR: 'minimizar' requiere: función, x0 y [paso]")
+		}
+		f, err := validarFuncion("minimizar", args[0]); if err != nil { return nil, err }
+		paso := 0.1
+		if len(args) == 3 {
+			paso, err = validar1("minimizar", args[2:]); if err != nil { return nil, err }
+			if paso == 0 { return nil, fmt.Errorf("❌ ERROR en 'minimizar': el paso no puede ser 0") }
+		}
+
+		if _, esLista := args[1].([]interface{}); !esLista {
+			x0, err := validar1("minimizar", args[1:2]); if err != nil { return nil, err }
+			res, err := minimizar1D(funcionReal("minimizar", f), x0, paso)
+			if err != nil { return nil, fmt.Errorf("❌ ERROR en 'minimizar': %w", err) }
+			return finalizar("minimizar", res)
+		}
+
+		x0, err := evaluador.ConvertirAListaReal(args[1]); if err != nil { return nil, err }
+		if len(x0) == 0 { return nil, fmt.Errorf("❌ ERROR en 'minimizar': el punto inicial está vacío") }
+		g := func(xs []float64) (float64, error) {
+			y, err := f.LlamarReal(xs...)
+			if err != nil { return 0, fmt.Errorf("❌ ERROR en 'minimizar' al evaluar %s en %v: %w", f.Etiqueta(), xs, err) }
+			return y, nil
+		}
+		res, err := nelderMead(g, x0, paso); if err != nil { return nil, err }
+		salida := make([]interface{}, len(res))
+		for i, v := range res {
+			salida[i] = v
+		}
+		return salida, nil
+	}
+
+	// --- 5. ECUACIONES DIFERENCIALES ---
+
+	// resolver_edo(f, y0, t0, t1, paso, [metodo]) -> [[t0, y0], [t1, y1], ...]
+	// Resuelve y' = f(t, y). Con y0 lista resuelve un sistema: f devuelve la lista de derivadas.
+	// metodo: "rk4" (paso fijo, por defecto) o "rk45" (paso adaptativo, 'paso' es el inicial).
+	evaluador.Funciones["resolver_edo"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 5 || len(args) > 6 {
+			return nil, fmt.Errorf("❌ ERROR: 'resolver_edo' requiere: función, y0, t0, t1, paso y [metodo]")
+		}
+		f, err := validarFuncion("resolver_edo", args[0]); if err != nil { return nil, err }
+		_, sistema := args[1].([]interface{})
+		var y0 []float64
+		if sistema {
+			y0, err = evaluador.ConvertirAListaReal(args[1]); if err != nil { return nil, err }
+			if len(y0) == 0 { return nil, fmt.Errorf("❌ ERROR en 'resolver_edo': y0 está vacío") }
+		} else {
+			v, err := validar1("resolver_edo", args[1:2]); if err != nil { return nil, err }
+			y0 = []float64{v}
+		}
+		t0, t1, paso, err := validar3("resolver_edo", args[2:5]); if err != nil { return nil, err }
+		if paso <= 0 { return nil, fmt.Errorf("❌ ERROR en 'resolver_edo': el paso debe ser positivo") }
+		metodo := "rk4"
+		if len(args) == 6 {
+			m, ok := args[5].(string)
+			if !ok { return nil, fmt.Errorf("❌ ERROR en 'resolver_edo': el método debe ser texto") }
+			metodo = strings.ToLower(strings.TrimSpace(m))
+		}
+		if t0 == t1 {
+			return []interface{}{puntoEDO(t0, y0, sistema)}, nil
+		}
+		return resolverEDO("resolver_edo", f, y0, sistema, t0, t1, paso, 1e-8, metodo)
+	}
+}
@@ -0,0 +1,94 @@
+package matematicas
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// registrar deja una función de prueba disponible por nombre mientras dure el test.
+func registrar(t *testing.T, nombre string, f func(args ...interface{}) (interface{}, error)) {
+	t.Helper()
+	evaluador.Funciones[nombre] = f
+	t.Cleanup(func() { delete(evaluador.Funciones, nombre) })
+}
+
+func TestCalculoNumerico(t *testing.T) {
+	real := func(v interface{}) float64 { f, _ := evaluador.ConvertirAReal(v); return f }
+	registrar(t, "f_prueba", func(args ...interface{}) (interface{}, error) {
+		x := real(args[0])
+		return x*x - 2, nil
+	})
+	registrar(t, "rosen_prueba", func(args ...interface{}) (interface{}, error) {
+		x, y := real(args[0]), real(args[1])
+		return (1-x)*(1-x) + 100*(y-x*x)*(y-x*x), nil
+	})
+	registrar(t, "decae_prueba", func(args ...interface{}) (interface{}, error) {
+		return -0.5 * real(args[1]), nil
+	})
+
+	ctx := evaluador.PrepararContextoEvaluador()
+	ctx.Variables["p"] = []interface{}{-1.0, 1.0}
+	casos := []struct {
+		expresion string
+		esperado  float64
+		tol       float64
+	}{
+		{"derivar(seno, 0)", 1, 1e-9},
+		{`derivar("exp", 1)`, math.E, 1e-9},
+		{"derivar(seno, 0, 2)", 0, 1e-6},
+		{"derivar(f_prueba, 3)", 6, 1e-9},
+		{"derivar(f_prueba, 3, 2)", 2, 1e-6},
+		{"integrar(seno, 0, 3.141592653589793)", 2, 1e-9},
+		{"integrar(f_prueba, 0, 3)", 3, 1e-9},
+		{"raiz_de(f_prueba, 0, 2)", math.Sqrt2, 1e-12},
+		{`raiz_de(f_prueba, 0, 2, "biseccion")`, math.Sqrt2, 1e-12},
+		{"raiz_de(f_prueba, 1)", math.Sqrt2, 1e-12},
+		{"raiz_de(coseno, 1, 2)", math.Pi / 2, 1e-12},
+		{"minimizar(coseno, 3)", math.Pi, 1e-6},
+	}
+	for _, c := range casos {
+		r, err := evaluador.EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if v := real(r); math.Abs(v-c.esperado) > c.tol {
+			t.Errorf("%s = %v, se esperaba %v", c.expresion, v, c.esperado)
+		}
+	}
+
+	// Rosenbrock: mínimo en (1, 1)
+	r, err := evaluador.EvalConContexto("minimizar(rosen_prueba, p)", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range r.([]interface{}) {
+		if math.Abs(real(v)-1) > 1e-6 {
+			t.Errorf("minimizar(rosen)[%d] = %v, se esperaba 1", i, v)
+		}
+	}
+
+	// y' = -y/2 con y(0) = 1: y(1) = e^(-1/2)
+	r, err = evaluador.EvalConContexto("resolver_edo(decae_prueba, 1, 0, 1, 0.5)", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	puntos := r.([]interface{})
+	ultimo := puntos[len(puntos)-1].([]interface{})
+	if len(puntos) != 3 || real(ultimo[0]) != 1 || math.Abs(real(ultimo[1])-math.Exp(-0.5)) > 1e-4 {
+		t.Errorf("resolver_edo = %v", evaluador.FormatearValor(r))
+	}
+
+	for expresion, mensaje := range map[string]string{
+		"raiz_de(f_prueba, 3, 4)": "signos opuestos",
+		"derivar(5, 1)":           "debe ser una función",
+		"integrar(f_prueba, 0)":   "requiere",
+	} {
+		if _, err := evaluador.EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package matematicas
+
+import (
+	"fmt"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// magnitudes lee argumentos físicos en unidades SI: cada uno puede ser una
+// cantidad de la dimensión esperada (se convierte) o un número (ya en SI).
+func magnitudes(nombre string, args []interface{}, unidadesSI ...string) ([]float64, error) {
+	if len(args) != len(unidadesSI) {
+		return nil, fmt.Errorf("❌ ERROR: '%s' requiere %d argumentos", nombre, len(unidadesSI))
+	}
+	res := make([]float64, len(args))
+	for i, a := range args {
+		if c, ok := a.(evaluador.Cantidad); ok {
+			si, err := c.A(unidadesSI[i])
+			if err != nil {
+				return nil, fmt.Errorf("❌ ERROR en '%s' (argumento %d): %w", nombre, i+1, err)
+			}
+			res[i] = si.Valor
+			continue
+		}
+		v, err := evaluador.ConvertirAReal(a)
+		if err != nil {
+			return nil, fmt.Errorf("❌ ERROR en '%s' (argumento %d): se esperaba un número o una cantidad en %s", nombre, i+1, unidadesSI[i])
+		}
+		res[i] = v
+	}
+	return res, nil
+}
+
+// finalizarCantidad valida el resultado y lo devuelve con su unidad si algún
+// argumento traía unidades; con solo números devuelve un número (en SI).
+func finalizarCantidad(nombre string, args []interface{}, valor float64, unidad string) (interface{}, error) {
+	if _, err := finalizar(nombre, valor); err != nil {
+		return nil, err
+	}
+	if !algunaCantidad(args) {
+		return valor, nil
+	}
+	c, err := evaluador.NuevaCantidad(valor, unidad)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func algunaCantidad(args []interface{}) bool {
+	for _, a := range args {
+		if _, ok := a.(evaluador.Cantidad); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func validarCantidad(nombre string, v interface{}) (evaluador.Cantidad, error) {
+	c, ok := v.(evaluador.Cantidad)
+	if !ok {
+		return evaluador.Cantidad{}, fmt.Errorf("❌ ERROR en '%s': se esperaba una cantidad con unidad, recibiste %v", nombre, v)
+	}
+	return c, nil
+}
+
+func inyectarCantidadesGlobal() {
+
+	// --- 1. CREACIÓN Y CONVERSIÓN ---
+
+	// cantidad(9.8, "m/s^2") -> magnitud con unidad (equivale a escribir 9.8 m/s^2)
+	evaluador.Funciones["cantidad"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'cantidad' requiere: valor y \"unidad\"") }
+		v, err := evaluador.ConvertirAReal(args[0]); if err != nil { return nil, err }
+		unidad, ok := args[1].(string)
+		if !ok { return nil, fmt.Errorf("❌ ERROR en 'cantidad': la unidad debe ser texto, p. ej. \"km/h\"") }
+		c, err := evaluador.NuevaCantidad(v, unidad); if err != nil { return nil, err }
+		return c, nil
+	}
+
+	// convertir_unidad(q, "km/h") o convertir_unidad(valor, "mi", "km") -> la misma cantidad en otra unidad compatible
+	evaluador.Funciones["convertir_unidad"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 3 {
+			c, err := evaluador.Funciones["cantidad"](args[0], args[1]); if err != nil { return nil, err }
+			args = []interface{}{c, args[2]}
+		}
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'convertir_unidad' requiere: cantidad y \"unidad\", o valor, \"origen\" y \"destino\"") }
+		c, err := validarCantidad("convertir_unidad", args[0]); if err != nil { return nil, err }
+		convertida, err := c.A(fmt.Sprint(args[1])); if err != nil { return nil, err }
+		return convertida, nil
+	}
+
+	// --- 2. MÉTODOS: v.a("km/h"), v.valor(), v.unidad() ---
+	evaluador.Funciones["cantidad.a"] = evaluador.Funciones["convertir_unidad"]
+	evaluador.Funciones["cantidad.valor"] = func(args ...interface{}) (interface{}, error) {
+		return args[0].(evaluador.Cantidad).Valor, nil
+	}
+	evaluador.Funciones["cantidad.unidad"] = func(args ...interface{}) (interface{}, error) {
+		return args[0].(evaluador.Cantidad).Unidad(), nil
+	}
+	evaluador.Funciones["cantidad.dimension"] = func(args ...interface{}) (interface{}, error) {
+		return args[0].(evaluador.Cantidad).Dimension().String(), nil
+	}
+	evaluador.Funciones["cantidad.si"] = func(args ...interface{}) (interface{}, error) {
+		return args[0].(evaluador.Cantidad).EnSI(), nil
+	}
+
+	// --- 3. FUNCIONES QUE CONSERVAN LA UNIDAD ---
+
+	// raiz(16 m^2) = 4 m
+	raizReal := evaluador.Funciones["raiz"]
+	evaluador.Funciones["raiz"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 1 {
+			if c, ok := args[0].(evaluador.Cantidad); ok {
+				if c.Valor < 0 { return nil, fmt.Errorf("❌ ERROR: raiz de numero negativo") }
+				r, err := c.Potencia(0.5); if err != nil { return nil, err }
+				return r, nil
+			}
+		}
+		return raizReal(args...)
+	}
+
+	// absoluto(-3 m) = 3 m
+	absolutoReal := evaluador.Funciones["absoluto"]
+	evaluador.Funciones["absoluto"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 1 {
+			if c, ok := args[0].(evaluador.Cantidad); ok {
+				if c.Valor < 0 { return c.Negar(), nil }
+				return c, nil
+			}
+		}
+		return absolutoReal(args...)
+	}
+}
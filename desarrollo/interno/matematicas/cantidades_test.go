@@ -0,0 +1,56 @@
+package matematicas
+
+import (
+	"strings"
+	"testing"
+
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func TestCantidadesConUnidades(t *testing.T) {
+	ctx := evaluador.PrepararContextoEvaluador()
+	casos := []struct {
+		expresion string
+		esperado  string
+	}{
+		{"5 m/s", "5 m/s"},
+		{`cantidad(9.8, "m/s^2")`, "9.8 m/s^2"},
+		{`(100 km/h).a("m/s")`, "27.77777777777778 m/s"},
+		{"(10 m)/(2 s)", "5 m/s"},
+		{"(5 m)/(2 m)", "2.5"},
+		{"1 km + 500 m", "1.5 km"},
+		{"2 m * 3", "6 m"},
+		{"(3 m)^2", "9 m^2"},
+		{"-(5 kg)", "-5 kg"},
+		{"raiz(16 m^2)", "4 m"},
+		{"absoluto(-3 N)", "3 N"},
+		{"1 km == 1000 m", "verdadero"},
+		{"seno(90 grado)", "1"},
+		{"(2 kg).valor()", "2"},
+		{"(2 kg).unidad()", "kg"},
+		{"(1 kPa).si()", "1000"},
+		{"(1 N).dimension()", "m*kg*s^-2"},
+		{`convertir_unidad(1, "mi", "km")`, "1.609344 km"},
+		// las funciones de física aceptan cantidades y devuelven la unidad derivada
+		{"fuerza(2 kg, 9.8 m/s^2)", "19.6 N"},
+		{"velocidad(100 km, 2 h)", "13.88888888888889 m/s"},
+		{"presion(10 N, 2 m^2)", "5 Pa"},
+		// y sin unidades siguen funcionando con números
+		{"velocidad(100, 10)", "10"},
+		{"energia_cinetica(2, 3)", "9"},
+	}
+	for _, c := range casos {
+		r, err := evaluador.EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if s := evaluador.FormatearValor(r); s != c.esperado {
+			t.Errorf("%s = %s, se esperaba %s", c.expresion, s, c.esperado)
+		}
+	}
+
+	if _, err := evaluador.EvalConContexto("5 m + 3 s", ctx); err == nil || !strings.Contains(err.Error(), "dimensiones físicas incompatibles") {
+		t.Errorf("5 m + 3 s: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package matematicas
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// validarComplejo1 extrae un único argumento como complejo (los reales se promueven).
+func validarComplejo1(nombre string, args []interface{}) (complex128, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("❌ ERROR: '%s' requiere 1 argumento", nombre)
+	}
+	c, ok := evaluador.ComoComplejo(args[0])
+	if !ok {
+		return 0, fmt.Errorf("❌ ERROR en '%s': se esperaba un número complejo", nombre)
+	}
+	return c, nil
+}
+
+func finalizarComplejo(nombre string, c complex128) (interface{}, error) {
+	if cmplx.IsNaN(c) || cmplx.IsInf(c) {
+		return nil, fmt.Errorf("❌ ERROR en '%s': resultado complejo fuera de rango", nombre)
+	}
+	return c, nil
+}
+
+// extenderAComplejos hace que una función real ya registrada acepte complejos:
+// si el argumento es complejo usa 'f', si no delega en la versión real.
+func extenderAComplejos(nombre string, f func(complex128) complex128) {
+	versionReal := evaluador.Funciones[nombre]
+	evaluador.Funciones[nombre] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 1 {
+			if c, ok := args[0].(complex128); ok {
+				return finalizarComplejo(nombre, f(c))
+			}
+		}
+		return versionReal(args...)
+	}
+}
+
+func inyectarComplejosGlobal() {
+
+	// --- 1. CONSTRUCCIÓN Y PARTES ---
+
+	// complejo(re, im) -> re + im·i
+	evaluador.Funciones["complejo"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 1 {
+			return validarComplejo1("complejo", args)
+		}
+		re, im, err := validar2("complejo", args); if err != nil { return nil, err }
+		return complex(re, im), nil
+	}
+
+	// desde_polar(r, angulo_grados) -> complejo (inverso de a_polar)
+	evaluador.Funciones["desde_polar"] = func(args ...interface{}) (interface{}, error) {
+		r, ang, err := validar2("desde_polar", args); if err != nil { return nil, err }
+		return cmplx.Rect(r, ang*(math.Pi/180)), nil
+	}
+
+	// parte_real(z), parte_imaginaria(z)
+	evaluador.Funciones["parte_real"] = func(args ...interface{}) (interface{}, error) {
+		z, err := validarComplejo1("parte_real", args); if err != nil { return nil, err }
+		return real(z), nil
+	}
+	evaluador.Funciones["parte_imaginaria"] = func(args ...interface{}) (interface{}, error) {
+		z, err := validarComplejo1("parte_imaginaria", args); if err != nil { return nil, err }
+		return imag(z), nil
+	}
+
+	// --- 2. MÓDULO, ARGUMENTO Y CONJUGADO ---
+
+	// modulo(z) -> |z|
+	evaluador.Funciones["modulo"] = func(args ...interface{}) (interface{}, error) {
+		z, err := validarComplejo1("modulo", args); if err != nil { return nil, err }
+		return finalizar("modulo", cmplx.Abs(z))
+	}
+
+	// argumento(z) -> ángulo en radianes en (-π, π]
+	evaluador.Funciones["argumento"] = func(args ...interface{}) (interface{}, error) {
+		z, err := validarComplejo1("argumento", args); if err != nil { return nil, err }
+		return cmplx.Phase(z), nil
+	}
+
+	// conjugado(z) -> re - im·i
+	evaluador.Funciones["conjugado"] = func(args ...interface{}) (interface{}, error) {
+		z, err := validarComplejo1("conjugado", args); if err != nil { return nil, err }
+		return cmplx.Conj(z), nil
+	}
+
+	// --- 3. FUNCIONES REALES EXTENDIDAS AL PLANO COMPLEJO ---
+	extenderAComplejos("exp", cmplx.Exp)
+	extenderAComplejos("raiz", cmplx.Sqrt)
+	extenderAComplejos("logaritmo", cmplx.Log)
+	extenderAComplejos("logaritmo10", cmplx.Log10)
+	extenderAComplejos("seno", cmplx.Sin)
+	extenderAComplejos("coseno", cmplx.Cos)
+	extenderAComplejos("tangente", cmplx.Tan)
+	extenderAComplejos("arcoseno", cmplx.Asin)
+	extenderAComplejos("arcocoseno", cmplx.Acos)
+	extenderAComplejos("arcotangente", cmplx.Atan)
+	extenderAComplejos("seno_h", cmplx.Sinh)
+	extenderAComplejos("coseno_h", cmplx.Cosh)
+	extenderAComplejos("tangente_h", cmplx.Tanh)
+	extenderAComplejos("sinh", cmplx.Sinh)
+	extenderAComplejos("cosh", cmplx.Cosh)
+
+	// absoluto(z) de un complejo es su módulo
+	absolutoReal := evaluador.Funciones["absoluto"]
+	evaluador.Funciones["absoluto"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 1 {
+			if z, ok := args[0].(complex128); ok {
+				return cmplx.Abs(z), nil
+			}
+		}
+		return absolutoReal(args...)
+	}
+
+	// potencia(z, w) con base o exponente complejo
+	potenciaReal := evaluador.Funciones["potencia"]
+	evaluador.Funciones["potencia"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 2 {
+			_, c1 := args[0].(complex128)
+			_, c2 := args[1].(complex128)
+			if c1 || c2 {
+				b, _ := evaluador.ComoComplejo(args[0])
+				e, _ := evaluador.ComoComplejo(args[1])
+				return finalizarComplejo("potencia", evaluador.PotenciaCompleja(b, e))
+			}
+		}
+		return potenciaReal(args...)
+	}
+
+	// a_polar(z) -> [radio, angulo_grados] (también acepta a_polar(x, y))
+	aPolarReal := evaluador.Funciones["a_polar"]
+	evaluador.Funciones["a_polar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 1 {
+			z, err := validarComplejo1("a_polar", args); if err != nil { return nil, err }
+			r, ang := cmplx.Polar(z)
+			return []float64{r, ang * (180 / math.Pi)}, nil
+		}
+		return aPolarReal(args...)
+	}
+
+	// --- 4. MÉTODOS: z.modulo(), z.conjugado() ---
+	for _, metodo := range []string{"modulo", "argumento", "conjugado", "parte_real", "parte_imaginaria", "a_polar"} {
+		evaluador.Funciones["complejo."+metodo] = evaluador.Funciones[metodo]
+	}
+}
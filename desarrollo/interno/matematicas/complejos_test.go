@@ -0,0 +1,58 @@
+package matematicas
+
+import (
+	"strings"
+	"testing"
+
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func TestAritmeticaCompleja(t *testing.T) {
+	ctx := evaluador.PrepararContextoEvaluador()
+	ctx.Variables["z"] = complex(3, 4)
+
+	casos := []struct {
+		expresion string
+		esperado  string
+	}{
+		{"3+4i", "3+4i"},
+		{"complejo(3, 4)", "3+4i"},
+		{"z * conjugado(z)", "25+0i"},
+		{"modulo(z)", "5"},
+		{"absoluto(z)", "5"},
+		{"z / (1-2i)", "-1+2i"},
+		{"-z", "-3-4i"},
+		{"z^2", "-7+24i"},
+		{"potencia(z, 2)", "-7+24i"},
+		{"1i^2", "-1+0i"},
+		{"raiz(-4+0i)", "0+2i"},
+		{"raiz(4)", "2"},
+		{"z == 3+4i", "verdadero"},
+		{"z.conjugado()", "3-4i"},
+		{"resolver_cuadratica(1, 2, 5)", "[-1+2i, -1-2i]"},
+		{"a_polar(1+1i)", "[1.4142135623730951 45]"},
+	}
+	for _, c := range casos {
+		r, err := evaluador.EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if s := evaluador.FormatearValor(r); s != c.esperado {
+			t.Errorf("%s = %s, se esperaba %s", c.expresion, s, c.esperado)
+		}
+	}
+
+	r, err := evaluador.EvalConContexto("argumento(1i)", ctx)
+	if err != nil || r.(float64) != 1.5707963267948966 {
+		t.Errorf("argumento(1i) = %v, %v", r, err)
+	}
+	for expresion, mensaje := range map[string]string{
+		"z < 1": "no tienen orden",
+		"z / 0": "división por cero",
+	} {
+		if _, err := evaluador.EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
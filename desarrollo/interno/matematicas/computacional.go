@@ -116,6 +116,11 @@ func inyectarComputacionalGlobal() {
 		return float64(^int64(a)), nil
 	}
 
+	// Nombres en español; bit_o sustituye al operador '|', que es la tubería
+	for es, en := range map[string]string{"bit_y": "bit_and", "bit_o": "bit_or", "bit_no": "bit_not"} {
+		evaluador.Funciones[es] = evaluador.Funciones[en]
+	}
+
 	// --- 4. DESPLAZAMIENTOS Y ROTACIONES ---
 
 	evaluador.Funciones["desplazar_izq"] = func(args ...interface{}) (interface{}, error) {
@@ -0,0 +1,161 @@
+package matematicas
+
+import (
+	"fmt"
+	"math"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// validarModoRedondeo lee el modo opcional ("mitad_par", "mitad_arriba", "truncar").
+func validarModoRedondeo(nombre string, args []interface{}, pos int) (evaluador.ModoRedondeo, error) {
+	if len(args) <= pos {
+		return evaluador.ConfigDecimal.Redondeo, nil
+	}
+	texto, ok := args[pos].(string)
+	if !ok {
+		return 0, fmt.Errorf("❌ ERROR en '%s': el modo de redondeo debe ser texto", nombre)
+	}
+	return evaluador.ModoRedondeoDesdeNombre(texto)
+}
+
+func validarDecimal(nombre string, v interface{}) (evaluador.Decimal, error) {
+	d, ok := evaluador.ComoDecimal(v)
+	if !ok {
+		return evaluador.Decimal{}, fmt.Errorf("❌ ERROR en '%s': no se puede convertir a decimal → %v", nombre, v)
+	}
+	return d, nil
+}
+
+// hayDecimal indica si algún argumento (o elemento de lista) es decimal.
+func hayDecimal(args []interface{}) bool {
+	for _, a := range args {
+		switch x := a.(type) {
+		case evaluador.Decimal:
+			return true
+		case []interface{}:
+			if hayDecimal(x) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validarDecimalesN convierte los argumentos (aplanando listas) a decimales.
+func validarDecimalesN(nombre string, args []interface{}) ([]evaluador.Decimal, error) {
+	var res []evaluador.Decimal
+	for _, a := range args {
+		if lista, ok := a.([]interface{}); ok {
+			sub, err := validarDecimalesN(nombre, lista)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, sub...)
+			continue
+		}
+		d, err := validarDecimal(nombre, a)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, d)
+	}
+	return res, nil
+}
+
+// extenderADecimales hace que una función real ya registrada calcule en
+// decimal exacto cuando recibe algún decimal. aridad < 0 admite listas y
+// cantidad variable de argumentos.
+func extenderADecimales(nombre string, aridad int, f func(d []evaluador.Decimal) (evaluador.Decimal, error)) {
+	versionReal := evaluador.Funciones[nombre]
+	evaluador.Funciones[nombre] = func(args ...interface{}) (interface{}, error) {
+		if !hayDecimal(args) {
+			return versionReal(args...)
+		}
+		ds, err := validarDecimalesN(nombre, args)
+		if err != nil {
+			return nil, err
+		}
+		if aridad >= 0 && len(ds) != aridad {
+			return nil, fmt.Errorf("❌ ERROR: '%s' requiere %d argumentos", nombre, aridad)
+		}
+		res, err := f(ds)
+		if err != nil {
+			return nil, fmt.Errorf("❌ ERROR en '%s': %w", nombre, err)
+		}
+		return res, nil
+	}
+}
+
+// potenciaDecimal eleva a un exponente decimal: exacto si es entero; si no,
+// se aproxima con float64 y se lleva a la escala configurada.
+func potenciaDecimal(base, exponente evaluador.Decimal) (evaluador.Decimal, error) {
+	if entero := exponente.ConEscala(0, evaluador.RedondeoTruncar); entero.Comparar(exponente) == 0 {
+		n := entero.Float64()
+		if math.Abs(n) <= math.MaxInt32 {
+			return base.Potencia(int(n))
+		}
+	}
+	res := math.Pow(base.Float64(), exponente.Float64())
+	if math.IsNaN(res) || math.IsInf(res, 0) {
+		return evaluador.Decimal{}, fmt.Errorf("potencia indefinida → %s^%s", base, exponente)
+	}
+	d, err := evaluador.DecimalDesdeReal(res)
+	if err != nil {
+		return evaluador.Decimal{}, err
+	}
+	return d.ConEscala(evaluador.ConfigDecimal.Escala, evaluador.ConfigDecimal.Redondeo), nil
+}
+
+func inyectarDecimalesGlobal() {
+
+	// --- 1. CREACIÓN Y REDONDEO ---
+
+	// decimal(valor, [escala], [modo]) -> decimal exacto; decimal("0.1") o decimal(19.99, 2)
+	evaluador.Funciones["decimal"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 3 {
+			return nil, fmt.Errorf("❌ ERROR: 'decimal' requiere entre 1 y 3 argumentos: valor, [escala], [modo]")
+		}
+		d, err := validarDecimal("decimal", args[0]); if err != nil { return nil, err }
+		if len(args) == 1 { return d, nil }
+		escala, err := validar1("decimal", args[1:2]); if err != nil { return nil, err }
+		modo, err := validarModoRedondeo("decimal", args, 2); if err != nil { return nil, err }
+		return d.Redondear(int(escala), modo)
+	}
+
+	// redondear_decimal(d, escala, [modo]) -> redondear_decimal(2.675, 2, "mitad_arriba") = 2.68
+	evaluador.Funciones["redondear_decimal"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 {
+			return nil, fmt.Errorf("❌ ERROR: 'redondear_decimal' requiere: valor, escala y [modo]")
+		}
+		d, err := validarDecimal("redondear_decimal", args[0]); if err != nil { return nil, err }
+		escala, err := validar1("redondear_decimal", args[1:2]); if err != nil { return nil, err }
+		modo, err := validarModoRedondeo("redondear_decimal", args, 2); if err != nil { return nil, err }
+		return d.Redondear(int(escala), modo)
+	}
+
+	// configurar_decimal(escala, [modo]) -> decimales y redondeo de divisiones y potencias
+	evaluador.Funciones["configurar_decimal"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 {
+			return nil, fmt.Errorf("❌ ERROR: 'configurar_decimal' requiere: escala y [modo]")
+		}
+		escala, err := validar1("configurar_decimal", args[:1]); if err != nil { return nil, err }
+		if escala < 0 || escala > 1000 {
+			return nil, fmt.Errorf("❌ ERROR en 'configurar_decimal': la escala debe estar entre 0 y 1000")
+		}
+		modo, err := validarModoRedondeo("configurar_decimal", args, 1); if err != nil { return nil, err }
+		evaluador.ConfigDecimal.Escala = int(escala)
+		evaluador.ConfigDecimal.Redondeo = modo
+		return true, nil
+	}
+
+	// --- 2. MÉTODOS: d.redondear(2), d.escala() ---
+	evaluador.Funciones["decimal.redondear"] = evaluador.Funciones["redondear_decimal"]
+	evaluador.Funciones["decimal.escala"] = func(args ...interface{}) (interface{}, error) {
+		d, err := validarDecimal("escala", args[0]); if err != nil { return nil, err }
+		return d.Escala(), nil
+	}
+	evaluador.Funciones["decimal.a_real"] = func(args ...interface{}) (interface{}, error) {
+		d, err := validarDecimal("a_real", args[0]); if err != nil { return nil, err }
+		return d.Float64(), nil
+	}
+}
@@ -0,0 +1,490 @@
+package matematicas
+
+import (
+	"fmt"
+	"math"
+	"nepa/desarrollo/interno/evaluador"
+	"sort"
+	"strings"
+)
+
+// --- AYUDANTES: SERIES Y CUANTILES ---
+
+// esMatrizDatos distingue una matriz (lista de filas) de una lista simple.
+func esMatrizDatos(v interface{}) bool {
+	switch m := v.(type) {
+	case [][]float64, [][]interface{}:
+		return true
+	case []interface{}:
+		if len(m) == 0 { return false }
+		for _, f := range m {
+			switch f.(type) {
+			case []interface{}, []float64:
+			default:
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// porSerie aplica el cálculo a la lista de datos o, si llega una matriz,
+// a cada una de sus columnas (devuelve una lista con un resultado por columna).
+func porSerie(nombre string, args []interface{}, calcular func([]float64) (interface{}, error)) (interface{}, error) {
+	if len(args) == 1 && esMatrizDatos(args[0]) {
+		m, err := convertirAMatriz(nombre, args[0]); if err != nil { return nil, err }
+		res := make([]interface{}, len(m[0]))
+		for j := range m[0] {
+			columna := make([]float64, len(m))
+			for i := range m { columna[i] = m[i][j] }
+			if res[j], err = calcular(columna); err != nil { return nil, fmt.Errorf("%v (columna %d)", err, j+1) }
+		}
+		return res, nil
+	}
+	nums, err := validarN(nombre, args); if err != nil { return nil, err }
+	if len(nums) == 0 { return nil, fmt.Errorf("❌ ERROR: '%s' requiere al menos 1 dato", nombre) }
+	return calcular(nums)
+}
+
+func ordenados(nums []float64) []float64 {
+	copia := append([]float64(nil), nums...)
+	sort.Float64s(copia)
+	return copia
+}
+
+// cuantil interpola linealmente entre posiciones (mismo criterio que 'percentil').
+func cuantil(orden []float64, p float64) float64 {
+	idx := p * float64(len(orden)-1)
+	i := int(idx)
+	if i+1 >= len(orden) { return orden[len(orden)-1] }
+	return orden[i] + (idx-float64(i))*(orden[i+1]-orden[i])
+}
+
+func sumaCuadrados(nums []float64) float64 {
+	media, _ := calcularMedia(nums)
+	var s float64
+	for _, v := range nums { s += (v - media) * (v - media) }
+	return s
+}
+
+// varianzaDatos: muestral divide entre n-1, poblacional entre n.
+func varianzaDatos(nombre string, nums []float64, muestral bool) (float64, error) {
+	n := float64(len(nums))
+	if muestral {
+		if n < 2 { return 0, fmt.Errorf("❌ ERROR: '%s' muestral requiere al menos 2 datos", nombre) }
+		return sumaCuadrados(nums) / (n - 1), nil
+	}
+	if n < 1 { return 0, fmt.Errorf("❌ ERROR: '%s' requiere al menos 1 dato", nombre) }
+	return sumaCuadrados(nums) / n, nil
+}
+
+// modas devuelve los valores más frecuentes en orden ascendente.
+func modas(nums []float64) []float64 {
+	conteo := map[float64]int{}
+	maximo := 0
+	for _, v := range nums {
+		conteo[v]++
+		if conteo[v] > maximo { maximo = conteo[v] }
+	}
+	var res []float64
+	for v, c := range conteo {
+		if c == maximo { res = append(res, v) }
+	}
+	sort.Float64s(res)
+	return res
+}
+
+// valorModa: un número si la moda es única, una lista si hay varias.
+func valorModa(nums []float64) interface{} {
+	m := modas(nums)
+	if len(m) == 1 { return m[0] }
+	res := make([]interface{}, len(m))
+	for i, v := range m { res[i] = v }
+	return res
+}
+
+func listaReales(nums []float64) []interface{} {
+	res := make([]interface{}, len(nums))
+	for i, v := range nums { res[i] = v }
+	return res
+}
+
+// bordesClases calcula los límites de las clases de un histograma. La
+// especificación puede ser un número de clases, una lista de bordes o una
+// regla: "sturges" (por defecto), "raiz", "scott" o "freedman".
+func bordesClases(nombre string, nums []float64, espec interface{}) ([]float64, error) {
+	orden := ordenados(nums)
+	minimo, maximo := orden[0], orden[len(orden)-1]
+	n := float64(len(nums))
+	clases := 0
+	switch e := espec.(type) {
+	case nil:
+		clases = int(math.Ceil(math.Log2(n))) + 1
+	case string:
+		ancho := 0.0
+		switch strings.ToLower(strings.TrimSpace(e)) {
+		case "sturges":
+			clases = int(math.Ceil(math.Log2(n))) + 1
+		case "raiz":
+			clases = int(math.Ceil(math.Sqrt(n)))
+		case "scott":
+			if n > 1 {
+				v, _ := varianzaDatos(nombre, nums, true)
+				ancho = 3.49 * math.Sqrt(v) / math.Cbrt(n)
+			}
+		case "freedman":
+			ancho = 2 * (cuantil(orden, 0.75) - cuantil(orden, 0.25)) / math.Cbrt(n)
+		default:
+			return nil, fmt.Errorf("❌ ERROR en '%s': regla de clases desconocida '%s' (usa sturges, raiz, scott o freedman)", nombre, e)
+		}
+		if ancho > 0 { clases = int(math.Ceil((maximo - minimo) / ancho)) }
+	default:
+		if lista, err := evaluador.ConvertirAListaReal(espec); err == nil && len(lista) > 1 {
+			bordes := ordenados(lista)
+			return bordes, nil
+		}
+		k, err := evaluador.ConvertirAReal(espec)
+		if err != nil || k < 1 || k != math.Trunc(k) { return nil, fmt.Errorf("❌ ERROR en '%s': las clases deben ser un entero positivo, una lista de bordes o una regla", nombre) }
+		clases = int(k)
+	}
+	if clases < 1 { clases = 1 }
+	if clases > 10_000 { return nil, fmt.Errorf("❌ ERROR en '%s': demasiadas clases (%d)", nombre, clases) }
+	if maximo == minimo {
+		minimo, maximo = minimo-0.5, maximo+0.5
+	}
+	bordes := make([]float64, clases+1)
+	for i := range bordes { bordes[i] = minimo + (maximo-minimo)*float64(i)/float64(clases) }
+	bordes[clases] = maximo
+	return bordes, nil
+}
+
+// contarClases: intervalos [a, b) salvo el último, que incluye su borde derecho.
+func contarClases(nums, bordes []float64) []int {
+	conteos := make([]int, len(bordes)-1)
+	for _, v := range nums {
+		if v < bordes[0] || v > bordes[len(bordes)-1] { continue }
+		i := sort.SearchFloat64s(bordes, v)
+		if i < len(bordes) && bordes[i] == v { i++ }
+		i--
+		if i >= len(conteos) { i = len(conteos) - 1 }
+		conteos[i]++
+	}
+	return conteos
+}
+
+// resumenDatos arma el diccionario de resumen(); las medidas que no están
+// definidas para tan pocos datos quedan en nulo.
+func resumenDatos(nums []float64) (interface{}, error) {
+	if len(nums) == 0 { return nil, fmt.Errorf("❌ ERROR: 'resumen' requiere al menos 1 dato") }
+	orden := ordenados(nums)
+	n := float64(len(nums))
+	media, _ := calcularMedia(nums)
+	var suma float64
+	for _, v := range nums { suma += v }
+	varPob, _ := varianzaDatos("resumen", nums, false)
+	res := map[string]interface{}{
+		"n":                      len(nums),
+		"suma":                   suma,
+		"media":                  media,
+		"mediana":                cuantil(orden, 0.5),
+		"moda":                   valorModa(nums),
+		"minimo":                 orden[0],
+		"maximo":                 orden[len(orden)-1],
+		"rango":                  orden[len(orden)-1] - orden[0],
+		"q1":                     cuantil(orden, 0.25),
+		"q3":                     cuantil(orden, 0.75),
+		"rango_intercuartil":     cuantil(orden, 0.75) - cuantil(orden, 0.25),
+		"varianza_poblacional":   varPob,
+		"desviacion_poblacional": math.Sqrt(varPob),
+		"varianza":               nil,
+		"desviacion":             nil,
+		"error_estandar":         nil,
+		"coeficiente_variacion":  nil,
+		"sesgo":                  nil,
+		"curtosis":               nil,
+	}
+	if n >= 2 {
+		v, _ := varianzaDatos("resumen", nums, true)
+		res["varianza"], res["desviacion"] = v, math.Sqrt(v)
+		res["error_estandar"] = math.Sqrt(v / n)
+		if media != 0 { res["coeficiente_variacion"] = math.Sqrt(v) / math.Abs(media) }
+	}
+	if n >= 3 {
+		if s, err := evaluador.Funciones["sesgo"](listaReales(nums)); err == nil { res["sesgo"] = s }
+	}
+	if n >= 4 {
+		if c, err := evaluador.Funciones["curtosis"](listaReales(nums)); err == nil { res["curtosis"] = c }
+	}
+	return res, nil
+}
+
+func inyectarDescriptivaGlobal() {
+
+	// --- 1. TENDENCIA CENTRAL ---
+
+	// media(lista) o media(matriz) -> promedio por columna
+	evaluador.Funciones["media"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("media", args, func(nums []float64) (interface{}, error) {
+			m, _ := calcularMedia(nums)
+			return finalizar("media", m)
+		})
+	}
+
+	evaluador.Funciones["mediana"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("mediana", args, func(nums []float64) (interface{}, error) {
+			return finalizar("mediana", cuantil(ordenados(nums), 0.5))
+		})
+	}
+
+	// moda(lista) -> el valor más frecuente, o la lista de valores si hay empate
+	evaluador.Funciones["moda"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("moda", args, func(nums []float64) (interface{}, error) {
+			return valorModa(nums), nil
+		})
+	}
+
+	// media_ponderada(valores, pesos) -> Σ(w·x) / Σw
+	evaluador.Funciones["media_ponderada"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'media_ponderada' requiere: valores y pesos") }
+		x, err := evaluador.ConvertirAListaReal(args[0]); if err != nil { return nil, err }
+		w, err := evaluador.ConvertirAListaReal(args[1]); if err != nil { return nil, err }
+		if len(x) != len(w) { return nil, fmt.Errorf("❌ ERROR en 'media_ponderada': %d valores y %d pesos", len(x), len(w)) }
+		var suma, pesos float64
+		for i := range x {
+			if w[i] < 0 { return nil, fmt.Errorf("❌ ERROR en 'media_ponderada': los pesos no pueden ser negativos") }
+			suma += w[i] * x[i]
+			pesos += w[i]
+		}
+		if pesos == 0 { return nil, fmt.Errorf("❌ ERROR en 'media_ponderada': la suma de los pesos es cero") }
+		return finalizar("media_ponderada", suma/pesos)
+	}
+
+	evaluador.Funciones["media_geometrica"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("media_geometrica", args, func(nums []float64) (interface{}, error) {
+			var logs float64
+			for _, v := range nums {
+				if v <= 0 { return nil, fmt.Errorf("❌ ERROR: 'media_geometrica' requiere datos positivos") }
+				logs += math.Log(v)
+			}
+			return finalizar("media_geometrica", math.Exp(logs/float64(len(nums))))
+		})
+	}
+
+	evaluador.Funciones["media_armonica"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("media_armonica", args, func(nums []float64) (interface{}, error) {
+			var inversos float64
+			for _, v := range nums {
+				if v <= 0 { return nil, fmt.Errorf("❌ ERROR: 'media_armonica' requiere datos positivos") }
+				inversos += 1 / v
+			}
+			return finalizar("media_armonica", float64(len(nums))/inversos)
+		})
+	}
+
+	// media_recortada(lista, 0.1) -> descarta el 10% menor y el 10% mayor
+	evaluador.Funciones["media_recortada"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'media_recortada' requiere: datos y proporción (0 a 0.5)") }
+		p, err := evaluador.ConvertirAReal(args[1]); if err != nil { return nil, err }
+		if p < 0 || p >= 0.5 { return nil, fmt.Errorf("❌ ERROR en 'media_recortada': la proporción debe estar en [0, 0.5)") }
+		return porSerie("media_recortada", args[:1], func(nums []float64) (interface{}, error) {
+			orden := ordenados(nums)
+			k := int(math.Floor(p * float64(len(orden))))
+			m, _ := calcularMedia(orden[k : len(orden)-k])
+			return finalizar("media_recortada", m)
+		})
+	}
+
+	// --- 2. DISPERSIÓN (muestral: n-1, poblacional: n) ---
+
+	varianza := func(nombre string, muestral, raiz bool) func(args ...interface{}) (interface{}, error) {
+		return func(args ...interface{}) (interface{}, error) {
+			return porSerie(nombre, args, func(nums []float64) (interface{}, error) {
+				v, err := varianzaDatos(nombre, nums, muestral); if err != nil { return nil, err }
+				if raiz { v = math.Sqrt(v) }
+				return finalizar(nombre, v)
+			})
+		}
+	}
+	evaluador.Funciones["varianza"] = varianza("varianza", true, false)
+	evaluador.Funciones["varianza_muestral"] = evaluador.Funciones["varianza"]
+	evaluador.Funciones["varianza_poblacional"] = varianza("varianza_poblacional", false, false)
+	evaluador.Funciones["desviacion"] = varianza("desviacion", true, true)
+	evaluador.Funciones["desviacion_muestral"] = evaluador.Funciones["desviacion"]
+	evaluador.Funciones["desviacion_poblacional"] = varianza("desviacion_poblacional", false, true)
+
+	// recorrido(lista) -> máximo - mínimo ('rango' es el rango de una matriz)
+	evaluador.Funciones["recorrido"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("recorrido", args, func(nums []float64) (interface{}, error) {
+			orden := ordenados(nums)
+			return finalizar("recorrido", orden[len(orden)-1]-orden[0])
+		})
+	}
+
+	// desviacion_media(lista) -> promedio de |x - media|
+	evaluador.Funciones["desviacion_media"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("desviacion_media", args, func(nums []float64) (interface{}, error) {
+			media, _ := calcularMedia(nums)
+			var s float64
+			for _, v := range nums { s += math.Abs(v - media) }
+			return finalizar("desviacion_media", s/float64(len(nums)))
+		})
+	}
+
+	evaluador.Funciones["coeficiente_variacion"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("coeficiente_variacion", args, func(nums []float64) (interface{}, error) {
+			v, err := varianzaDatos("coeficiente_variacion", nums, true); if err != nil { return nil, err }
+			media, _ := calcularMedia(nums)
+			if media == 0 { return nil, fmt.Errorf("❌ ERROR: 'coeficiente_variacion' no está definido con media cero") }
+			return finalizar("coeficiente_variacion", math.Sqrt(v)/math.Abs(media))
+		})
+	}
+
+	// error_estandar(lista) -> s / √n
+	evaluador.Funciones["error_estandar"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("error_estandar", args, func(nums []float64) (interface{}, error) {
+			v, err := varianzaDatos("error_estandar", nums, true); if err != nil { return nil, err }
+			return finalizar("error_estandar", math.Sqrt(v/float64(len(nums))))
+		})
+	}
+
+	// --- 3. POSICIÓN: CUARTILES Y PUNTUACIONES Z ---
+
+	// cuartiles(lista) -> [Q1, Q2, Q3]
+	evaluador.Funciones["cuartiles"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("cuartiles", args, func(nums []float64) (interface{}, error) {
+			orden := ordenados(nums)
+			return []interface{}{cuantil(orden, 0.25), cuantil(orden, 0.5), cuantil(orden, 0.75)}, nil
+		})
+	}
+
+	// cuartil(lista, k) -> Qk con k de 0 (mínimo) a 4 (máximo)
+	evaluador.Funciones["cuartil"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'cuartil' requiere: datos y k (0 a 4)") }
+		k, err := evaluador.ConvertirAReal(args[1]); if err != nil { return nil, err }
+		if k < 0 || k > 4 || k != math.Trunc(k) { return nil, fmt.Errorf("❌ ERROR en 'cuartil': k debe ser 0, 1, 2, 3 o 4") }
+		return porSerie("cuartil", args[:1], func(nums []float64) (interface{}, error) {
+			return finalizar("cuartil", cuantil(ordenados(nums), k/4))
+		})
+	}
+
+	// rango_intercuartil(lista) -> Q3 - Q1
+	evaluador.Funciones["rango_intercuartil"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("rango_intercuartil", args, func(nums []float64) (interface{}, error) {
+			orden := ordenados(nums)
+			return finalizar("rango_intercuartil", cuantil(orden, 0.75)-cuantil(orden, 0.25))
+		})
+	}
+	evaluador.Funciones["iqr"] = evaluador.Funciones["rango_intercuartil"]
+
+	// puntuaciones_z(lista, ["poblacional"]) -> (x - media) / desviación de cada dato
+	evaluador.Funciones["puntuaciones_z"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 { return nil, fmt.Errorf("❌ ERROR: 'puntuaciones_z' requiere: datos y [\"muestral\" | \"poblacional\"]") }
+		muestral := true
+		if len(args) == 2 {
+			switch fmt.Sprint(args[1]) {
+			case "muestral":
+			case "poblacional":
+				muestral = false
+			default:
+				return nil, fmt.Errorf("❌ ERROR en 'puntuaciones_z': usa \"muestral\" o \"poblacional\"")
+			}
+		}
+		return porSerie("puntuaciones_z", args[:1], func(nums []float64) (interface{}, error) {
+			v, err := varianzaDatos("puntuaciones_z", nums, muestral); if err != nil { return nil, err }
+			if v == 0 { return nil, fmt.Errorf("❌ ERROR: 'puntuaciones_z' requiere datos con dispersión (desviación cero)") }
+			media, _ := calcularMedia(nums)
+			z := make([]interface{}, len(nums))
+			for i, x := range nums { z[i] = (x - media) / math.Sqrt(v) }
+			return z, nil
+		})
+	}
+
+	// puntuacion_z(x, media, desviacion)
+	evaluador.Funciones["puntuacion_z"] = func(args ...interface{}) (interface{}, error) {
+		x, media, desv, err := validar3("puntuacion_z", args); if err != nil { return nil, err }
+		if desv <= 0 { return nil, fmt.Errorf("❌ ERROR en 'puntuacion_z': la desviación debe ser positiva") }
+		return finalizar("puntuacion_z", (x-media)/desv)
+	}
+
+	// --- 4. FRECUENCIAS E HISTOGRAMAS ---
+
+	// histograma(lista, [clases]) -> {"bordes": [...], "frecuencias": [...]}
+	evaluador.Funciones["histograma"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 { return nil, fmt.Errorf("❌ ERROR: 'histograma' requiere: datos y [clases | bordes | regla]") }
+		nums, err := evaluador.ConvertirAListaReal(args[0]); if err != nil { return nil, err }
+		if len(nums) == 0 { return nil, fmt.Errorf("❌ ERROR: 'histograma' requiere al menos 1 dato") }
+		var espec interface{}
+		if len(args) == 2 { espec = args[1] }
+		bordes, err := bordesClases("histograma", nums, espec); if err != nil { return nil, err }
+		conteos := contarClases(nums, bordes)
+		frecuencias := make([]interface{}, len(conteos))
+		for i, c := range conteos { frecuencias[i] = c }
+		return map[string]interface{}{"bordes": listaReales(bordes), "frecuencias": frecuencias}, nil
+	}
+
+	// tabla_frecuencias(lista) -> una fila por valor distinto (sirve para texto);
+	// tabla_frecuencias(lista, clases) -> una fila por intervalo
+	evaluador.Funciones["tabla_frecuencias"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 { return nil, fmt.Errorf("❌ ERROR: 'tabla_frecuencias' requiere: datos y [clases | bordes | regla]") }
+		datos, ok := args[0].([]interface{})
+		if !ok {
+			nums, err := evaluador.ConvertirAListaReal(args[0]); if err != nil { return nil, err }
+			datos = listaReales(nums)
+		}
+		if len(datos) == 0 { return nil, fmt.Errorf("❌ ERROR: 'tabla_frecuencias' requiere al menos 1 dato") }
+		total := float64(len(datos))
+		var filas []interface{}
+		acumulada := 0
+		agregar := func(fila map[string]interface{}, frecuencia int) {
+			acumulada += frecuencia
+			fila["frecuencia"] = frecuencia
+			fila["relativa"] = float64(frecuencia) / total
+			fila["acumulada"] = acumulada
+			fila["relativa_acumulada"] = float64(acumulada) / total
+			filas = append(filas, fila)
+		}
+
+		if len(args) == 1 {
+			// Valores distintos en orden (números de menor a mayor, texto alfabético)
+			conteo := map[string]int{}
+			valores := map[string]interface{}{}
+			var claves []string
+			for _, d := range datos {
+				clave := evaluador.FormatearValor(d)
+				if _, visto := conteo[clave]; !visto {
+					claves = append(claves, clave)
+					valores[clave] = d
+				}
+				conteo[clave]++
+			}
+			sort.SliceStable(claves, func(i, j int) bool {
+				a, errA := evaluador.ConvertirAReal(valores[claves[i]])
+				b, errB := evaluador.ConvertirAReal(valores[claves[j]])
+				if errA == nil && errB == nil { return a < b }
+				return claves[i] < claves[j]
+			})
+			for _, c := range claves { agregar(map[string]interface{}{"valor": valores[c]}, conteo[c]) }
+			return filas, nil
+		}
+
+		nums, err := evaluador.ConvertirAListaReal(datos); if err != nil { return nil, err }
+		bordes, err := bordesClases("tabla_frecuencias", nums, args[1]); if err != nil { return nil, err }
+		for i, c := range contarClases(nums, bordes) {
+			agregar(map[string]interface{}{"desde": bordes[i], "hasta": bordes[i+1], "marca": (bordes[i] + bordes[i+1]) / 2}, c)
+		}
+		return filas, nil
+	}
+
+	// --- 5. RESUMEN COMPLETO ---
+
+	// resumen(lista) -> diccionario con todas las medidas; con una matriz, uno por columna
+	evaluador.Funciones["resumen"] = func(args ...interface{}) (interface{}, error) {
+		return porSerie("resumen", args, resumenDatos)
+	}
+
+	// Métodos: datos.media(), datos.resumen()...
+	for _, nombre := range []string{"media", "mediana", "moda", "varianza", "desviacion", "cuartiles", "rango_intercuartil", "puntuaciones_z", "histograma", "tabla_frecuencias", "resumen"} {
+		evaluador.Funciones["lista."+nombre] = evaluador.Funciones[nombre]
+	}
+}
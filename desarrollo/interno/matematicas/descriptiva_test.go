@@ -0,0 +1,69 @@
+package matematicas
+
+import (
+	"strings"
+	"testing"
+
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func TestEstadisticaDescriptiva(t *testing.T) {
+	ctx := evaluador.PrepararContextoEvaluador()
+	ctx.Variables["d"] = []interface{}{2, 4, 4, 4, 5, 5, 7, 9}
+	ctx.Variables["m"] = []interface{}{[]interface{}{1, 10}, []interface{}{2, 20}, []interface{}{3, 30}, []interface{}{6, 40}}
+	ctx.Variables["t"] = []interface{}{"rojo", "azul", "rojo", "verde", "rojo"}
+	ctx.Variables["w"] = []interface{}{1, 1, 1, 1, 1, 1, 1, 3}
+	ctx.Variables["b"] = []interface{}{0, 5, 10}
+	ctx.Variables["uno"] = []interface{}{7}
+	ctx.Variables["vacia"] = []interface{}{}
+
+	casos := []struct {
+		expresion string
+		esperado  string
+	}{
+		{"media(d)", "5"},
+		{"media(1, 2, 3)", "2"},
+		{"d.media()", "5"},
+		{"mediana(d)", "4.5"},
+		{"moda(d)", "4"},
+		{"varianza(d)", "4.571428571428571"},
+		{"varianza_poblacional(d)", "4"},
+		{"desviacion_poblacional(d)", "2"},
+		{"recorrido(d)", "7"},
+		{"cuartiles(d)", "[4, 4.5, 5.5]"},
+		{"rango_intercuartil(d)", "1.5"},
+		{"media_ponderada(d, w)", "5.8"},
+		{"media_recortada(d, 0.125)", "4.833333333333333"},
+		{`puntuaciones_z(d, "poblacional")`, "[-1.5, -0.5, -0.5, -0.5, 0, 0, 1, 2]"},
+		{"puntuacion_z(7, 5, 2)", "1"},
+		{"desviacion_media(d)", "1.5"},
+		// una matriz se resume por columnas
+		{"media(m)", "[3, 25]"},
+		{"desviacion(m)", "[2.160246899469287, 12.909944487358056]"},
+		{"histograma(d, 2)", `{"bordes": [2, 5.5, 9], "frecuencias": [6, 2]}`},
+		{"histograma(d, b)", `{"bordes": [0, 5, 10], "frecuencias": [4, 4]}`},
+		{"tabla_frecuencias(t)", `[{"acumulada": 1, "frecuencia": 1, "relativa": 0.2, "relativa_acumulada": 0.2, "valor": "azul"}, {"acumulada": 4, "frecuencia": 3, "relativa": 0.6, "relativa_acumulada": 0.8, "valor": "rojo"}, {"acumulada": 5, "frecuencia": 1, "relativa": 0.2, "relativa_acumulada": 1, "valor": "verde"}]`},
+		{"resumen(uno)", `{"coeficiente_variacion": nulo, "curtosis": nulo, "desviacion": nulo, "desviacion_poblacional": 0, "error_estandar": nulo, "maximo": 7, "media": 7, "mediana": 7, "minimo": 7, "moda": 7, "n": 1, "q1": 7, "q3": 7, "rango": 0, "rango_intercuartil": 0, "sesgo": nulo, "suma": 7, "varianza": nulo, "varianza_poblacional": 0}`},
+	}
+	for _, c := range casos {
+		r, err := evaluador.EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if s := evaluador.FormatearValor(r); s != c.esperado {
+			t.Errorf("%s = %s, se esperaba %s", c.expresion, s, c.esperado)
+		}
+	}
+
+	for expresion, mensaje := range map[string]string{
+		"varianza(uno)":       "al menos 2 datos",
+		"media(vacia)":        "al menos 1 dato",
+		`histograma(d, "xx")`: "regla de clases desconocida",
+		"cuartil(d, 5)":       "k debe ser 0, 1, 2, 3 o 4",
+	} {
+		if _, err := evaluador.EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
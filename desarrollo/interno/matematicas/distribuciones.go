@@ -0,0 +1,533 @@
+package matematicas
+
+import (
+	"fmt"
+	"math"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// --- FUNCIONES ESPECIALES: GAMMA Y BETA INCOMPLETAS ---
+
+const (
+	precisionEspecial  = 1e-14
+	minimoEspecial     = 1e-300
+	iteracionesMaximas = 500
+)
+
+// gammaIncompleta devuelve la gamma regularizada inferior P(a, x).
+func gammaIncompleta(a, x float64) float64 {
+	if x <= 0 { return 0 }
+	lg, _ := math.Lgamma(a)
+	prefijo := math.Exp(-x + a*math.Log(x) - lg)
+	if x < a+1 {
+		// Serie
+		ap, suma := a, 1/a
+		termino := suma
+		for i := 0; i < iteracionesMaximas; i++ {
+			ap++
+			termino *= x / ap
+			suma += termino
+			if math.Abs(termino) < math.Abs(suma)*precisionEspecial { break }
+		}
+		return suma * prefijo
+	}
+	// Fracción continua para Q(a, x) = 1 - P(a, x)
+	b := x + 1 - a
+	c, d := 1/minimoEspecial, 1/b
+	h := d
+	for i := 1; i < iteracionesMaximas; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < minimoEspecial { d = minimoEspecial }
+		c = b + an/c
+		if math.Abs(c) < minimoEspecial { c = minimoEspecial }
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < precisionEspecial { break }
+	}
+	return 1 - prefijo*h
+}
+
+// betaIncompleta devuelve la beta regularizada I_x(a, b).
+func betaIncompleta(a, b, x float64) float64 {
+	if x <= 0 { return 0 }
+	if x >= 1 { return 1 }
+	lab, _ := math.Lgamma(a + b)
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	prefijo := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+	if x < (a+1)/(a+b+2) {
+		return prefijo * fraccionBeta(a, b, x) / a
+	}
+	return 1 - prefijo*fraccionBeta(b, a, 1-x)/b
+}
+
+func fraccionBeta(a, b, x float64) float64 {
+	ajustar := func(v float64) float64 {
+		if math.Abs(v) < minimoEspecial { return minimoEspecial }
+		return v
+	}
+	c, d := 1.0, 1/ajustar(1-(a+b)*x/(a+1))
+	h := d
+	for m := 1; m < iteracionesMaximas; m++ {
+		fm := float64(m)
+		aa := fm * (b - fm) * x / ((a - 1 + 2*fm) * (a + 2*fm))
+		d = 1 / ajustar(1+aa*d)
+		c = ajustar(1 + aa/c)
+		h *= d * c
+		aa = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 1 + 2*fm))
+		d = 1 / ajustar(1+aa*d)
+		c = ajustar(1 + aa/c)
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < precisionEspecial { break }
+	}
+	return h
+}
+
+// invertirAcumulada busca x con F(x) = p por bisección; 'desde' es el inicio
+// del soporte (-Inf si la distribución no está acotada por la izquierda).
+func invertirAcumulada(acumulada func(float64) float64, p, desde float64) float64 {
+	lo, hi := desde, desde+1
+	if math.IsInf(desde, -1) { lo, hi = -1, 1 }
+	for acumulada(hi) < p && hi < 1e300 { hi = hi*2 + 1 }
+	for math.IsInf(desde, -1) && acumulada(lo) > p && lo > -1e300 { lo = lo*2 - 1 }
+	for i := 0; i < 300 && hi-lo > 1e-15*math.Max(1, math.Abs(hi)); i++ {
+		medio := (lo + hi) / 2
+		if acumulada(medio) < p { lo = medio } else { hi = medio }
+	}
+	return (lo + hi) / 2
+}
+
+// gammaAleatoria usa el método de Marsaglia y Tsang (forma k, escala 1).
+func gammaAleatoria(k float64) float64 {
+	if k < 1 {
+		return gammaAleatoria(k+1) * math.Pow(azar().Float64(), 1/k)
+	}
+	d := k - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := azar().NormFloat64()
+		v := 1 + c*x
+		if v <= 0 { continue }
+		v = v * v * v
+		u := azar().Float64()
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) { return d * v }
+	}
+}
+
+// --- CATÁLOGO DE DISTRIBUCIONES ---
+
+// distribucion describe una familia; cada una se publica como
+// <nombre>_pdf (o _pmf si es discreta), _cdf, _cuantil y _aleatorio.
+type distribucion struct {
+	nombre     string
+	parametros string // para los mensajes de error
+	nParams    int
+	porDefecto []float64 // valores de los últimos parámetros si se omiten
+	discreta   bool
+	desde      float64 // inicio del soporte
+	validar    func(p []float64) error
+	densidad   func(x float64, p []float64) float64
+	acumulada  func(x float64, p []float64) float64
+	cuantil    func(q float64, p []float64) float64 // nil: se invierte la acumulada
+	aleatorio  func(p []float64) float64            // nil: se invierte un uniforme
+}
+
+func positivo(nombre string, v float64) error {
+	if !(v > 0) || math.IsInf(v, 0) { return fmt.Errorf("'%s' debe ser positivo", nombre) }
+	return nil
+}
+
+func esEnteroNoNegativo(v float64) bool { return v >= 0 && v == math.Trunc(v) }
+
+var distribuciones = []distribucion{
+	{
+		nombre: "normal", parametros: "media, desviación", nParams: 2, porDefecto: []float64{0, 1}, desde: math.Inf(-1),
+		validar:   func(p []float64) error { return positivo("desviación", p[1]) },
+		densidad:  func(x float64, p []float64) float64 { z := (x - p[0]) / p[1]; return math.Exp(-z*z/2) / (p[1] * math.Sqrt(2*math.Pi)) },
+		acumulada: func(x float64, p []float64) float64 { return 0.5 * math.Erfc(-(x-p[0])/(p[1]*math.Sqrt2)) },
+		cuantil:   func(q float64, p []float64) float64 { return p[0] + p[1]*math.Sqrt2*math.Erfinv(2*q-1) },
+		aleatorio: func(p []float64) float64 { return p[0] + p[1]*azar().NormFloat64() },
+	},
+	{
+		nombre: "uniforme", parametros: "mínimo, máximo", nParams: 2, porDefecto: []float64{0, 1}, desde: math.Inf(-1),
+		validar: func(p []float64) error {
+			if !(p[0] < p[1]) { return fmt.Errorf("el mínimo debe ser menor que el máximo") }
+			return nil
+		},
+		densidad: func(x float64, p []float64) float64 {
+			if x < p[0] || x > p[1] { return 0 }
+			return 1 / (p[1] - p[0])
+		},
+		acumulada: func(x float64, p []float64) float64 { return math.Max(0, math.Min(1, (x-p[0])/(p[1]-p[0]))) },
+		cuantil:   func(q float64, p []float64) float64 { return p[0] + q*(p[1]-p[0]) },
+		aleatorio: func(p []float64) float64 { return p[0] + azar().Float64()*(p[1]-p[0]) },
+	},
+	{
+		nombre: "exponencial", parametros: "tasa", nParams: 1, porDefecto: []float64{1},
+		validar: func(p []float64) error { return positivo("tasa", p[0]) },
+		densidad: func(x float64, p []float64) float64 {
+			if x < 0 { return 0 }
+			return p[0] * math.Exp(-p[0]*x)
+		},
+		acumulada: func(x float64, p []float64) float64 {
+			if x < 0 { return 0 }
+			return -math.Expm1(-p[0] * x)
+		},
+		cuantil:   func(q float64, p []float64) float64 { return -math.Log1p(-q) / p[0] },
+		aleatorio: func(p []float64) float64 { return azar().ExpFloat64() / p[0] },
+	},
+	{
+		nombre: "gamma", parametros: "forma, escala", nParams: 2, porDefecto: []float64{1},
+		validar: func(p []float64) error {
+			if err := positivo("forma", p[0]); err != nil { return err }
+			return positivo("escala", p[1])
+		},
+		densidad: func(x float64, p []float64) float64 {
+			if x < 0 || (x == 0 && p[0] > 1) { return 0 }
+			lg, _ := math.Lgamma(p[0])
+			return math.Exp((p[0]-1)*math.Log(x) - x/p[1] - lg - p[0]*math.Log(p[1]))
+		},
+		acumulada: func(x float64, p []float64) float64 { return gammaIncompleta(p[0], x/p[1]) },
+		aleatorio: func(p []float64) float64 { return p[1] * gammaAleatoria(p[0]) },
+	},
+	{
+		nombre: "chi2", parametros: "grados de libertad", nParams: 1,
+		validar: func(p []float64) error { return positivo("grados de libertad", p[0]) },
+		densidad: func(x float64, p []float64) float64 {
+			if x < 0 || (x == 0 && p[0] > 2) { return 0 }
+			k := p[0] / 2
+			lg, _ := math.Lgamma(k)
+			return math.Exp((k-1)*math.Log(x) - x/2 - lg - k*math.Ln2)
+		},
+		acumulada: func(x float64, p []float64) float64 { return gammaIncompleta(p[0]/2, x/2) },
+		aleatorio: func(p []float64) float64 { return 2 * gammaAleatoria(p[0]/2) },
+	},
+	{
+		nombre: "t", parametros: "grados de libertad", nParams: 1, desde: math.Inf(-1),
+		validar: func(p []float64) error { return positivo("grados de libertad", p[0]) },
+		densidad: func(x float64, p []float64) float64 {
+			v := p[0]
+			a, _ := math.Lgamma((v + 1) / 2)
+			b, _ := math.Lgamma(v / 2)
+			return math.Exp(a-b-(v+1)/2*math.Log1p(x*x/v)) / math.Sqrt(v*math.Pi)
+		},
+		acumulada: func(x float64, p []float64) float64 { return tAcumulada(x, p[0]) },
+		aleatorio: func(p []float64) float64 { return azar().NormFloat64() / math.Sqrt(2*gammaAleatoria(p[0]/2)/p[0]) },
+	},
+	{
+		nombre: "f", parametros: "gl numerador, gl denominador", nParams: 2,
+		validar: func(p []float64) error {
+			if err := positivo("gl numerador", p[0]); err != nil { return err }
+			return positivo("gl denominador", p[1])
+		},
+		densidad: func(x float64, p []float64) float64 {
+			if x <= 0 { return 0 }
+			d1, d2 := p[0], p[1]
+			lab, _ := math.Lgamma((d1 + d2) / 2)
+			la, _ := math.Lgamma(d1 / 2)
+			lb, _ := math.Lgamma(d2 / 2)
+			return math.Exp(lab - la - lb + d1/2*math.Log(d1/d2) + (d1/2-1)*math.Log(x) - (d1+d2)/2*math.Log1p(d1*x/d2))
+		},
+		acumulada: func(x float64, p []float64) float64 {
+			if x <= 0 { return 0 }
+			return betaIncompleta(p[0]/2, p[1]/2, p[0]*x/(p[0]*x+p[1]))
+		},
+		aleatorio: func(p []float64) float64 { return (gammaAleatoria(p[0]/2) / p[0]) / (gammaAleatoria(p[1]/2) / p[1]) },
+	},
+	{
+		nombre: "binomial", parametros: "ensayos, probabilidad", nParams: 2, discreta: true,
+		validar: func(p []float64) error {
+			if !esEnteroNoNegativo(p[0]) { return fmt.Errorf("los ensayos deben ser un entero no negativo") }
+			if p[1] < 0 || p[1] > 1 { return fmt.Errorf("la probabilidad debe estar entre 0 y 1") }
+			return nil
+		},
+		densidad: func(k float64, p []float64) float64 {
+			n, q := p[0], p[1]
+			if !esEnteroNoNegativo(k) || k > n { return 0 }
+			if q == 0 || q == 1 {
+				if (q == 0 && k == 0) || (q == 1 && k == n) { return 1 }
+				return 0
+			}
+			ln, _ := math.Lgamma(n + 1)
+			lk, _ := math.Lgamma(k + 1)
+			lnk, _ := math.Lgamma(n - k + 1)
+			return math.Exp(ln - lk - lnk + k*math.Log(q) + (n-k)*math.Log1p(-q))
+		},
+		acumulada: func(k float64, p []float64) float64 {
+			k = math.Floor(k)
+			if k < 0 { return 0 }
+			if k >= p[0] { return 1 }
+			return betaIncompleta(p[0]-k, k+1, 1-p[1])
+		},
+	},
+	{
+		nombre: "poisson", parametros: "tasa", nParams: 1, discreta: true,
+		validar: func(p []float64) error { return positivo("tasa", p[0]) },
+		densidad: func(k float64, p []float64) float64 {
+			if !esEnteroNoNegativo(k) { return 0 }
+			lk, _ := math.Lgamma(k + 1)
+			return math.Exp(k*math.Log(p[0]) - p[0] - lk)
+		},
+		acumulada: func(k float64, p []float64) float64 {
+			k = math.Floor(k)
+			if k < 0 { return 0 }
+			return 1 - gammaIncompleta(k+1, p[0])
+		},
+	},
+}
+
+// cuantilDe resuelve F⁻¹(q); en las discretas es el menor k con F(k) >= q.
+func (d distribucion) cuantilDe(q float64, p []float64) float64 {
+	if d.cuantil != nil { return d.cuantil(q, p) }
+	if d.discreta {
+		k := math.Floor(invertirAcumulada(func(x float64) float64 { return d.acumulada(x, p) }, q, 0))
+		for k > 0 && d.acumulada(k-1, p) >= q { k-- }
+		for d.acumulada(k, p) < q { k++ }
+		return k
+	}
+	return invertirAcumulada(func(x float64) float64 { return d.acumulada(x, p) }, q, d.desde)
+}
+
+func (d distribucion) aleatorioDe(p []float64) float64 {
+	if d.aleatorio != nil { return d.aleatorio(p) }
+	u := azar().Float64()
+	for u == 0 { u = azar().Float64() }
+	return d.cuantilDe(u, p)
+}
+
+// leerParametros convierte los parámetros de la familia, completando los omitidos.
+func (d distribucion) leerParametros(nombre string, args []interface{}, omitibles bool) ([]float64, error) {
+	faltan := d.nParams - len(args)
+	if len(args) > d.nParams || faltan < 0 || (faltan > 0 && (!omitibles || faltan > len(d.porDefecto))) {
+		return nil, fmt.Errorf("❌ ERROR: '%s' requiere los parámetros: %s", nombre, d.parametros)
+	}
+	p := make([]float64, d.nParams)
+	for i, a := range args {
+		v, err := evaluador.ConvertirAReal(a)
+		if err != nil { return nil, fmt.Errorf("❌ ERROR en '%s': los parámetros (%s) deben ser números", nombre, d.parametros) }
+		p[i] = v
+	}
+	copy(p[len(args):], d.porDefecto[len(d.porDefecto)-faltan:])
+	if err := d.validar(p); err != nil { return nil, fmt.Errorf("❌ ERROR en '%s': %v", nombre, err) }
+	return p, nil
+}
+
+// resultado devuelve enteros para las discretas.
+func (d distribucion) resultado(nombre string, v float64) (interface{}, error) {
+	if d.discreta && !math.IsInf(v, 0) && math.Abs(v) < 1<<53 { return int(v), nil }
+	return finalizar(nombre, v)
+}
+
+func registrarDistribucion(d distribucion) {
+	densidad := d.nombre + "_pdf"
+	if d.discreta { densidad = d.nombre + "_pmf" }
+
+	// <dist>_pdf(x, parámetros) -> densidad (o probabilidad puntual)
+	evaluador.Funciones[densidad] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 { return nil, fmt.Errorf("❌ ERROR: '%s' requiere: x, %s", densidad, d.parametros) }
+		x, err := evaluador.ConvertirAReal(args[0]); if err != nil { return nil, err }
+		p, err := d.leerParametros(densidad, args[1:], true); if err != nil { return nil, err }
+		return finalizar(densidad, d.densidad(x, p))
+	}
+
+	// <dist>_cdf(x, parámetros) -> P(X <= x)
+	nombreCdf := d.nombre + "_cdf"
+	evaluador.Funciones[nombreCdf] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 { return nil, fmt.Errorf("❌ ERROR: '%s' requiere: x, %s", nombreCdf, d.parametros) }
+		x, err := evaluador.ConvertirAReal(args[0]); if err != nil { return nil, err }
+		p, err := d.leerParametros(nombreCdf, args[1:], true); if err != nil { return nil, err }
+		return finalizar(nombreCdf, d.acumulada(x, p))
+	}
+
+	// <dist>_cuantil(q, parámetros) -> x tal que P(X <= x) = q
+	nombreCuantil := d.nombre + "_cuantil"
+	evaluador.Funciones[nombreCuantil] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 { return nil, fmt.Errorf("❌ ERROR: '%s' requiere: probabilidad, %s", nombreCuantil, d.parametros) }
+		q, err := evaluador.ConvertirAReal(args[0]); if err != nil { return nil, err }
+		if !(q > 0 && q < 1) && !(d.discreta && q == 0) { return nil, fmt.Errorf("❌ ERROR en '%s': la probabilidad debe estar entre 0 y 1 (exclusivo)", nombreCuantil) }
+		p, err := d.leerParametros(nombreCuantil, args[1:], true); if err != nil { return nil, err }
+		return d.resultado(nombreCuantil, d.cuantilDe(q, p))
+	}
+
+	// <dist>_aleatorio(parámetros, [n]) -> un valor, o una lista de n valores
+	nombreAleatorio := d.nombre + "_aleatorio"
+	evaluador.Funciones[nombreAleatorio] = func(args ...interface{}) (interface{}, error) {
+		cantidad := -1
+		if len(args) == d.nParams+1 {
+			n, err := validarEntero(nombreAleatorio, args[d.nParams]); if err != nil { return nil, err }
+			if n.Sign() < 0 || !n.IsInt64() || n.Int64() > 10_000_000 { return nil, fmt.Errorf("❌ ERROR en '%s': la cantidad debe estar entre 0 y 10000000", nombreAleatorio) }
+			cantidad = int(n.Int64())
+			args = args[:d.nParams]
+		}
+		p, err := d.leerParametros(nombreAleatorio, args, false); if err != nil { return nil, err }
+		if cantidad < 0 { return d.resultado(nombreAleatorio, d.aleatorioDe(p)) }
+		res := make([]interface{}, cantidad)
+		for i := range res {
+			if res[i], err = d.resultado(nombreAleatorio, d.aleatorioDe(p)); err != nil { return nil, err }
+		}
+		return res, nil
+	}
+	vigilarFuenteSegura(nombreAleatorio)
+}
+
+// --- PRUEBAS DE HIPÓTESIS ---
+
+// valorP según la hipótesis alternativa: "bilateral" (por defecto), "mayor" o "menor".
+func valorP(nombre string, alternativa string, acumulada float64) (float64, error) {
+	switch alternativa {
+	case "", "bilateral", "distinta":
+		return math.Min(1, 2*math.Min(acumulada, 1-acumulada)), nil
+	case "mayor":
+		return 1 - acumulada, nil
+	case "menor":
+		return acumulada, nil
+	}
+	return 0, fmt.Errorf("❌ ERROR en '%s': alternativa desconocida '%s' (usa bilateral, mayor o menor)", nombre, alternativa)
+}
+
+func tAcumulada(t, gl float64) float64 {
+	// Cerca de 0, gl/(gl+t²) se redondea a 1; ahí se usa la forma complementaria.
+	var cola float64
+	if t*t < gl {
+		cola = 0.5 - 0.5*betaIncompleta(0.5, gl/2, t*t/(gl+t*t))
+	} else {
+		cola = 0.5 * betaIncompleta(gl/2, 0.5, gl/(gl+t*t))
+	}
+	if t > 0 { return 1 - cola }
+	return cola
+}
+
+func inyectarDistribucionesGlobal() {
+
+	// --- 1. FAMILIAS: normal, uniforme, exponencial, gamma, chi2, t, f, binomial, poisson ---
+	for _, d := range distribuciones {
+		registrarDistribucion(d)
+	}
+
+	// --- 2. PRUEBA T ---
+
+	// prueba_t(datos, media0, [alternativa])  -> una muestra
+	// prueba_t(a, b, [alternativa])           -> dos muestras (Welch, varianzas distintas)
+	evaluador.Funciones["prueba_t"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 { return nil, fmt.Errorf("❌ ERROR: 'prueba_t' requiere: datos, media0 (o segunda muestra) y [alternativa]") }
+		alternativa := ""
+		if len(args) == 3 {
+			texto, ok := args[2].(string)
+			if !ok { return nil, fmt.Errorf("❌ ERROR en 'prueba_t': la alternativa debe ser texto") }
+			alternativa = texto
+		}
+		a, err := evaluador.ConvertirAListaReal(args[0]); if err != nil { return nil, err }
+		if len(a) < 2 { return nil, fmt.Errorf("❌ ERROR en 'prueba_t': la muestra requiere al menos 2 datos") }
+		mediaA, _ := calcularMedia(a)
+		varA, _ := varianzaDatos("prueba_t", a, true)
+		nA := float64(len(a))
+
+		var t, gl float64
+		res := map[string]interface{}{}
+		if _, esLista := args[1].([]interface{}); esLista {
+			b, err := evaluador.ConvertirAListaReal(args[1]); if err != nil { return nil, err }
+			if len(b) < 2 { return nil, fmt.Errorf("❌ ERROR en 'prueba_t': la segunda muestra requiere al menos 2 datos") }
+			mediaB, _ := calcularMedia(b)
+			varB, _ := varianzaDatos("prueba_t", b, true)
+			nB := float64(len(b))
+			sa, sb := varA/nA, varB/nB
+			if sa+sb == 0 { return nil, fmt.Errorf("❌ ERROR en 'prueba_t': las muestras no tienen dispersión") }
+			t = (mediaA - mediaB) / math.Sqrt(sa+sb)
+			gl = (sa + sb) * (sa + sb) / (sa*sa/(nA-1) + sb*sb/(nB-1))
+			res["media_a"], res["media_b"] = mediaA, mediaB
+		} else {
+			media0, err := evaluador.ConvertirAReal(args[1]); if err != nil { return nil, err }
+			if varA == 0 { return nil, fmt.Errorf("❌ ERROR en 'prueba_t': la muestra no tiene dispersión") }
+			t = (mediaA - media0) / math.Sqrt(varA/nA)
+			gl = nA - 1
+			res["media"] = mediaA
+		}
+		p, err := valorP("prueba_t", alternativa, tAcumulada(t, gl)); if err != nil { return nil, err }
+		res["estadistico"], res["grados_libertad"], res["p_valor"] = t, gl, p
+		return res, nil
+	}
+
+	// prueba_t_pareada(antes, despues, [alternativa]) -> prueba t sobre las diferencias
+	evaluador.Funciones["prueba_t_pareada"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 { return nil, fmt.Errorf("❌ ERROR: 'prueba_t_pareada' requiere: a, b y [alternativa]") }
+		a, err := evaluador.ConvertirAListaReal(args[0]); if err != nil { return nil, err }
+		b, err := evaluador.ConvertirAListaReal(args[1]); if err != nil { return nil, err }
+		if len(a) != len(b) { return nil, fmt.Errorf("❌ ERROR en 'prueba_t_pareada': las muestras tienen %d y %d datos", len(a), len(b)) }
+		diferencias := make([]interface{}, len(a))
+		for i := range a { diferencias[i] = a[i] - b[i] }
+		return evaluador.Funciones["prueba_t"](append([]interface{}{diferencias, 0}, args[2:]...)...)
+	}
+
+	// --- 3. PRUEBA CHI CUADRADO ---
+
+	// prueba_chi2(observados, [esperados]) -> bondad de ajuste (esperados uniformes si se omiten)
+	// prueba_chi2(tabla)                   -> independencia en una tabla de contingencia
+	evaluador.Funciones["prueba_chi2"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 { return nil, fmt.Errorf("❌ ERROR: 'prueba_chi2' requiere: observados y [esperados], o una tabla de contingencia") }
+		var estadistico, gl float64
+		var esperadosRes interface{}
+
+		if len(args) == 1 && esMatrizDatos(args[0]) {
+			tabla, err := convertirAMatriz("prueba_chi2", args[0]); if err != nil { return nil, err }
+			filas, columnas := len(tabla), len(tabla[0])
+			if filas < 2 || columnas < 2 { return nil, fmt.Errorf("❌ ERROR en 'prueba_chi2': la tabla debe ser al menos de 2x2") }
+			sumaFilas, sumaColumnas := make([]float64, filas), make([]float64, columnas)
+			var total float64
+			for i, fila := range tabla {
+				for j, v := range fila {
+					if v < 0 { return nil, fmt.Errorf("❌ ERROR en 'prueba_chi2': las frecuencias no pueden ser negativas") }
+					sumaFilas[i] += v
+					sumaColumnas[j] += v
+					total += v
+				}
+			}
+			esperados := make([]interface{}, filas)
+			for i := range tabla {
+				fila := make([]interface{}, columnas)
+				for j := range tabla[i] {
+					e := sumaFilas[i] * sumaColumnas[j] / total
+					if e == 0 { return nil, fmt.Errorf("❌ ERROR en 'prueba_chi2': hay una fila o columna sin observaciones") }
+					estadistico += (tabla[i][j] - e) * (tabla[i][j] - e) / e
+					fila[j] = e
+				}
+				esperados[i] = fila
+			}
+			gl = float64((filas - 1) * (columnas - 1))
+			esperadosRes = esperados
+		} else {
+			obs, err := evaluador.ConvertirAListaReal(args[0]); if err != nil { return nil, err }
+			if len(obs) < 2 { return nil, fmt.Errorf("❌ ERROR en 'prueba_chi2': se requieren al menos 2 categorías") }
+			var total float64
+			for _, v := range obs { total += v }
+			esp := make([]float64, len(obs))
+			if len(args) == 2 {
+				if esp, err = evaluador.ConvertirAListaReal(args[1]); err != nil { return nil, err }
+				if len(esp) != len(obs) { return nil, fmt.Errorf("❌ ERROR en 'prueba_chi2': %d observados y %d esperados", len(obs), len(esp)) }
+				var totalEsp float64
+				for _, e := range esp { totalEsp += e }
+				// Los esperados pueden darse como proporciones
+				if math.Abs(totalEsp-1) < 1e-9 {
+					for i := range esp { esp[i] *= total }
+				}
+			} else {
+				for i := range esp { esp[i] = total / float64(len(obs)) }
+			}
+			for i := range obs {
+				if esp[i] <= 0 { return nil, fmt.Errorf("❌ ERROR en 'prueba_chi2': los esperados deben ser positivos") }
+				estadistico += (obs[i] - esp[i]) * (obs[i] - esp[i]) / esp[i]
+			}
+			gl = float64(len(obs) - 1)
+			esperadosRes = listaReales(esp)
+		}
+		return map[string]interface{}{
+			"estadistico":     estadistico,
+			"grados_libertad": gl,
+			"p_valor":         1 - gammaIncompleta(gl/2, estadistico/2),
+			"esperados":       esperadosRes,
+		}, nil
+	}
+}
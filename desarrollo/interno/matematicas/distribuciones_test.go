@@ -0,0 +1,78 @@
+package matematicas
+
+import (
+	"math"
+	"testing"
+
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func TestCuantiles(t *testing.T) {
+	casos := []struct {
+		funcion  string
+		args     []interface{}
+		esperado float64
+	}{
+		{"normal_cuantil", []interface{}{0.975}, 1.959963984540054},
+		{"normal_cuantil", []interface{}{0.5, 10, 2}, 10},
+		{"normal_cuantil", []interface{}{0.025, 100, 15}, 100 - 15*1.959963984540054},
+		{"uniforme_cuantil", []interface{}{0.25, 2, 4}, 2.5},
+		{"exponencial_cuantil", []interface{}{0.5, 2}, math.Ln2 / 2},
+		{"gamma_cuantil", []interface{}{0.5, 2, 1}, 1.678346990016661},
+		{"chi2_cuantil", []interface{}{0.95, 5}, 11.070497693516351},
+		{"t_cuantil", []interface{}{0.975, 10}, 2.2281388519649385},
+		{"f_cuantil", []interface{}{0.95, 5, 10}, 3.3258345304130112},
+		{"binomial_cuantil", []interface{}{0.5, 10, 0.5}, 5},
+		{"poisson_cuantil", []interface{}{0.5, 3}, 3},
+	}
+	for _, c := range casos {
+		res, err := evaluador.Funciones[c.funcion](c.args...)
+		if err != nil {
+			t.Errorf("%s%v: %v", c.funcion, c.args, err)
+			continue
+		}
+		v, err := evaluador.ConvertirAReal(res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(v-c.esperado) > 1e-7*math.Max(1, math.Abs(c.esperado)) {
+			t.Errorf("%s%v = %v, se esperaba %v", c.funcion, c.args, v, c.esperado)
+		}
+	}
+}
+
+// El cuantil es la inversa de la acumulada.
+func TestCuantilInversoDeAcumulada(t *testing.T) {
+	for _, d := range []struct {
+		nombre string
+		params []interface{}
+	}{
+		{"normal", []interface{}{1, 3}},
+		{"gamma", []interface{}{3.5, 2}},
+		{"chi2", []interface{}{7}},
+		{"t", []interface{}{4}},
+		{"f", []interface{}{3, 12}},
+	} {
+		for _, p := range []float64{0.001, 0.1, 0.5, 0.9, 0.999} {
+			x, err := evaluador.Funciones[d.nombre+"_cuantil"](append([]interface{}{p}, d.params...)...)
+			if err != nil {
+				t.Fatalf("%s_cuantil(%v): %v", d.nombre, p, err)
+			}
+			q, err := evaluador.Funciones[d.nombre+"_cdf"](append([]interface{}{x}, d.params...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(q.(float64)-p) > 1e-9 {
+				t.Errorf("%s: cdf(cuantil(%v)) = %v", d.nombre, p, q)
+			}
+		}
+	}
+}
+
+func TestCuantilFueraDeRango(t *testing.T) {
+	for _, p := range []interface{}{0.0, 1.0, -0.5, 2} {
+		if _, err := evaluador.Funciones["normal_cuantil"](p); err == nil {
+			t.Errorf("normal_cuantil(%v) debía fallar", p)
+		}
+	}
+}
@@ -102,4 +102,148 @@ func inyectarFinanzasGlobal() {
 		// Formula de Fisher: [(1 + nominal) / (1 + inflacion)] - 1
 		return finalizar("tasa_real", ((1+n)/(1+inf)) - 1)
 	}
+
+	inyectarFinanzasAvanzadas()
+	inyectarFinanzasDecimales()
+	inyectarConvencionesDias()
+}
+
+// inyectarFinanzasDecimales repite las fórmulas anteriores en aritmética decimal
+// exacta: si algún argumento es decimal, el resultado también lo es y no
+// arrastra errores de redondeo binario.
+func inyectarFinanzasDecimales() {
+	uno := evaluador.DecimalDesdeEntero(1)
+	cien := evaluador.DecimalDesdeEntero(100)
+
+	extenderADecimales("interes_simple", 3, func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		return d[0].Multiplicar(d[1]).Multiplicar(d[2]), nil
+	})
+
+	extenderADecimales("interes_compuesto", 3, func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		factor, err := potenciaDecimal(uno.Sumar(d[1]), d[2]); if err != nil { return evaluador.Decimal{}, err }
+		return d[0].Multiplicar(factor), nil
+	})
+
+	descontar := func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		factor, err := potenciaDecimal(uno.Sumar(d[1]), d[2]); if err != nil { return evaluador.Decimal{}, err }
+		return d[0].Dividir(factor)
+	}
+	extenderADecimales("valor_presente", 3, descontar)
+	extenderADecimales("poder_adquisitivo", 3, descontar)
+
+	extenderADecimales("cuota_prestamo", 3, func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		p, i, n := d[0], d[1], d[2]
+		if i.EsCero() { return p.Dividir(n) }
+		factor, err := potenciaDecimal(uno.Sumar(i), n); if err != nil { return evaluador.Decimal{}, err }
+		cociente, err := i.Multiplicar(factor).Dividir(factor.Restar(uno)); if err != nil { return evaluador.Decimal{}, err }
+		return p.Multiplicar(cociente), nil
+	})
+
+	extenderADecimales("total_pagado", 2, func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		return d[0].Multiplicar(d[1]), nil
+	})
+
+	extenderADecimales("roi", 2, func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		r, err := d[0].Dividir(d[1]); if err != nil { return evaluador.Decimal{}, err }
+		return r.Multiplicar(cien), nil
+	})
+
+	extenderADecimales("van", -1, func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		if len(d) < 3 { return evaluador.Decimal{}, fmt.Errorf("requiere: inversion, tasa y al menos 1 flujo") }
+		suma, base := d[0], uno.Sumar(d[1])
+		for t, flujo := range d[2:] {
+			factor, err := base.Potencia(t + 1); if err != nil { return evaluador.Decimal{}, err }
+			presente, err := flujo.Dividir(factor); if err != nil { return evaluador.Decimal{}, err }
+			suma = suma.Sumar(presente)
+		}
+		return suma, nil
+	})
+
+	extenderADecimales("margen_ganancia", 2, func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		r, err := d[0].Restar(d[1]).Dividir(d[0]); if err != nil { return evaluador.Decimal{}, err }
+		return r.Multiplicar(cien), nil
+	})
+
+	extenderADecimales("punto_equilibrio", 3, func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		if d[1].Comparar(d[2]) <= 0 { return evaluador.Decimal{}, fmt.Errorf("el precio debe ser mayor al costo variable") }
+		return d[0].Dividir(d[1].Restar(d[2]))
+	})
+
+	extenderADecimales("tasa_real", 2, func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		r, err := uno.Sumar(d[0]).Dividir(uno.Sumar(d[1])); if err != nil { return evaluador.Decimal{}, err }
+		return r.Restar(uno), nil
+	})
+
+	// --- Valor futuro, anualidades, tasas y depreciación ---
+
+	extenderAnualidadDecimal("valor_futuro", 4, func(d []evaluador.Decimal, anticipada bool) (evaluador.Decimal, error) {
+		if len(d) < 3 { return evaluador.Decimal{}, fmt.Errorf("requiere: capital, tasa y tiempo") }
+		factor, err := potenciaDecimal(uno.Sumar(d[1]), d[2]); if err != nil { return evaluador.Decimal{}, err }
+		res := d[0].Multiplicar(factor)
+		if len(d) == 4 {
+			f, err := factorAnualidadDecimal(d[1], d[2], true, anticipada); if err != nil { return evaluador.Decimal{}, err }
+			res = res.Sumar(d[3].Multiplicar(f))
+		}
+		return res, nil
+	})
+
+	extenderAnualidadDecimal("valor_presente_anualidad", 3, func(d []evaluador.Decimal, anticipada bool) (evaluador.Decimal, error) {
+		f, err := factorAnualidadDecimal(d[1], d[2], false, anticipada); if err != nil { return evaluador.Decimal{}, err }
+		return d[0].Multiplicar(f), nil
+	})
+
+	extenderAnualidadDecimal("valor_futuro_anualidad", 3, func(d []evaluador.Decimal, anticipada bool) (evaluador.Decimal, error) {
+		f, err := factorAnualidadDecimal(d[1], d[2], true, anticipada); if err != nil { return evaluador.Decimal{}, err }
+		return d[0].Multiplicar(f), nil
+	})
+
+	extenderAnualidadDecimal("pago_anualidad", 3, func(d []evaluador.Decimal, anticipada bool) (evaluador.Decimal, error) {
+		f, err := factorAnualidadDecimal(d[1], d[2], false, anticipada); if err != nil { return evaluador.Decimal{}, err }
+		return d[0].Dividir(f)
+	})
+
+	extenderADecimales("tasa_efectiva", 2, func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		periodica, err := d[0].Dividir(d[1]); if err != nil { return evaluador.Decimal{}, err }
+		factor, err := potenciaDecimal(uno.Sumar(periodica), d[1]); if err != nil { return evaluador.Decimal{}, err }
+		return factor.Restar(uno), nil
+	})
+
+	extenderADecimales("depreciacion_lineal", 3, func(d []evaluador.Decimal) (evaluador.Decimal, error) {
+		if d[0].Comparar(d[1]) < 0 { return evaluador.Decimal{}, fmt.Errorf("el valor residual no puede superar el costo") }
+		if d[2].Signo() <= 0 { return evaluador.Decimal{}, fmt.Errorf("la vida útil debe ser positiva") }
+		return d[0].Restar(d[1]).Dividir(d[2])
+	})
+}
+
+// extenderAnualidadDecimal es extenderADecimales para las funciones cuyo
+// último argumento opcional es el tipo de anualidad ("vencida"/"anticipada").
+func extenderAnualidadDecimal(nombre string, posTipo int, f func(d []evaluador.Decimal, anticipada bool) (evaluador.Decimal, error)) {
+	versionReal := evaluador.Funciones[nombre]
+	evaluador.Funciones[nombre] = func(args ...interface{}) (interface{}, error) {
+		montos := args
+		if len(montos) > posTipo { montos = args[:posTipo] }
+		if !hayDecimal(montos) || len(args) > posTipo+1 { return versionReal(args...) }
+		anticipada, err := tipoAnualidad(nombre, args, posTipo); if err != nil { return nil, err }
+		ds, err := validarDecimalesN(nombre, montos); if err != nil { return nil, err }
+		if len(ds) < 3 { return nil, fmt.Errorf("❌ ERROR: '%s' requiere al menos 3 argumentos", nombre) }
+		res, err := f(ds, anticipada)
+		if err != nil { return nil, fmt.Errorf("❌ ERROR en '%s': %w", nombre, err) }
+		return res, nil
+	}
+}
+
+// factorAnualidadDecimal es factorAnualidad en aritmética decimal.
+func factorAnualidadDecimal(tasa, n evaluador.Decimal, futuro, anticipada bool) (evaluador.Decimal, error) {
+	uno := evaluador.DecimalDesdeEntero(1)
+	var f evaluador.Decimal
+	if tasa.EsCero() {
+		f = n
+	} else {
+		p, err := potenciaDecimal(uno.Sumar(tasa), n); if err != nil { return evaluador.Decimal{}, err }
+		divisor := tasa
+		if !futuro { divisor = tasa.Multiplicar(p) }
+		if f, err = p.Restar(uno).Dividir(divisor); err != nil { return evaluador.Decimal{}, err }
+	}
+	if anticipada { f = f.Multiplicar(uno.Sumar(tasa)) }
+	return f, nil
 }
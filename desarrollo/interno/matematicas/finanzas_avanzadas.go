@@ -0,0 +1,532 @@
+package matematicas
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"nepa/desarrollo/interno/evaluador"
+	"strings"
+	"time"
+)
+
+// --- AYUDANTES ---
+
+// validarFecha acepta valores de tipo fecha y textos "AAAA-MM-DD".
+func validarFecha(nombre string, v interface{}) (time.Time, error) {
+	switch f := v.(type) {
+	case time.Time:
+		return f, nil
+	case string:
+		if t, err := evaluador.ParsearFecha(f); err == nil { return t, nil }
+	}
+	return time.Time{}, fmt.Errorf("❌ ERROR en '%s': se esperaba una fecha (AAAA-MM-DD), recibiste %v", nombre, v)
+}
+
+// sumarMeses avanza k meses sin desbordar: 31 de enero + 1 mes = 28/29 de febrero.
+func sumarMeses(t time.Time, k int) time.Time {
+	primero := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).AddDate(0, k, 0)
+	ultimo := primero.AddDate(0, 1, -1).Day()
+	dia := t.Day()
+	if dia > ultimo { dia = ultimo }
+	return primero.AddDate(0, 0, dia-1)
+}
+
+// tipoAnualidad lee "vencida" (pagos al final del periodo, por defecto) o "anticipada".
+func tipoAnualidad(nombre string, args []interface{}, pos int) (bool, error) {
+	if len(args) <= pos { return false, nil }
+	texto, _ := args[pos].(string)
+	switch strings.ToLower(strings.TrimSpace(texto)) {
+	case "vencida", "ordinaria":
+		return false, nil
+	case "anticipada":
+		return true, nil
+	}
+	return false, fmt.Errorf("❌ ERROR en '%s': el tipo de anualidad debe ser \"vencida\" o \"anticipada\"", nombre)
+}
+
+// factorAnualidad devuelve el valor presente (o futuro) de n pagos de 1.
+func factorAnualidad(tasa, n float64, futuro, anticipada bool) float64 {
+	var f float64
+	switch {
+	case tasa == 0:
+		f = n
+	case futuro:
+		f = (math.Pow(1+tasa, n) - 1) / tasa
+	default:
+		f = (1 - math.Pow(1+tasa, -n)) / tasa
+	}
+	if anticipada { f *= 1 + tasa }
+	return f
+}
+
+// valorFlujos es Σ cₖ / (1 + r)^tₖ y su derivada respecto de r.
+func valorFlujos(flujos, tiempos []float64, r float64) (float64, float64) {
+	var v, d float64
+	for k, c := range flujos {
+		descuento := math.Pow(1+r, -tiempos[k])
+		v += c * descuento
+		d -= tiempos[k] * c * descuento / (1 + r)
+	}
+	return v, d
+}
+
+// tasaInterna resuelve Σ cₖ / (1 + r)^tₖ = 0. Primero prueba Newton desde la
+// estimación; si no converge, busca un cambio de signo en una malla de tasas
+// (desde -99.9% hasta 10000%) y lo refina con Brent.
+func tasaInterna(nombre string, flujos, tiempos []float64, estimacion float64) (float64, error) {
+	positivos, negativos := false, false
+	var escala float64
+	for _, c := range flujos {
+		positivos = positivos || c > 0
+		negativos = negativos || c < 0
+		escala = math.Max(escala, math.Abs(c))
+	}
+	if !positivos || !negativos {
+		return 0, fmt.Errorf("❌ ERROR en '%s': los flujos deben tener al menos un ingreso y un egreso", nombre)
+	}
+
+	r := estimacion
+	for i := 0; i < 50 && r > -1; i++ {
+		v, d := valorFlujos(flujos, tiempos, r)
+		if math.Abs(v) <= 1e-10*escala { return r, nil }
+		if d == 0 || math.IsNaN(d) { break }
+		r -= v / d
+	}
+
+	f := func(r float64) (float64, error) { v, _ := valorFlujos(flujos, tiempos, r); return v, nil }
+	const pasos = 2000
+	desde, hasta := math.Log(0.001), math.Log(101.0)
+	mejor, distancia := math.NaN(), math.Inf(1)
+	anterior, vAnterior := 0.0, 0.0
+	for i := 0; i <= pasos; i++ {
+		tasa := math.Exp(desde+(hasta-desde)*float64(i)/pasos) - 1
+		v, _ := f(tasa)
+		if i > 0 && !math.IsNaN(v) && !math.IsInf(v, 0) && (v > 0) != (vAnterior > 0) {
+			raiz, err := raizBrent(f, anterior, tasa); if err != nil { return 0, err }
+			if d := math.Abs(raiz - estimacion); d < distancia { mejor, distancia = raiz, d }
+		}
+		anterior, vAnterior = tasa, v
+	}
+	if math.IsNaN(mejor) { return 0, fmt.Errorf("❌ ERROR en '%s': no se encontró una tasa que anule el valor actual", nombre) }
+	return mejor, nil
+}
+
+// flujosYEstimacion admite tir(lista, [estimacion]) o tir(f0, f1, f2...).
+func flujosYEstimacion(nombre string, args []interface{}) ([]float64, float64, error) {
+	if len(args) >= 1 && len(args) <= 2 {
+		if lista, ok := args[0].([]interface{}); ok {
+			flujos, err := evaluador.ConvertirAListaReal(lista); if err != nil { return nil, 0, err }
+			estimacion := 0.1
+			if len(args) == 2 {
+				if estimacion, err = evaluador.ConvertirAReal(args[1]); err != nil { return nil, 0, err }
+			}
+			return flujos, estimacion, nil
+		}
+	}
+	flujos, err := validarN(nombre, args)
+	return flujos, 0.1, err
+}
+
+// flujosFechados lee los flujos con sus fechas y los expresa en años (días/365)
+// desde la primera fecha, como la TIR.NO.PER de las hojas de cálculo.
+func flujosFechados(nombre string, vFlujos, vFechas interface{}) ([]float64, []float64, error) {
+	flujos, err := evaluador.ConvertirAListaReal(vFlujos); if err != nil { return nil, nil, err }
+	fechas, ok := vFechas.([]interface{})
+	if !ok || len(fechas) != len(flujos) {
+		return nil, nil, fmt.Errorf("❌ ERROR en '%s': se requiere una lista de fechas del mismo tamaño que los flujos", nombre)
+	}
+	tiempos := make([]float64, len(fechas))
+	var inicio time.Time
+	for i, v := range fechas {
+		f, err := validarFecha(nombre, v); if err != nil { return nil, nil, err }
+		if i == 0 { inicio = f }
+		tiempos[i] = f.Sub(inicio).Hours() / 24 / 365
+		if tiempos[i] < 0 { return nil, nil, fmt.Errorf("❌ ERROR en '%s': la fecha %d es anterior a la primera", nombre, i+1) }
+	}
+	return flujos, tiempos, nil
+}
+
+// --- TABLAS DE AMORTIZACIÓN ---
+
+// opcionesAmortizacion lee los argumentos opcionales en cualquier orden: el
+// sistema ("frances", "aleman", "americano"), el formato ("diccionarios" o
+// "matriz"), una fecha de inicio (los periodos se cuentan en meses), una
+// convención de días ("30/360", "actual/365"...) y un calendario ("mx", "es")
+// que mueve las fechas de pago inhábiles al siguiente día hábil.
+type opcionesAmortizacion struct {
+	sistema    string
+	matriz     bool
+	inicio     *time.Time
+	convencion string
+	calendario *evaluador.Calendario
+}
+
+func leerOpcionesAmortizacion(args []interface{}) (opcionesAmortizacion, error) {
+	op := opcionesAmortizacion{sistema: "frances"}
+	for _, a := range args {
+		if f, ok := a.(time.Time); ok {
+			op.inicio = &f
+			continue
+		}
+		texto, ok := a.(string)
+		if !ok { return op, fmt.Errorf("❌ ERROR en 'tabla_amortizacion': opción inválida → %v", a) }
+		switch strings.ToLower(strings.TrimSpace(texto)) {
+		case "frances", "francés":
+			op.sistema = "frances"
+		case "aleman", "alemán":
+			op.sistema = "aleman"
+		case "americano":
+			op.sistema = "americano"
+		case "matriz":
+			op.matriz = true
+		case "diccionarios", "lista":
+			op.matriz = false
+		default:
+			if conv, ok := normalizarConvencion(texto); ok {
+				op.convencion = conv
+				continue
+			}
+			if evaluador.EsNombreCalendario(texto) {
+				cal, err := evaluador.BuscarCalendario(texto); if err != nil { return op, err }
+				op.calendario = cal
+				continue
+			}
+			f, err := validarFecha("tabla_amortizacion", texto)
+			if err != nil { return op, fmt.Errorf("❌ ERROR en 'tabla_amortizacion': opción desconocida '%s' (sistema frances/aleman/americano, formato matriz/diccionarios, fecha de inicio, convención de días o calendario)", texto) }
+			op.inicio = &f
+		}
+	}
+	return op, nil
+}
+
+// filaAmortizacion guarda los montos como float64 o Decimal según la entrada.
+type filaAmortizacion struct {
+	cuota, interes, amortizacion, saldo interface{}
+}
+
+// amortizacionReal calcula la cuota fija con la tasa por periodo; si se dan
+// tasas (una por periodo, según los días de cada uno) el interés usa esas.
+func amortizacionReal(capital, tasa float64, n int, sistema string, tasas []float64) []filaAmortizacion {
+	filas := make([]filaAmortizacion, n)
+	saldo := capital
+	cuotaFija := capital / float64(n)
+	if tasa != 0 { cuotaFija = capital * tasa / (1 - math.Pow(1+tasa, -float64(n))) }
+	for k := 0; k < n; k++ {
+		tasaPeriodo := tasa
+		if tasas != nil { tasaPeriodo = tasas[k] }
+		interes := saldo * tasaPeriodo
+		var amortizacion float64
+		switch sistema {
+		case "frances":
+			amortizacion = cuotaFija - interes
+		case "aleman":
+			amortizacion = capital / float64(n)
+		case "americano":
+			if k == n-1 { amortizacion = saldo }
+		}
+		if k == n-1 { amortizacion = saldo }
+		saldo -= amortizacion
+		if math.Abs(saldo) < 1e-9*math.Max(1, capital) { saldo = 0 }
+		filas[k] = filaAmortizacion{interes + amortizacion, interes, amortizacion, saldo}
+	}
+	return filas
+}
+
+// amortizacionDecimal redondea cada monto a la escala del capital (mínimo
+// centavos); la última cuota absorbe la diferencia para dejar el saldo en cero.
+func amortizacionDecimal(capital, tasa evaluador.Decimal, n int, sistema string, tasas []evaluador.Decimal) ([]filaAmortizacion, error) {
+	escala := capital.Escala()
+	if escala < 2 { escala = 2 }
+	modo := evaluador.ConfigDecimal.Redondeo
+	uno := evaluador.DecimalDesdeEntero(1)
+	periodos := evaluador.DecimalDesdeEntero(int64(n))
+
+	var cuotaFija evaluador.Decimal
+	var err error
+	if tasa.EsCero() || sistema != "frances" {
+		cuotaFija, err = capital.Dividir(periodos)
+	} else {
+		var factor evaluador.Decimal
+		if factor, err = uno.Sumar(tasa).Potencia(n); err == nil {
+			var cociente evaluador.Decimal
+			if cociente, err = tasa.Multiplicar(factor).Dividir(factor.Restar(uno)); err == nil {
+				cuotaFija = capital.Multiplicar(cociente)
+			}
+		}
+	}
+	if err != nil { return nil, err }
+	cuotaFija = cuotaFija.ConEscala(escala, modo)
+
+	filas := make([]filaAmortizacion, n)
+	saldo := capital
+	for k := 0; k < n; k++ {
+		tasaPeriodo := tasa
+		if tasas != nil { tasaPeriodo = tasas[k] }
+		interes := saldo.Multiplicar(tasaPeriodo).ConEscala(escala, modo)
+		var amortizacion evaluador.Decimal
+		switch sistema {
+		case "frances":
+			amortizacion = cuotaFija.Restar(interes)
+		case "aleman":
+			amortizacion = cuotaFija
+		case "americano":
+			amortizacion = evaluador.DecimalDesdeEntero(0)
+		}
+		if k == n-1 { amortizacion = saldo }
+		saldo = saldo.Restar(amortizacion)
+		filas[k] = filaAmortizacion{interes.Sumar(amortizacion), interes, amortizacion, saldo}
+	}
+	return filas, nil
+}
+
+// --- DEPRECIACIÓN ---
+
+// depreciacionPeriodos devuelve la depreciación de cada periodo según el método.
+func depreciacionPeriodos(nombre string, costo, residual float64, vida int, metodo string, factor float64) ([]float64, error) {
+	if costo < residual { return nil, fmt.Errorf("❌ ERROR en '%s': el valor residual no puede superar el costo", nombre) }
+	res := make([]float64, vida)
+	base := costo - residual
+	libros := costo
+	switch metodo {
+	case "lineal":
+		for k := range res { res[k] = base / float64(vida) }
+	case "saldo_decreciente":
+		// Doble saldo decreciente por defecto; nunca baja del valor residual y el
+		// último periodo lleva el libro exactamente al residual
+		tasa := factor / float64(vida)
+		for k := range res {
+			d := math.Min(libros*tasa, libros-residual)
+			if k == vida-1 { d = libros - residual }
+			res[k] = d
+			libros -= d
+		}
+	case "suma_digitos":
+		suma := float64(vida*(vida+1)) / 2
+		for k := range res { res[k] = base * float64(vida-k) / suma }
+	default:
+		return nil, fmt.Errorf("❌ ERROR en '%s': método desconocido '%s' (usa lineal, saldo_decreciente o suma_digitos)", nombre, metodo)
+	}
+	return res, nil
+}
+
+func validarVida(nombre string, v interface{}) (int, error) {
+	n, err := validarEntero(nombre, v); if err != nil { return 0, err }
+	if n.Sign() <= 0 || !n.IsInt64() || n.Int64() > 10_000 { return 0, fmt.Errorf("❌ ERROR en '%s': la vida útil debe ser un entero entre 1 y 10000", nombre) }
+	return int(n.Int64()), nil
+}
+
+func inyectarFinanzasAvanzadas() {
+
+	// --- 1. VALOR FUTURO Y ANUALIDADES ---
+
+	// valor_futuro(capital, tasa, tiempo, [pago], [tipo]) -> capital·(1+i)^n más
+	// el valor futuro de los pagos periódicos (vencidos o anticipados)
+	evaluador.Funciones["valor_futuro"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 3 || len(args) > 5 { return nil, fmt.Errorf("❌ ERROR: 'valor_futuro' requiere: capital, tasa, tiempo, [pago] y [tipo]") }
+		c, i, t, err := validar3("valor_futuro", args[:3]); if err != nil { return nil, err }
+		res := c * math.Pow(1+i, t)
+		if len(args) >= 4 {
+			pago, err := evaluador.ConvertirAReal(args[3]); if err != nil { return nil, err }
+			anticipada, err := tipoAnualidad("valor_futuro", args, 4); if err != nil { return nil, err }
+			res += pago * factorAnualidad(i, t, true, anticipada)
+		}
+		return finalizar("valor_futuro", res)
+	}
+
+	// valor_presente_anualidad(pago, tasa, periodos, [tipo]) -> "vencida" o "anticipada"
+	evaluador.Funciones["valor_presente_anualidad"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 3 || len(args) > 4 { return nil, fmt.Errorf("❌ ERROR: 'valor_presente_anualidad' requiere: pago, tasa, periodos y [tipo]") }
+		pago, i, n, err := validar3("valor_presente_anualidad", args[:3]); if err != nil { return nil, err }
+		anticipada, err := tipoAnualidad("valor_presente_anualidad", args, 3); if err != nil { return nil, err }
+		return finalizar("valor_presente_anualidad", pago*factorAnualidad(i, n, false, anticipada))
+	}
+
+	// valor_futuro_anualidad(pago, tasa, periodos, [tipo])
+	evaluador.Funciones["valor_futuro_anualidad"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 3 || len(args) > 4 { return nil, fmt.Errorf("❌ ERROR: 'valor_futuro_anualidad' requiere: pago, tasa, periodos y [tipo]") }
+		pago, i, n, err := validar3("valor_futuro_anualidad", args[:3]); if err != nil { return nil, err }
+		anticipada, err := tipoAnualidad("valor_futuro_anualidad", args, 3); if err != nil { return nil, err }
+		return finalizar("valor_futuro_anualidad", pago*factorAnualidad(i, n, true, anticipada))
+	}
+
+	// pago_anualidad(valor_presente, tasa, periodos, [tipo]) -> pago periódico
+	evaluador.Funciones["pago_anualidad"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 3 || len(args) > 4 { return nil, fmt.Errorf("❌ ERROR: 'pago_anualidad' requiere: valor presente, tasa, periodos y [tipo]") }
+		vp, i, n, err := validar3("pago_anualidad", args[:3]); if err != nil { return nil, err }
+		anticipada, err := tipoAnualidad("pago_anualidad", args, 3); if err != nil { return nil, err }
+		if n <= 0 { return nil, fmt.Errorf("❌ ERROR en 'pago_anualidad': los periodos deben ser positivos") }
+		return finalizar("pago_anualidad", vp/factorAnualidad(i, n, false, anticipada))
+	}
+
+	// --- 2. TASAS ---
+
+	// tasa_efectiva(nominal, capitalizaciones) -> (1 + j/m)^m - 1
+	evaluador.Funciones["tasa_efectiva"] = func(args ...interface{}) (interface{}, error) {
+		j, m, err := validar2("tasa_efectiva", args); if err != nil { return nil, err }
+		if m <= 0 { return nil, fmt.Errorf("❌ ERROR en 'tasa_efectiva': las capitalizaciones por año deben ser positivas") }
+		return finalizar("tasa_efectiva", math.Pow(1+j/m, m)-1)
+	}
+
+	// tasa_nominal(efectiva, capitalizaciones) -> m·((1 + i)^(1/m) - 1)
+	evaluador.Funciones["tasa_nominal"] = func(args ...interface{}) (interface{}, error) {
+		i, m, err := validar2("tasa_nominal", args); if err != nil { return nil, err }
+		if m <= 0 { return nil, fmt.Errorf("❌ ERROR en 'tasa_nominal': las capitalizaciones por año deben ser positivas") }
+		return finalizar("tasa_nominal", m*(math.Pow(1+i, 1/m)-1))
+	}
+
+	// tasa_periodica(efectiva_anual, periodos_por_año) -> tasa equivalente de cada periodo
+	evaluador.Funciones["tasa_periodica"] = func(args ...interface{}) (interface{}, error) {
+		i, m, err := validar2("tasa_periodica", args); if err != nil { return nil, err }
+		if m <= 0 { return nil, fmt.Errorf("❌ ERROR en 'tasa_periodica': los periodos por año deben ser positivos") }
+		return finalizar("tasa_periodica", math.Pow(1+i, 1/m)-1)
+	}
+
+	// --- 3. TASA INTERNA DE RETORNO ---
+
+	// tir(flujos, [estimacion]) o tir(f0, f1, ...) -> tasa que anula el VAN; f0 suele ser la inversión (negativa)
+	evaluador.Funciones["tir"] = func(args ...interface{}) (interface{}, error) {
+		flujos, estimacion, err := flujosYEstimacion("tir", args); if err != nil { return nil, err }
+		if len(flujos) < 2 { return nil, fmt.Errorf("❌ ERROR: 'tir' requiere al menos 2 flujos") }
+		tiempos := make([]float64, len(flujos))
+		for k := range tiempos { tiempos[k] = float64(k) }
+		r, err := tasaInterna("tir", flujos, tiempos, estimacion); if err != nil { return nil, err }
+		return finalizar("tir", r)
+	}
+
+	// tir_no_periodica(flujos, fechas, [estimacion]) -> tasa anual efectiva (base 365 días)
+	evaluador.Funciones["tir_no_periodica"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 { return nil, fmt.Errorf("❌ ERROR: 'tir_no_periodica' requiere: flujos, fechas y [estimacion]") }
+		flujos, tiempos, err := flujosFechados("tir_no_periodica", args[0], args[1]); if err != nil { return nil, err }
+		estimacion := 0.1
+		if len(args) == 3 {
+			if estimacion, err = evaluador.ConvertirAReal(args[2]); err != nil { return nil, err }
+		}
+		r, err := tasaInterna("tir_no_periodica", flujos, tiempos, estimacion); if err != nil { return nil, err }
+		return finalizar("tir_no_periodica", r)
+	}
+
+	// van_no_periodico(tasa, flujos, fechas) -> Σ cₖ / (1 + tasa)^(días/365)
+	evaluador.Funciones["van_no_periodico"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 3 { return nil, fmt.Errorf("❌ ERROR: 'van_no_periodico' requiere: tasa, flujos y fechas") }
+		tasa, err := evaluador.ConvertirAReal(args[0]); if err != nil { return nil, err }
+		if tasa <= -1 { return nil, fmt.Errorf("❌ ERROR en 'van_no_periodico': la tasa debe ser mayor que -1") }
+		flujos, tiempos, err := flujosFechados("van_no_periodico", args[1], args[2]); if err != nil { return nil, err }
+		v, _ := valorFlujos(flujos, tiempos, tasa)
+		return finalizar("van_no_periodico", v)
+	}
+
+	// --- 4. AMORTIZACIÓN ---
+
+	// tabla_amortizacion(capital, tasa_periodo, periodos, [sistema], [formato], [fecha_inicio], [convencion], [calendario])
+	// -> una fila por periodo: periodo, (fecha), cuota, interes, amortizacion, saldo.
+	// La tasa es siempre la del periodo (mensual), con o sin convención. Con una
+	// convención de días el interés de cada mes se ajusta a su plazo real:
+	// saldo × (tasa × 12) × fraccion_año(fecha anterior, fecha de pago); con 30/360
+	// coincide con saldo × tasa. La cuota fija se calcula con la tasa del periodo.
+	evaluador.Funciones["tabla_amortizacion"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 3 { return nil, fmt.Errorf("❌ ERROR: 'tabla_amortizacion' requiere: capital, tasa por periodo, periodos y opciones [sistema], [formato], [fecha_inicio], [convencion], [calendario]") }
+		op, err := leerOpcionesAmortizacion(args[3:]); if err != nil { return nil, err }
+		nGrande, err := validarEntero("tabla_amortizacion", args[2]); if err != nil { return nil, err }
+		if nGrande.Sign() <= 0 || !nGrande.IsInt64() || nGrande.Int64() > 100_000 { return nil, fmt.Errorf("❌ ERROR en 'tabla_amortizacion': los periodos deben ser un entero entre 1 y 100000") }
+		n := int(nGrande.Int64())
+		if op.inicio == nil && (op.convencion != "" || op.calendario != nil) { return nil, fmt.Errorf("❌ ERROR en 'tabla_amortizacion': la convención de días y el calendario requieren una fecha de inicio") }
+
+		var fechas []time.Time
+		var fracciones []*big.Rat
+		if op.inicio != nil {
+			if fechas, err = fechasDePago(*op.inicio, n, op.calendario); err != nil { return nil, err }
+			if op.convencion != "" {
+				fracciones = make([]*big.Rat, n)
+				for k := range fracciones { fracciones[k] = fraccionAño(fechas[k], fechas[k+1], op.convencion) }
+			}
+		}
+
+		var filas []filaAmortizacion
+		if hayDecimal(args[:2]) {
+			ds, err := validarDecimalesN("tabla_amortizacion", args[:2]); if err != nil { return nil, err }
+			tasa, tasas := ds[1], []evaluador.Decimal(nil)
+			if fracciones != nil {
+				anual := tasa.Multiplicar(evaluador.DecimalDesdeEntero(12))
+				tasas = make([]evaluador.Decimal, n)
+				for k, f := range fracciones {
+					d, err := anual.Multiplicar(evaluador.DecimalDesdeEntero(f.Num().Int64())).Dividir(evaluador.DecimalDesdeEntero(f.Denom().Int64())); if err != nil { return nil, err }
+					tasas[k] = d
+				}
+			}
+			if filas, err = amortizacionDecimal(ds[0], tasa, n, op.sistema, tasas); err != nil { return nil, fmt.Errorf("❌ ERROR en 'tabla_amortizacion': %w", err) }
+		} else {
+			capital, tasa, err := validar2("tabla_amortizacion", args[:2]); if err != nil { return nil, err }
+			if tasa <= -1 { return nil, fmt.Errorf("❌ ERROR en 'tabla_amortizacion': la tasa debe ser mayor que -1") }
+			var tasas []float64
+			if fracciones != nil {
+				tasas = make([]float64, n)
+				for k, f := range fracciones {
+					x, _ := f.Float64()
+					tasas[k] = tasa * 12 * x
+				}
+			}
+			filas = amortizacionReal(capital, tasa, n, op.sistema, tasas)
+		}
+
+		res := make([]interface{}, n)
+		for k, f := range filas {
+			if op.matriz {
+				res[k] = []interface{}{k + 1, f.cuota, f.interes, f.amortizacion, f.saldo}
+				continue
+			}
+			fila := map[string]interface{}{"periodo": k + 1, "cuota": f.cuota, "interes": f.interes, "amortizacion": f.amortizacion, "saldo": f.saldo}
+			if fechas != nil { fila["fecha"] = fechas[k+1] }
+			if op.convencion != "" { fila["dias"] = int(diasConvencion(fechas[k], fechas[k+1], op.convencion)) }
+			res[k] = fila
+		}
+		return res, nil
+	}
+
+	// --- 5. DEPRECIACIÓN ---
+
+	// depreciacion_lineal(costo, residual, vida) -> depreciación de cada periodo
+	evaluador.Funciones["depreciacion_lineal"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 3 { return nil, fmt.Errorf("❌ ERROR: 'depreciacion_lineal' requiere: costo, valor residual y vida útil") }
+		costo, residual, err := validar2("depreciacion_lineal", args[:2]); if err != nil { return nil, err }
+		vida, err := validarVida("depreciacion_lineal", args[2]); if err != nil { return nil, err }
+		d, err := depreciacionPeriodos("depreciacion_lineal", costo, residual, vida, "lineal", 0); if err != nil { return nil, err }
+		return finalizar("depreciacion_lineal", d[0])
+	}
+
+	// depreciacion_saldo_decreciente(costo, residual, vida, periodo, [factor]) -> factor 2 = doble saldo
+	evaluador.Funciones["depreciacion_saldo_decreciente"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 4 || len(args) > 5 { return nil, fmt.Errorf("❌ ERROR: 'depreciacion_saldo_decreciente' requiere: costo, residual, vida, periodo y [factor]") }
+		costo, residual, err := validar2("depreciacion_saldo_decreciente", args[:2]); if err != nil { return nil, err }
+		vida, err := validarVida("depreciacion_saldo_decreciente", args[2]); if err != nil { return nil, err }
+		periodo, err := validarVida("depreciacion_saldo_decreciente", args[3]); if err != nil { return nil, err }
+		if periodo > vida { return nil, fmt.Errorf("❌ ERROR en 'depreciacion_saldo_decreciente': el periodo %d supera la vida útil (%d)", periodo, vida) }
+		factor := 2.0
+		if len(args) == 5 {
+			if factor, err = evaluador.ConvertirAReal(args[4]); err != nil { return nil, err }
+			if factor <= 0 { return nil, fmt.Errorf("❌ ERROR en 'depreciacion_saldo_decreciente': el factor debe ser positivo") }
+		}
+		d, err := depreciacionPeriodos("depreciacion_saldo_decreciente", costo, residual, vida, "saldo_decreciente", factor); if err != nil { return nil, err }
+		return finalizar("depreciacion_saldo_decreciente", d[periodo-1])
+	}
+
+	// tabla_depreciacion(costo, residual, vida, [metodo]) -> lineal, saldo_decreciente o suma_digitos
+	evaluador.Funciones["tabla_depreciacion"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 3 || len(args) > 4 { return nil, fmt.Errorf("❌ ERROR: 'tabla_depreciacion' requiere: costo, residual, vida y [metodo]") }
+		costo, residual, err := validar2("tabla_depreciacion", args[:2]); if err != nil { return nil, err }
+		vida, err := validarVida("tabla_depreciacion", args[2]); if err != nil { return nil, err }
+		metodo := "lineal"
+		if len(args) == 4 {
+			texto, ok := args[3].(string)
+			if !ok { return nil, fmt.Errorf("❌ ERROR en 'tabla_depreciacion': el método debe ser texto") }
+			metodo = strings.ToLower(strings.TrimSpace(texto))
+		}
+		d, err := depreciacionPeriodos("tabla_depreciacion", costo, residual, vida, metodo, 2); if err != nil { return nil, err }
+		res := make([]interface{}, vida)
+		var acumulada float64
+		for k, v := range d {
+			acumulada += v
+			res[k] = map[string]interface{}{"periodo": k + 1, "depreciacion": v, "acumulada": acumulada, "valor_libros": costo - acumulada}
+		}
+		return res, nil
+	}
+}
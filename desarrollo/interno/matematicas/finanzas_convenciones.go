@@ -0,0 +1,154 @@
+package matematicas
+
+import (
+	"fmt"
+	"math/big"
+	"nepa/desarrollo/interno/evaluador"
+	"strings"
+	"time"
+)
+
+// --- CONVENCIONES DE CONTEO DE DÍAS ---
+//
+// Cómo se mide el plazo entre dos fechas para calcular intereses:
+//   30/360         cada mes cuenta 30 días y el año 360 (bono, ISDA)
+//   30e/360        30/360 europeo: el día 31 siempre cuenta como 30
+//   actual/365     días reales entre 365
+//   actual/360     días reales entre 360
+//   actual/actual  días reales entre 365 o 366 según el año (ISDA)
+// "real" es sinónimo de "actual": real/365, real/360, real/real.
+
+var aliasConvencion = map[string]string{
+	"30/360": "30/360", "30u/360": "30/360", "30/360us": "30/360", "bono": "30/360",
+	"30e/360": "30e/360", "30/360e": "30e/360", "eurobono": "30e/360",
+	"actual/365": "actual/365", "act/365": "actual/365", "real/365": "actual/365", "actual/365f": "actual/365",
+	"actual/360": "actual/360", "act/360": "actual/360", "real/360": "actual/360",
+	"actual/actual": "actual/actual", "act/act": "actual/actual", "real/real": "actual/actual",
+}
+
+// normalizarConvencion acepta mayúsculas y espacios: "Actual / 365" → "actual/365".
+func normalizarConvencion(texto string) (string, bool) {
+	c, ok := aliasConvencion[strings.ToLower(strings.ReplaceAll(texto, " ", ""))]
+	return c, ok
+}
+
+func validarConvencion(nombre string, v interface{}) (string, error) {
+	texto, _ := v.(string)
+	if c, ok := normalizarConvencion(texto); ok { return c, nil }
+	return "", fmt.Errorf("❌ ERROR en '%s': convención de días desconocida %v (usa 30/360, 30e/360, actual/365, actual/360 o actual/actual)", nombre, v)
+}
+
+// numeroDeDia numera los días del calendario, sin importar hora ni zona.
+func numeroDeDia(t time.Time) int64 {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+// diasConvencion cuenta los días del plazo según la convención.
+func diasConvencion(desde, hasta time.Time, conv string) int64 {
+	switch conv {
+	case "30/360", "30e/360":
+		d1, d2 := desde.Day(), hasta.Day()
+		if d1 == 31 { d1 = 30 }
+		if d2 == 31 && (d1 == 30 || conv == "30e/360") { d2 = 30 }
+		return int64(360*(hasta.Year()-desde.Year()) + 30*(int(hasta.Month())-int(desde.Month())) + d2 - d1)
+	}
+	return numeroDeDia(hasta) - numeroDeDia(desde)
+}
+
+// fraccionAño devuelve el plazo en años como racional exacto.
+func fraccionAño(desde, hasta time.Time, conv string) *big.Rat {
+	switch conv {
+	case "30/360", "30e/360", "actual/360":
+		return big.NewRat(diasConvencion(desde, hasta, conv), 360)
+	case "actual/365":
+		return big.NewRat(diasConvencion(desde, hasta, conv), 365)
+	}
+	// actual/actual: los días de cada año se dividen entre la duración de ese año
+	if hasta.Before(desde) { return new(big.Rat).Neg(fraccionAño(hasta, desde, conv)) }
+	total := new(big.Rat)
+	for año := desde.Year(); año <= hasta.Year(); año++ {
+		inicio := numeroDeDia(time.Date(año, time.January, 1, 0, 0, 0, 0, time.UTC))
+		fin := numeroDeDia(time.Date(año+1, time.January, 1, 0, 0, 0, 0, time.UTC))
+		a, b := max(inicio, numeroDeDia(desde)), min(fin, numeroDeDia(hasta))
+		if b > a { total.Add(total, big.NewRat(b-a, fin-inicio)) }
+	}
+	return total
+}
+
+// plazoEnFechas lee (desde, hasta, [convencion]); la convención por defecto es actual/365.
+func plazoEnFechas(nombre string, args []interface{}) (*big.Rat, error) {
+	desde, err := validarFecha(nombre, args[0]); if err != nil { return nil, err }
+	hasta, err := validarFecha(nombre, args[1]); if err != nil { return nil, err }
+	conv := "actual/365"
+	if len(args) > 2 {
+		if conv, err = validarConvencion(nombre, args[2]); err != nil { return nil, err }
+	}
+	return fraccionAño(desde, hasta, conv), nil
+}
+
+func esFecha(v interface{}) bool {
+	if _, ok := v.(time.Time); ok { return true }
+	_, err := validarFecha("", v)
+	return err == nil
+}
+
+// aceptarPlazoEnFechas permite dar el tiempo de una fórmula (capital, tasa,
+// tiempo) como dos fechas y una convención: interes_simple(c, i, desde, hasta, "30/360").
+// La tasa es anual y el plazo se convierte a años con la convención.
+func aceptarPlazoEnFechas(nombre string) {
+	anterior := evaluador.Funciones[nombre]
+	evaluador.Funciones[nombre] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 4 || len(args) > 5 || !esFecha(args[2]) { return anterior(args...) }
+		plazo, err := plazoEnFechas(nombre, args[2:]); if err != nil { return nil, err }
+		if hayDecimal(args[:2]) {
+			num := evaluador.DecimalDesdeEntero(plazo.Num().Int64())
+			t, err := num.Dividir(evaluador.DecimalDesdeEntero(plazo.Denom().Int64())); if err != nil { return nil, err }
+			return anterior(args[0], args[1], t)
+		}
+		t, _ := plazo.Float64()
+		return anterior(args[0], args[1], t)
+	}
+}
+
+// fechasDePago devuelve las n+1 fechas de un calendario mensual de pagos; con
+// calendario, las de pago se mueven al siguiente día hábil.
+func fechasDePago(inicio time.Time, n int, cal *evaluador.Calendario) ([]time.Time, error) {
+	fechas := make([]time.Time, n+1)
+	fechas[0] = inicio
+	for k := 1; k <= n; k++ {
+		fechas[k] = sumarMeses(inicio, k)
+		if cal != nil {
+			ajustada, err := cal.Ajustar(fechas[k], "siguiente"); if err != nil { return nil, err }
+			fechas[k] = ajustada
+		}
+	}
+	return fechas, nil
+}
+
+func inyectarConvencionesDias() {
+
+	// dias_entre(desde, hasta, [convencion]) -> días del plazo (reales por defecto)
+	evaluador.Funciones["dias_entre"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 { return nil, fmt.Errorf("❌ ERROR: 'dias_entre' requiere: desde, hasta y [convencion]") }
+		desde, err := validarFecha("dias_entre", args[0]); if err != nil { return nil, err }
+		hasta, err := validarFecha("dias_entre", args[1]); if err != nil { return nil, err }
+		conv := "actual/365"
+		if len(args) == 3 {
+			if conv, err = validarConvencion("dias_entre", args[2]); err != nil { return nil, err }
+		}
+		return int(diasConvencion(desde, hasta, conv)), nil
+	}
+
+	// fraccion_año(desde, hasta, [convencion]) -> plazo en años (actual/365 por defecto)
+	evaluador.Funciones["fraccion_año"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 { return nil, fmt.Errorf("❌ ERROR: 'fraccion_año' requiere: desde, hasta y [convencion]") }
+		plazo, err := plazoEnFechas("fraccion_año", args); if err != nil { return nil, err }
+		f, _ := plazo.Float64()
+		return finalizar("fraccion_año", f)
+	}
+
+	// interes_simple, interes_compuesto y valor_presente con plazo en fechas
+	for _, nombre := range []string{"interes_simple", "interes_compuesto", "valor_presente"} {
+		aceptarPlazoEnFechas(nombre)
+	}
+}
@@ -0,0 +1,88 @@
+package matematicas
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func fecha(t *testing.T, s string) time.Time {
+	t.Helper()
+	f, err := evaluador.ParsearFecha(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestConvencionesDeDias(t *testing.T) {
+	casos := []struct {
+		desde, hasta string
+		convencion   string
+		dias         int64
+		fraccion     *big.Rat
+	}{
+		{"2024-01-31", "2024-02-29", "30/360", 29, big.NewRat(29, 360)},
+		{"2024-02-29", "2024-03-31", "30/360", 32, big.NewRat(32, 360)},
+		{"2024-01-30", "2024-03-31", "30/360", 60, big.NewRat(60, 360)},  // 31 cuenta 30 si el inicio es 30
+		{"2024-01-15", "2024-03-31", "30/360", 76, big.NewRat(76, 360)},  // si no, se queda en 31
+		{"2024-01-15", "2024-03-31", "30e/360", 75, big.NewRat(75, 360)}, // europeo: siempre 30
+		{"2024-01-01", "2024-07-01", "actual/365", 182, big.NewRat(182, 365)},
+		{"2024-01-01", "2024-07-01", "actual/360", 182, big.NewRat(182, 360)},
+		{"2024-01-01", "2025-01-01", "actual/actual", 366, big.NewRat(1, 1)},
+		// medio año en 2023 (184/365) más medio en 2024 (182/366)
+		{"2023-07-01", "2024-07-01", "actual/actual", 366, new(big.Rat).Add(big.NewRat(184, 365), big.NewRat(182, 366))},
+		{"2024-07-01", "2024-01-01", "actual/365", -182, big.NewRat(-182, 365)},
+	}
+	for _, c := range casos {
+		desde, hasta := fecha(t, c.desde), fecha(t, c.hasta)
+		if d := diasConvencion(desde, hasta, c.convencion); d != c.dias {
+			t.Errorf("%s %s→%s: %d días, se esperaban %d", c.convencion, c.desde, c.hasta, d, c.dias)
+		}
+		if f := fraccionAño(desde, hasta, c.convencion); f.Cmp(c.fraccion) != 0 {
+			t.Errorf("%s %s→%s: fracción %s, se esperaba %s", c.convencion, c.desde, c.hasta, f.RatString(), c.fraccion.RatString())
+		}
+	}
+
+	for texto, esperado := range map[string]string{"Actual / 365": "actual/365", "act/act": "actual/actual", "real/360": "actual/360", "30E/360": "30e/360", "bono": "30/360"} {
+		if c, ok := normalizarConvencion(texto); !ok || c != esperado {
+			t.Errorf("normalizarConvencion(%q) = %q, %v", texto, c, ok)
+		}
+	}
+	if _, ok := normalizarConvencion("actual/364"); ok {
+		t.Error("actual/364 no es una convención")
+	}
+}
+
+func TestAmortizacionConConvencion(t *testing.T) {
+	// Con 30/360 cada mes cuenta 30 días: el interés coincide con la tasa del periodo.
+	filas, err := evaluador.Funciones["tabla_amortizacion"](1200, 0.01, 3, "2024-01-15", "30/360")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sin, err := evaluador.Funciones["tabla_amortizacion"](1200, 0.01, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := range filas.([]interface{}) {
+		con, base := filas.([]interface{})[k].(map[string]interface{}), sin.([]interface{})[k].(map[string]interface{})
+		for _, campo := range []string{"cuota", "interes", "saldo"} {
+			if math.Abs(con[campo].(float64)-base[campo].(float64)) > 1e-9 {
+				t.Errorf("periodo %d: %s %v con 30/360, %v sin convención", k+1, campo, con[campo], base[campo])
+			}
+		}
+	}
+
+	// Con actual/365 el interés sigue los días de cada mes: 31 días en enero-febrero
+	filas, err = evaluador.Funciones["tabla_amortizacion"](1200, 0.01, 3, "2024-01-15", "actual/365")
+	if err != nil {
+		t.Fatal(err)
+	}
+	primera := filas.([]interface{})[0].(map[string]interface{})
+	if esperado := 1200 * 0.12 * 31 / 365; math.Abs(primera["interes"].(float64)-esperado) > 1e-9 || primera["dias"] != 31 {
+		t.Errorf("primer periodo: interés %v en %v días, se esperaba %v en 31", primera["interes"], primera["dias"], esperado)
+	}
+}
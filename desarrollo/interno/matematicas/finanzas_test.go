@@ -0,0 +1,59 @@
+package matematicas
+
+import (
+	"math"
+	"testing"
+
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func TestTasaInterna(t *testing.T) {
+	periodos := func(n int) []float64 {
+		res := make([]float64, n)
+		for i := range res {
+			res[i] = float64(i)
+		}
+		return res
+	}
+	casos := []struct {
+		nombre   string
+		flujos   []float64
+		tiempos  []float64
+		esperado float64
+	}{
+		{"bono a la par", []float64{-1000, 100, 100, 1100}, periodos(4), 0.10},
+		{"un solo periodo", []float64{-100, 110}, periodos(2), 0.10},
+		{"tasa negativa", []float64{-100, 50, 40}, periodos(3), -0.06992647},
+		{"flujos irregulares", []float64{-10000, 3000, 4200, 6800}, periodos(4), 0.16340560},
+		{"tasa muy alta", []float64{-1, 30}, periodos(2), 29},
+		{"fechado (años de 365 días)", []float64{-1000, 1100}, []float64{0, 730.0 / 365}, math.Sqrt(1.1) - 1},
+	}
+	for _, c := range casos {
+		r, err := tasaInterna("tir", c.flujos, c.tiempos, 0.1)
+		if err != nil {
+			t.Errorf("%s: %v", c.nombre, err)
+			continue
+		}
+		if math.Abs(r-c.esperado) > 1e-7 {
+			t.Errorf("%s: tasa %v, se esperaba %v", c.nombre, r, c.esperado)
+		}
+		if v, _ := valorFlujos(c.flujos, c.tiempos, r); math.Abs(v) > 1e-6 {
+			t.Errorf("%s: el valor actual a la tasa encontrada es %v", c.nombre, v)
+		}
+	}
+
+	if _, err := tasaInterna("tir", []float64{100, 200}, periodos(2), 0.1); err == nil {
+		t.Error("sin egresos no hay tasa interna y debía fallar")
+	}
+}
+
+func TestTirFunciones(t *testing.T) {
+	r, err := evaluador.Funciones["tir"]([]interface{}{-1000, 100, 100, 1100})
+	if err != nil || math.Abs(r.(float64)-0.1) > 1e-9 {
+		t.Errorf("tir(lista) = %v, %v", r, err)
+	}
+	r, err = evaluador.Funciones["tir"](-100, 110)
+	if err != nil || math.Abs(r.(float64)-0.1) > 1e-9 {
+		t.Errorf("tir(f0, f1) = %v, %v", r, err)
+	}
+}
@@ -13,95 +13,103 @@ const (
 	H_Planck    = 6.62607e-34 // Constante de Planck
 )
 
+// Los argumentos pueden ser cantidades con unidad (100 km, 2 h) o números,
+// que se interpretan en unidades SI. Si algún argumento lleva unidad, el
+// resultado es una cantidad en su unidad SI; con solo números es un número.
 func inyectarFisicaGlobal() {
 
 	// --- 1. CINEMÁTICA (MOVIMIENTO) ---
 
-	// velocidad(distancia, tiempo)
+	// velocidad(distancia, tiempo) -> m/s
 	evaluador.Funciones["velocidad"] = func(args ...interface{}) (interface{}, error) {
-		d, t, err := validar2("velocidad", args); if err != nil { return nil, err }
-		return finalizar("velocidad", d/t)
+		v, err := magnitudes("velocidad", args, "m", "s"); if err != nil { return nil, err }
+		return finalizarCantidad("velocidad", args, v[0]/v[1], "m/s")
 	}
 
-	// posicion_mrua(posicion_inicial, velocidad_inicial, aceleracion, tiempo)
+	// posicion_mrua(posicion_inicial, velocidad_inicial, aceleracion, tiempo) -> m
 	// d = xi + vi*t + 0.5*a*t^2
 	evaluador.Funciones["posicion_mrua"] = func(args ...interface{}) (interface{}, error) {
-		xi, vi, a, t, err := validar4("posicion_mrua", args); if err != nil { return nil, err }
+		v, err := magnitudes("posicion_mrua", args, "m", "m/s", "m/s^2", "s"); if err != nil { return nil, err }
+		xi, vi, a, t := v[0], v[1], v[2], v[3]
 		res := xi + (vi * t) + (0.5 * a * math.Pow(t, 2))
-		return finalizar("posicion_mrua", res)
+		return finalizarCantidad("posicion_mrua", args, res, "m")
 	}
 
 	// --- 2. DINÁMICA Y FUERZAS (NEWTON) ---
 
-	// fuerza(masa, aceleracion) -> F = m * a
+	// fuerza(masa, aceleracion) -> F = m * a [N]
 	evaluador.Funciones["fuerza"] = func(args ...interface{}) (interface{}, error) {
-		m, a, err := validar2("fuerza", args); if err != nil { return nil, err }
-		return finalizar("fuerza", m*a)
+		v, err := magnitudes("fuerza", args, "kg", "m/s^2"); if err != nil { return nil, err }
+		return finalizarCantidad("fuerza", args, v[0]*v[1], "N")
 	}
 
-	// peso(masa, gravedad) -> P = m * g
+	// peso(masa, gravedad) -> P = m * g [N]
 	evaluador.Funciones["peso"] = func(args ...interface{}) (interface{}, error) {
-		m, g, err := validar2("peso", args); if err != nil { return nil, err }
-		return finalizar("peso", m*g)
+		v, err := magnitudes("peso", args, "kg", "m/s^2"); if err != nil { return nil, err }
+		return finalizarCantidad("peso", args, v[0]*v[1], "N")
 	}
 
 	// --- 3. ENERGÍA Y TRABAJO ---
 
-	// energia_cinetica(masa, velocidad) -> Ec = 0.5 * m * v^2
+	// energia_cinetica(masa, velocidad) -> Ec = 0.5 * m * v^2 [J]
 	evaluador.Funciones["energia_cinetica"] = func(args ...interface{}) (interface{}, error) {
-		m, v, err := validar2("energia_cinetica", args); if err != nil { return nil, err }
-		return finalizar("energia_cinetica", 0.5*m*math.Pow(v, 2))
+		v, err := magnitudes("energia_cinetica", args, "kg", "m/s"); if err != nil { return nil, err }
+		return finalizarCantidad("energia_cinetica", args, 0.5*v[0]*math.Pow(v[1], 2), "J")
 	}
 
-	// energia_potencial(masa, gravedad, altura) -> Ep = m * g * h
+	// energia_potencial(masa, gravedad, altura) -> Ep = m * g * h [J]
 	evaluador.Funciones["energia_potencial"] = func(args ...interface{}) (interface{}, error) {
-		m, g, h, err := validar3("energia_potencial", args); if err != nil { return nil, err }
-		return finalizar("energia_potencial", m*g*h)
+		v, err := magnitudes("energia_potencial", args, "kg", "m/s^2", "m"); if err != nil { return nil, err }
+		return finalizarCantidad("energia_potencial", args, v[0]*v[1]*v[2], "J")
 	}
 
-	// energia_masa(masa) -> E = m * c^2 (Einstein)
+	// energia_masa(masa) -> E = m * c^2 (Einstein) [J]
 	evaluador.Funciones["energia_masa"] = func(args ...interface{}) (interface{}, error) {
-		m, err := validar1("energia_masa", args); if err != nil { return nil, err }
-		return finalizar("energia_masa", m*math.Pow(C_Luz, 2))
+		v, err := magnitudes("energia_masa", args, "kg"); if err != nil { return nil, err }
+		return finalizarCantidad("energia_masa", args, v[0]*math.Pow(C_Luz, 2), "J")
 	}
 
 	// --- 4. ASTROFÍSICA (GRAVITACIÓN) ---
 
-	// atraccion_gravitatoria(masa1, masa2, distancia)
+	// atraccion_gravitatoria(masa1, masa2, distancia) [N]
 	// F = G * (m1 * m2) / r^2
 	evaluador.Funciones["atraccion_gravitatoria"] = func(args ...interface{}) (interface{}, error) {
-		m1, m2, r, err := validar3("atraccion_gravitatoria", args); if err != nil { return nil, err }
-		res := G_Universal * (m1 * m2) / math.Pow(r, 2)
-		return finalizar("atraccion_gravitatoria", res)
+		v, err := magnitudes("atraccion_gravitatoria", args, "kg", "kg", "m"); if err != nil { return nil, err }
+		res := G_Universal * (v[0] * v[1]) / math.Pow(v[2], 2)
+		return finalizarCantidad("atraccion_gravitatoria", args, res, "N")
 	}
 
 	// --- 5. MECÁNICA CUÁNTICA BÁSICA ---
 
-	// energia_foton(frecuencia) -> E = h * f
+	// energia_foton(frecuencia) -> E = h * f [J]
 	evaluador.Funciones["energia_foton"] = func(args ...interface{}) (interface{}, error) {
-		f, err := validar1("energia_foton", args); if err != nil { return nil, err }
-		return finalizar("energia_foton", H_Planck*f)
+		v, err := magnitudes("energia_foton", args, "Hz"); if err != nil { return nil, err }
+		return finalizarCantidad("energia_foton", args, H_Planck*v[0], "J")
 	}
 
 	// --- 6. RELATIVIDAD ESPECIAL ---
 
-	// dilatacion_tiempo(tiempo_propio, velocidad)
+	// dilatacion_tiempo(tiempo_propio, velocidad) [s]
 	// t = t0 / sqrt(1 - v^2/c^2)
 	evaluador.Funciones["dilatacion_tiempo"] = func(args ...interface{}) (interface{}, error) {
-		t0, v, err := validar2("dilatacion_tiempo", args); if err != nil { return nil, err }
-		if v >= C_Luz { return nil, fmt.Errorf("❌ ERROR: La velocidad no puede ser mayor o igual a la de la luz") }
-		factor := math.Sqrt(1 - math.Pow(v, 2)/math.Pow(C_Luz, 2))
-		return finalizar("dilatacion_tiempo", t0/factor)
+		v, err := magnitudes("dilatacion_tiempo", args, "s", "m/s"); if err != nil { return nil, err }
+		t0, vel := v[0], v[1]
+		if vel >= C_Luz { return nil, fmt.Errorf("❌ ERROR: La velocidad no puede ser mayor o igual a la de la luz") }
+		factor := math.Sqrt(1 - math.Pow(vel, 2)/math.Pow(C_Luz, 2))
+		return finalizarCantidad("dilatacion_tiempo", args, t0/factor, "s")
 	}
 
 	// --- 7. FLUIDOS Y TERMODINÁMICA ---
 
-	// presion(fuerza, area)
+	// presion(fuerza, area) [Pa]
 	evaluador.Funciones["presion"] = func(args ...interface{}) (interface{}, error) {
-		f, a, err := validar2("presion", args); if err != nil { return nil, err }
-		return finalizar("presion", f/a)
+		v, err := magnitudes("presion", args, "N", "m^2"); if err != nil { return nil, err }
+		return finalizarCantidad("presion", args, v[0]/v[1], "Pa")
 	}
 
+	// Las escalas de temperatura relativas (°C, °F) no son cantidades
+	// multiplicativas: estas conversiones siguen trabajando con números.
+
 	// celsius_a_fahrenheit(c)
 	evaluador.Funciones["celsius_a_fahrenheit"] = func(args ...interface{}) (interface{}, error) {
 		c, err := validar1("celsius_a_fahrenheit", args); if err != nil { return nil, err }
@@ -4,6 +4,8 @@ func init() {
 	inyectarBasicasGlobal()
 	inyectarAlgebraGlobal()
 	inyectarEstadisticaGlobal()
+	inyectarDescriptivaGlobal()
+	inyectarRegresionGlobal()
 	inyectarFinanzasGlobal()
 	inyectarGeometriaGlobal()
 	inyectarFisicaGlobal()
@@ -11,6 +13,15 @@ func init() {
 	// Los nuevos guerreros:
 	inyectarTrigonometriaGlobal()
 	inyectarProbabilidadGlobal()
+	inyectarDistribucionesGlobal()
 	inyectarUnidadesGlobal()
 	inyectarMatricesGlobal()
+	inyectarAlgebraLinealGlobal()
+	inyectarDecimalesGlobal()
+	inyectarRacionalesGlobal()
+	inyectarCantidadesGlobal()
+	inyectarCalculoGlobal()
+	inyectarPolinomiosGlobal()
+	inyectarSimbolicoGlobal()
+	inyectarComplejosGlobal() // Debe ir después de básicas y trigonometría: las extiende
 }
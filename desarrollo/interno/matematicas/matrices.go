@@ -80,7 +80,8 @@ func inyectarMatricesGlobal() {
 	// matriz_transponer(M) -> Cambia filas por columnas
 	evaluador.Funciones["matriz_transponer"] = func(args ...interface{}) (interface{}, error) {
 		if len(args) != 1 { return nil, fmt.Errorf("requiere una matriz") }
-		m := args[0].([][]float64)
+		m, err := convertirAMatriz("matriz_transponer", args[0])
+		if err != nil { return nil, err }
 		
 		filas := len(m)
 		cols := len(m[0])
@@ -125,8 +126,9 @@ func obtener9Valores(args []interface{}) ([]float64, error) {
 
 func validarDosMatrices(args []interface{}) ([][]float64, [][]float64, error) {
 	if len(args) != 2 { return nil, nil, fmt.Errorf("se requieren 2 matrices") }
-	m1, ok1 := args[0].([][]float64)
-	m2, ok2 := args[1].([][]float64)
-	if !ok1 || !ok2 { return nil, nil, fmt.Errorf("argumentos deben ser matrices [][]float64") }
+	m1, err := convertirAMatriz("matriz", args[0])
+	if err != nil { return nil, nil, err }
+	m2, err := convertirAMatriz("matriz", args[1])
+	if err != nil { return nil, nil, err }
 	return m1, m2, nil
 }
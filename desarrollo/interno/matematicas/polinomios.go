@@ -0,0 +1,122 @@
+package matematicas
+
+import (
+	"fmt"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func validarPolinomio(nombre string, v interface{}) (evaluador.Polinomio, error) {
+	if texto, ok := v.(string); ok {
+		return evaluador.ParsearPolinomio(texto)
+	}
+	p, ok := v.(evaluador.Polinomio)
+	if !ok {
+		return evaluador.Polinomio{}, fmt.Errorf("❌ ERROR en '%s': se esperaba un polinomio, recibiste %v", nombre, v)
+	}
+	return p, nil
+}
+
+func inyectarPolinomiosGlobal() {
+
+	// --- 1. CREACIÓN ---
+
+	// polinomio(c0, c1, c2...), polinomio(lista) o polinomio("3x^2 - 2x + 1")
+	// Los coeficientes van en orden ascendente, igual que en poli_evaluar.
+	evaluador.Funciones["polinomio"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 0 { return nil, fmt.Errorf("❌ ERROR: 'polinomio' requiere coeficientes (c0, c1, ...) o un texto como \"3x^2 - 2x + 1\"") }
+		if len(args) == 1 {
+			if texto, ok := args[0].(string); ok {
+				p, err := validarPolinomio("polinomio", texto); if err != nil { return nil, err }
+				return p, nil
+			}
+			if p, ok := args[0].(evaluador.Polinomio); ok { return p, nil }
+		}
+		coef, err := validarN("polinomio", args); if err != nil { return nil, err }
+		return evaluador.NuevoPolinomio(coef, "x"), nil
+	}
+
+	// --- 2. CONSULTA Y EVALUACIÓN ---
+
+	evaluador.Funciones["polinomio.grado"] = func(args ...interface{}) (interface{}, error) {
+		p, err := validarPolinomio("grado", args[0]); if err != nil { return nil, err }
+		return p.Grado(), nil
+	}
+
+	// p.coeficientes() -> [c0, c1, c2...]
+	evaluador.Funciones["polinomio.coeficientes"] = func(args ...interface{}) (interface{}, error) {
+		p, err := validarPolinomio("coeficientes", args[0]); if err != nil { return nil, err }
+		coef := p.Coeficientes()
+		res := make([]interface{}, len(coef))
+		for i, c := range coef { res[i] = c }
+		return res, nil
+	}
+
+	evaluador.Funciones["polinomio.variable"] = func(args ...interface{}) (interface{}, error) {
+		p, err := validarPolinomio("variable", args[0]); if err != nil { return nil, err }
+		return p.Variable(), nil
+	}
+
+	// p.evaluar(x) -> p(x); x puede ser real, complejo o matriz cuadrada
+	evaluador.Funciones["polinomio.evaluar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'evaluar' requiere un punto: p.evaluar(x)") }
+		p, err := validarPolinomio("evaluar", args[0]); if err != nil { return nil, err }
+		return p.EvaluarValor(args[1])
+	}
+
+	// --- 3. DIVISIÓN, DERIVADA E INTEGRAL ---
+
+	// poli_dividir(p, q) -> [cociente, resto]
+	evaluador.Funciones["poli_dividir"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'poli_dividir' requiere: dividendo y divisor") }
+		p, err := validarPolinomio("poli_dividir", args[0]); if err != nil { return nil, err }
+		q, ok := evaluador.ComoPolinomio(args[1], p.Variable())
+		if !ok {
+			if q, err = validarPolinomio("poli_dividir", args[1]); err != nil { return nil, err }
+		}
+		cociente, resto, err := p.Dividir(q); if err != nil { return nil, err }
+		return []interface{}{cociente, resto}, nil
+	}
+
+	// poli_derivar(p, [n]) -> derivada n-ésima exacta
+	evaluador.Funciones["poli_derivar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 { return nil, fmt.Errorf("❌ ERROR: 'poli_derivar' requiere: polinomio y [orden]") }
+		p, err := validarPolinomio("poli_derivar", args[0]); if err != nil { return nil, err }
+		orden := 1
+		if len(args) == 2 {
+			n, err := validarEntero("poli_derivar", args[1]); if err != nil { return nil, err }
+			if n.Sign() < 0 { return nil, fmt.Errorf("❌ ERROR en 'poli_derivar': el orden debe ser un entero no negativo") }
+			// Más allá del grado la derivada ya es cero
+			if !n.IsInt64() || n.Int64() > int64(p.Grado()+1) { n.SetInt64(int64(p.Grado() + 1)) }
+			orden = int(n.Int64())
+		}
+		for i := 0; i < orden; i++ { p = p.Derivar() }
+		return p, nil
+	}
+
+	// poli_integrar(p, [c]) -> primitiva con constante c (0 por defecto)
+	evaluador.Funciones["poli_integrar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 { return nil, fmt.Errorf("❌ ERROR: 'poli_integrar' requiere: polinomio y [constante]") }
+		p, err := validarPolinomio("poli_integrar", args[0]); if err != nil { return nil, err }
+		c := 0.0
+		if len(args) == 2 {
+			if c, err = evaluador.ConvertirAReal(args[1]); err != nil { return nil, err }
+		}
+		return p.Integrar(c), nil
+	}
+
+	// --- 4. RAÍCES (Durand-Kerner) ---
+
+	// poli_raices(p) -> todas las raíces: reales primero (ascendentes), luego complejas
+	evaluador.Funciones["poli_raices"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'poli_raices' requiere un polinomio") }
+		p, err := validarPolinomio("poli_raices", args[0]); if err != nil { return nil, err }
+		raices, err := p.Raices(); if err != nil { return nil, err }
+		return raices, nil
+	}
+
+	// --- 5. MÉTODOS: p.dividir(q), p.derivar(), p.integrar(), p.raices() ---
+	evaluador.Funciones["polinomio.dividir"] = evaluador.Funciones["poli_dividir"]
+	evaluador.Funciones["polinomio.derivar"] = evaluador.Funciones["poli_derivar"]
+	evaluador.Funciones["polinomio.integrar"] = evaluador.Funciones["poli_integrar"]
+	evaluador.Funciones["polinomio.raices"] = evaluador.Funciones["poli_raices"]
+}
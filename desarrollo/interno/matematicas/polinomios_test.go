@@ -0,0 +1,83 @@
+package matematicas
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func TestPolinomios(t *testing.T) {
+	ctx := evaluador.PrepararContextoEvaluador()
+	p, err := evaluador.ParsearPolinomio("3x^2 - 2x + 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.Variables["p"] = p
+	ctx.Variables["q"] = evaluador.NuevoPolinomio([]float64{-1, 1}, "x")
+	ctx.Variables["m"] = [][]float64{{1, 2}, {3, 4}}
+	ctx.Variables["cs"] = []interface{}{-6, 11, -6, 1}
+
+	casos := []struct {
+		expresion string
+		esperado  string
+	}{
+		{`polinomio("3x^2 - 2x + 1")`, "3x^2 - 2x + 1"},
+		{"polinomio(1, 0, -1)", "-x^2 + 1"},
+		{"polinomio(cs)", "x^3 - 6x^2 + 11x - 6"},
+		{`polinomio("2y^3 - y")`, "2y^3 - y"},
+		{"p + q", "3x^2 - x"},
+		{"p - p", "0"},
+		{"p * q", "3x^3 - 5x^2 + 3x - 1"},
+		{"p / q", "3x + 1"},
+		{"p % q", "2"},
+		{"q ^ 3", "x^3 - 3x^2 + 3x - 1"},
+		{"1 + p", "3x^2 - 2x + 2"},
+		{`p == polinomio("1 - 2x + 3x^2")`, "verdadero"},
+		{"p(2)", "9"},
+		{"p.evaluar(1 + 2i)", "-10+8i"},
+		{"p.grado()", "2"},
+		{"p.coeficientes()", "[1, -2, 3]"},
+		{"p.derivar()", "6x - 2"},
+		{"p.integrar(5)", "x^3 - x^2 + x + 5"},
+		{"polinomio(cs).raices()", "[1, 2, 3]"},
+		{`poli_raices("x^4 - 1")`, "[-1, 1, 0+1i, 0-1i]"},
+		{`poli_raices("x^3")`, "[0, 0, 0]"},
+		{`poli_dividir("x^3 - 1", "x - 1")`, "[x^2 + x + 1, 0]"},
+		{"p.dividir(q)", "[3x + 1, 2]"},
+		{`poli_derivar("x^5", 3)`, "60x^2"},
+		{`poli_derivar("x^5", 9)`, "0"},
+		// un polinomio es una función para el cálculo numérico
+		{"integrar(p, 0, 1)", "1"},
+		{"raiz_de(q, 0, 3)", "1"},
+	}
+	for _, c := range casos {
+		r, err := evaluador.EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if s := evaluador.FormatearValor(r); s != c.esperado {
+			t.Errorf("%s = %s, se esperaba %s", c.expresion, s, c.esperado)
+		}
+	}
+
+	// evaluado en una matriz cuadrada: 3M² - 2M + I
+	r, err := evaluador.EvalConContexto("p.evaluar(m)", ctx)
+	if err != nil || fmt.Sprint(r) != "[[20 26] [39 59]]" {
+		t.Errorf("p.evaluar(m) = %v, %v", r, err)
+	}
+
+	for expresion, mensaje := range map[string]string{
+		`polinomio("x + y")`:        "mezcla las variables 'x' y 'y'",
+		`polinomio("3x^")`:          "exponente inválido",
+		"p / polinomio(0)":          "división por el polinomio cero",
+		"poli_raices(polinomio(0))": "infinitas raíces",
+		"p ^ 0.5":                   "exponente entero no negativo",
+	} {
+		if _, err := evaluador.EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
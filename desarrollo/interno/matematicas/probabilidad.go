@@ -1,22 +1,98 @@
 package matematicas
 
 import (
+	"fmt"
 	"math"
+	"math/big"
 	"math/rand"
 	"nepa/desarrollo/interno/evaluador"
-	"time"
 )
 
+// enteroCombinatorio acepta enteros (o reales sin decimales) de tamaño razonable
+// para la combinatoria exacta.
+func enteroCombinatorio(v interface{}) (int64, bool) {
+	b, ok := evaluador.ComoEnteroGrande(v)
+	if !ok || !b.IsInt64() || b.Int64() > 100_000 {
+		return 0, false
+	}
+	return b.Int64(), true
+}
+
+// azar devuelve el generador del programa en curso (reproducible con semilla(n)).
+func azar() *rand.Rand {
+	return evaluador.GeneradorAleatorio().Rand
+}
+
+// vigilarFuenteSegura hace que un fallo de crypto/rand durante la función
+// llegue como error en lugar de como números en cero.
+func vigilarFuenteSegura(nombre string) {
+	f := evaluador.Funciones[nombre]
+	evaluador.Funciones[nombre] = func(args ...interface{}) (interface{}, error) {
+		res, err := f(args...)
+		if fallo := evaluador.GeneradorAleatorio().Fallo(); fallo != nil { return nil, fallo }
+		return res, err
+	}
+}
+
+// listaAleatoria acepta listas y texto (se toma carácter por carácter).
+func listaAleatoria(nombre string, v interface{}) ([]interface{}, error) {
+	switch l := v.(type) {
+	case []interface{}:
+		return l, nil
+	case string:
+		res := []interface{}{}
+		for _, r := range l { res = append(res, string(r)) }
+		return res, nil
+	}
+	return nil, fmt.Errorf("❌ ERROR: '%s' requiere una lista, recibiste %v", nombre, v)
+}
+
 func inyectarProbabilidadGlobal() {
-	rand.Seed(time.Now().UnixNano())
 
 	// --- 1. COMBINATORIA ---
+	// Con enteros el resultado es exacto (entero grande si hace falta: factorial(30));
+	// con reales se mantiene la aproximación por la función Gamma.
+
+	// factorial(n) -> n!
+	evaluador.Funciones["factorial"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'factorial' requiere 1 argumento") }
+		if n, ok := enteroCombinatorio(args[0]); ok {
+			if n < 0 { return nil, fmt.Errorf("❌ ERROR: factorial de un número negativo") }
+			return evaluador.NormalizarEntero(new(big.Int).MulRange(1, n)), nil
+		}
+		v, err := validar1("factorial", args); if err != nil { return nil, err }
+		return finalizar("factorial", math.Gamma(v+1))
+	}
+
 	// combinaciones(n, r) -> n! / (r!(n-r)!)
 	evaluador.Funciones["combinaciones"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 2 {
+			n, okN := enteroCombinatorio(args[0])
+			r, okR := enteroCombinatorio(args[1])
+			if okN && okR {
+				if n < 0 || r < 0 || r > n { return 0, nil }
+				return evaluador.NormalizarEntero(new(big.Int).Binomial(n, r)), nil
+			}
+		}
 		n, r, err := validar2("combinaciones", args); if err != nil { return nil, err }
 		return finalizar("combinaciones", math.Gamma(n+1)/(math.Gamma(r+1)*math.Gamma(n-r+1)))
 	}
 
+	// permutaciones(n, r) -> n! / (n-r)!
+	evaluador.Funciones["permutaciones"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 2 {
+			n, okN := enteroCombinatorio(args[0])
+			r, okR := enteroCombinatorio(args[1])
+			if okN && okR {
+				if n < 0 || r < 0 || r > n { return 0, nil }
+				if r == 0 { return 1, nil }
+				return evaluador.NormalizarEntero(new(big.Int).MulRange(n-r+1, n)), nil
+			}
+		}
+		n, r, err := validar2("permutaciones", args); if err != nil { return nil, err }
+		return finalizar("permutaciones", math.Gamma(n+1)/math.Gamma(n-r+1))
+	}
+
 	// --- 2. DISTRIBUCIONES (La Campana de Gauss) ---
 	// normal_pdf(x, media, desviacion)
 	evaluador.Funciones["distribucion_normal"] = func(args ...interface{}) (interface{}, error) {
@@ -27,8 +103,118 @@ func inyectarProbabilidadGlobal() {
 	}
 
 	// --- 3. GENERADORES ---
+	// Todos usan el generador del programa: semilla(n) o --semilla n los hace reproducibles.
+
+	// semilla(42) -> reinicia el generador; la misma semilla repite la secuencia
+	evaluador.Funciones["semilla"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'semilla' requiere un número entero") }
+		n, err := validarEntero("semilla", args[0]); if err != nil { return nil, err }
+		if !n.IsInt64() { return nil, fmt.Errorf("❌ ERROR en 'semilla': el número es demasiado grande") }
+		evaluador.GeneradorAleatorio().Sembrar(n.Int64())
+		return evaluador.NormalizarEntero(n), nil
+	}
+
+	// fuente_segura(verdadero) -> usa crypto/rand (no reproducible); falso vuelve a la normal
+	evaluador.Funciones["fuente_segura"] = func(args ...interface{}) (interface{}, error) {
+		activar := true
+		if len(args) > 1 { return nil, fmt.Errorf("❌ ERROR: 'fuente_segura' requiere [verdadero | falso]") }
+		if len(args) == 1 {
+			b, ok := args[0].(bool)
+			if !ok { return nil, fmt.Errorf("❌ ERROR en 'fuente_segura': se esperaba verdadero o falso") }
+			activar = b
+		}
+		if err := evaluador.GeneradorAleatorio().UsarFuenteSegura(activar); err != nil { return nil, err }
+		return activar, nil
+	}
+
+	// aleatorio() -> real en [0, 1)
+	evaluador.Funciones["aleatorio"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 0 { return nil, fmt.Errorf("❌ ERROR: 'aleatorio' no recibe argumentos (usa aleatorio_rango o aleatorio_entero)") }
+		return azar().Float64(), nil
+	}
+
 	evaluador.Funciones["aleatorio_rango"] = func(args ...interface{}) (interface{}, error) {
 		min, max, err := validar2("aleatorio_rango", args); if err != nil { return nil, err }
-		return min + rand.Float64()*(max-min), nil
+		return min + azar().Float64()*(max-min), nil
+	}
+
+	// aleatorio_entero(1, 6) -> entero entre ambos extremos, incluidos
+	evaluador.Funciones["aleatorio_entero"] = func(args ...interface{}) (interface{}, error) {
+		min, max, err := validarEnteros2("aleatorio_entero", args); if err != nil { return nil, err }
+		if min.Cmp(max) > 0 { return nil, fmt.Errorf("❌ ERROR en 'aleatorio_entero': el mínimo es mayor que el máximo") }
+		amplitud := new(big.Int).Sub(max, min)
+		amplitud.Add(amplitud, big.NewInt(1))
+		return evaluador.NormalizarEntero(new(big.Int).Add(min, new(big.Int).Rand(azar(), amplitud))), nil
+	}
+
+	// elegir(lista, [pesos]) -> un elemento al azar, opcionalmente ponderado
+	evaluador.Funciones["elegir"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 2 { return nil, fmt.Errorf("❌ ERROR: 'elegir' requiere: lista y [pesos]") }
+		lista, err := listaAleatoria("elegir", args[0]); if err != nil { return nil, err }
+		if len(lista) == 0 { return nil, fmt.Errorf("❌ ERROR: 'elegir' recibió una lista vacía") }
+		if len(args) == 1 { return lista[azar().Intn(len(lista))], nil }
+
+		pesos, err := evaluador.ConvertirAListaReal(args[1]); if err != nil { return nil, err }
+		if len(pesos) != len(lista) { return nil, fmt.Errorf("❌ ERROR en 'elegir': %d elementos y %d pesos", len(lista), len(pesos)) }
+		var total float64
+		for _, w := range pesos {
+			if w < 0 { return nil, fmt.Errorf("❌ ERROR en 'elegir': los pesos no pueden ser negativos") }
+			total += w
+		}
+		if total == 0 { return nil, fmt.Errorf("❌ ERROR en 'elegir': la suma de los pesos es cero") }
+		u := azar().Float64() * total
+		for i, w := range pesos {
+			if u < w { return lista[i], nil }
+			u -= w
+		}
+		return lista[len(lista)-1], nil
+	}
+
+	// barajar(lista) -> nueva lista con los mismos elementos en orden aleatorio
+	evaluador.Funciones["barajar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'barajar' requiere una lista") }
+		lista, err := listaAleatoria("barajar", args[0]); if err != nil { return nil, err }
+		res := append([]interface{}{}, lista...)
+		azar().Shuffle(len(res), func(i, j int) { res[i], res[j] = res[j], res[i] })
+		return res, nil
+	}
+
+	// muestra(lista, k, [con_reemplazo]) -> k elementos; sin reemplazo por defecto
+	evaluador.Funciones["muestra"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 { return nil, fmt.Errorf("❌ ERROR: 'muestra' requiere: lista, k y [con_reemplazo]") }
+		lista, err := listaAleatoria("muestra", args[0]); if err != nil { return nil, err }
+		kGrande, err := validarEntero("muestra", args[1]); if err != nil { return nil, err }
+		conReemplazo := false
+		if len(args) == 3 {
+			b, ok := args[2].(bool)
+			if !ok { return nil, fmt.Errorf("❌ ERROR en 'muestra': con_reemplazo debe ser verdadero o falso") }
+			conReemplazo = b
+		}
+		if kGrande.Sign() < 0 || !kGrande.IsInt64() { return nil, fmt.Errorf("❌ ERROR en 'muestra': k debe ser un entero no negativo") }
+		k := int(kGrande.Int64())
+		if conReemplazo {
+			if len(lista) == 0 && k > 0 { return nil, fmt.Errorf("❌ ERROR: 'muestra' recibió una lista vacía") }
+			if k > 10_000_000 { return nil, fmt.Errorf("❌ ERROR en 'muestra': k es demasiado grande") }
+			res := make([]interface{}, k)
+			for i := range res { res[i] = lista[azar().Intn(len(lista))] }
+			return res, nil
+		}
+		if k > len(lista) { return nil, fmt.Errorf("❌ ERROR en 'muestra': no se pueden tomar %d elementos de %d sin reemplazo", k, len(lista)) }
+		// Fisher-Yates parcial
+		res := append([]interface{}{}, lista...)
+		for i := 0; i < k; i++ {
+			j := i + azar().Intn(len(res)-i)
+			res[i], res[j] = res[j], res[i]
+		}
+		return res[:k], nil
+	}
+
+	for _, nombre := range []string{"aleatorio", "aleatorio_rango", "aleatorio_entero", "elegir", "barajar", "muestra"} {
+		vigilarFuenteSegura(nombre)
+	}
+
+	// Métodos: lista.elegir(), lista.barajar(), lista.muestra(3)
+	for _, nombre := range []string{"elegir", "barajar", "muestra"} {
+		evaluador.Funciones["lista."+nombre] = evaluador.Funciones[nombre]
 	}
 }
@@ -0,0 +1,59 @@
+package matematicas
+
+import (
+	"fmt"
+	"math/big"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func validarRacional(nombre string, v interface{}) (*big.Rat, error) {
+	r, ok := evaluador.ComoRacional(v)
+	if !ok {
+		return nil, fmt.Errorf("❌ ERROR en '%s': no se puede convertir a racional → %v", nombre, v)
+	}
+	return r, nil
+}
+
+func inyectarRacionalesGlobal() {
+
+	// --- 1. CREACIÓN ---
+
+	// racional(num, den) -> fracción exacta simplificada: racional(2, 6) = 1/3
+	// racional(x) -> convierte entero, decimal, real (0.25 → 1/4) o texto ("3/4")
+	evaluador.Funciones["racional"] = func(args ...interface{}) (interface{}, error) {
+		switch len(args) {
+		case 1:
+			r, err := validarRacional("racional", args[0]); if err != nil { return nil, err }
+			return r, nil
+		case 2:
+			num, den, err := validarEnteros2("racional", args); if err != nil { return nil, err }
+			if den.Sign() == 0 { return nil, fmt.Errorf("❌ ERROR en 'racional': denominador cero") }
+			return new(big.Rat).SetFrac(num, den), nil
+		}
+		return nil, fmt.Errorf("❌ ERROR: 'racional' requiere 1 o 2 argumentos: numerador y [denominador]")
+	}
+
+	// --- 2. PARTES ---
+
+	// numerador(r), denominador(r) -> enteros de la fracción reducida
+	evaluador.Funciones["numerador"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'numerador' requiere 1 argumento") }
+		r, err := validarRacional("numerador", args[0]); if err != nil { return nil, err }
+		return evaluador.NormalizarEntero(new(big.Int).Set(r.Num())), nil
+	}
+	evaluador.Funciones["denominador"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'denominador' requiere 1 argumento") }
+		r, err := validarRacional("denominador", args[0]); if err != nil { return nil, err }
+		return evaluador.NormalizarEntero(new(big.Int).Set(r.Denom())), nil
+	}
+
+	// --- 3. MÉTODOS: r.numerador(), r.a_real(), n.es_primo() ---
+	evaluador.Funciones["racional.numerador"] = evaluador.Funciones["numerador"]
+	evaluador.Funciones["racional.denominador"] = evaluador.Funciones["denominador"]
+	evaluador.Funciones["racional.a_real"] = func(args ...interface{}) (interface{}, error) {
+		f, _ := args[0].(*big.Rat).Float64()
+		return f, nil
+	}
+	evaluador.Funciones["entero.es_primo"] = evaluador.Funciones["es_primo"]
+	evaluador.Funciones["entero.factorial"] = evaluador.Funciones["factorial"]
+}
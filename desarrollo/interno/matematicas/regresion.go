@@ -0,0 +1,424 @@
+package matematicas
+
+import (
+	"fmt"
+	"math"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// --- AYUDANTES: MÍNIMOS CUADRADOS ---
+
+// ajuste reúne lo que devuelven todas las regresiones; los errores estándar
+// quedan en NaN cuando no hay grados de libertad para estimarlos.
+type ajuste struct {
+	coeficientes []float64
+	errores      []float64
+	y, predichos []float64
+	parametros   int
+}
+
+// minimosCuadrados resuelve X·β ≈ y por QR (sin formar XᵀX, que empeora la
+// condición en los ajustes polinomiales) y estima la covarianza con R⁻¹R⁻ᵀ.
+func minimosCuadrados(nombre string, x [][]float64, y []float64) (*ajuste, error) {
+	n, p := len(x), len(x[0])
+	if n != len(y) { return nil, fmt.Errorf("❌ ERROR en '%s': %d filas de predictores y %d valores de y", nombre, n, len(y)) }
+	if n < p { return nil, fmt.Errorf("❌ ERROR en '%s': se requieren al menos %d datos para %d coeficientes", nombre, p, p) }
+	q, r := descomponerQR(x)
+
+	var diagonal float64
+	for j := 0; j < p; j++ { diagonal = math.Max(diagonal, math.Abs(r[j][j])) }
+	for j := 0; j < p; j++ {
+		if math.Abs(r[j][j]) <= 1e-12*diagonal || diagonal == 0 {
+			return nil, fmt.Errorf("❌ ERROR en '%s': los predictores son colineales (la columna %d depende de las demás)", nombre, j+1)
+		}
+	}
+
+	// β = R⁻¹·Qᵀy por sustitución hacia atrás
+	qty := make([]float64, p)
+	for j := 0; j < p; j++ {
+		for i := 0; i < n; i++ { qty[j] += q[i][j] * y[i] }
+	}
+	beta := make([]float64, p)
+	for j := p - 1; j >= 0; j-- {
+		s := qty[j]
+		for k := j + 1; k < p; k++ { s -= r[j][k] * beta[k] }
+		beta[j] = s / r[j][j]
+	}
+
+	// R⁻¹ (triangular superior) para la covarianza
+	rInv := make([][]float64, p)
+	for i := range rInv { rInv[i] = make([]float64, p) }
+	for j := 0; j < p; j++ {
+		rInv[j][j] = 1 / r[j][j]
+		for i := j - 1; i >= 0; i-- {
+			var s float64
+			for k := i + 1; k <= j; k++ { s += r[i][k] * rInv[k][j] }
+			rInv[i][j] = -s / r[i][i]
+		}
+	}
+	covarianza := make([][]float64, p)
+	for i := range covarianza {
+		covarianza[i] = make([]float64, p)
+		for j := range covarianza[i] {
+			for k := 0; k < p; k++ { covarianza[i][j] += rInv[i][k] * rInv[j][k] }
+		}
+	}
+
+	a := &ajuste{coeficientes: beta, y: y, predichos: make([]float64, n), parametros: p}
+	for i := range x {
+		for j := range beta { a.predichos[i] += x[i][j] * beta[j] }
+	}
+	a.errores = erroresEstandar(covarianza, a.sumaResiduos(), n, p)
+	return a, nil
+}
+
+// erroresEstandar escala (XᵀX)⁻¹ por la varianza residual s² = SSE / (n - p).
+func erroresEstandar(covarianza [][]float64, sse float64, n, p int) []float64 {
+	res := make([]float64, p)
+	for j := range res {
+		if n <= p { res[j] = math.NaN(); continue }
+		res[j] = math.Sqrt(covarianza[j][j] * sse / float64(n-p))
+	}
+	return res
+}
+
+func (a *ajuste) sumaResiduos() float64 {
+	var sse float64
+	for i := range a.y { sse += (a.y[i] - a.predichos[i]) * (a.y[i] - a.predichos[i]) }
+	return sse
+}
+
+// diccionario presenta el ajuste; r2 se mide siempre sobre la escala original de y.
+func (a *ajuste) diccionario() map[string]interface{} {
+	n, p := len(a.y), a.parametros
+	media, _ := calcularMedia(a.y)
+	var sst float64
+	for _, v := range a.y { sst += (v - media) * (v - media) }
+	sse := a.sumaResiduos()
+
+	nulable := func(v float64) interface{} {
+		if math.IsNaN(v) || math.IsInf(v, 0) { return nil }
+		return v
+	}
+	coeficientes := make([]interface{}, len(a.coeficientes))
+	for i, c := range a.coeficientes {
+		if c == 0 { c = 0 } // sin -0
+		coeficientes[i] = c
+	}
+	residuos := make([]interface{}, n)
+	for i := range a.y { residuos[i] = a.y[i] - a.predichos[i] }
+	errores := make([]interface{}, len(a.errores))
+	for i, e := range a.errores { errores[i] = nulable(e) }
+
+	r2, r2Ajustado, errorResidual := math.NaN(), math.NaN(), math.NaN()
+	if sst > 0 { r2 = 1 - sse/sst }
+	if n > p {
+		r2Ajustado = 1 - (1-r2)*float64(n-1)/float64(n-p)
+		errorResidual = math.Sqrt(sse / float64(n-p))
+	}
+	return map[string]interface{}{
+		"coeficientes":            coeficientes,
+		"errores_estandar":        errores,
+		"r2":                      nulable(r2),
+		"r2_ajustado":             nulable(r2Ajustado),
+		"residuos":                residuos,
+		"predichos":               listaReales(a.predichos),
+		"error_estandar_residual": nulable(errorResidual),
+		"n":                       n,
+		"grados_libertad":         n - p,
+	}
+}
+
+// datosXY lee dos listas numéricas del mismo tamaño.
+func datosXY(nombre string, vx, vy interface{}) ([]float64, []float64, error) {
+	x, err := evaluador.ConvertirAListaReal(vx); if err != nil { return nil, nil, err }
+	y, err := evaluador.ConvertirAListaReal(vy); if err != nil { return nil, nil, err }
+	if len(x) != len(y) { return nil, nil, fmt.Errorf("❌ ERROR en '%s': x tiene %d datos e y tiene %d", nombre, len(x), len(y)) }
+	if len(x) == 0 { return nil, nil, fmt.Errorf("❌ ERROR: '%s' recibió listas vacías", nombre) }
+	return x, y, nil
+}
+
+// derivadaCoeficiente es la derivada de un coeficiente original respecto del transformado.
+type derivadaCoeficiente func(c []float64) float64
+
+// ajusteTransformado ajusta una recta sobre datos transformados (ln y, ln x...)
+// y devuelve los coeficientes en la escala original con sus errores por el
+// método delta; 'modelo' calcula la predicción en la escala original.
+func ajusteTransformado(nombre string, x, y []float64, tx, ty func(float64) float64, originales func(c []float64) []float64, derivadas []derivadaCoeficiente, modelo func(c []float64, x float64) float64) (map[string]interface{}, error) {
+	diseno := make([][]float64, len(x))
+	yt := make([]float64, len(y))
+	for i := range x {
+		xt := tx(x[i])
+		yt[i] = ty(y[i])
+		if math.IsNaN(xt) || math.IsInf(xt, 0) || math.IsNaN(yt[i]) || math.IsInf(yt[i], 0) {
+			return nil, fmt.Errorf("❌ ERROR en '%s': el dato %d (x = %v, y = %v) está fuera del dominio del modelo", nombre, i+1, x[i], y[i])
+		}
+		diseno[i] = []float64{1, xt}
+	}
+	lineal, err := minimosCuadrados(nombre, diseno, yt); if err != nil { return nil, err }
+	coef := originales(lineal.coeficientes)
+	a := &ajuste{coeficientes: coef, y: y, predichos: make([]float64, len(y)), parametros: 2}
+	a.errores = make([]float64, 2)
+	for j := range a.errores { a.errores[j] = math.Abs(derivadas[j](coef)) * lineal.errores[j] }
+	for i := range x { a.predichos[i] = modelo(coef, x[i]) }
+	return a.diccionario(), nil
+}
+
+// --- NO LINEAL: LEVENBERG-MARQUARDT ---
+
+func levenbergMarquardt(nombre string, f evaluador.Funcion, x, y, p0 []float64) (*ajuste, int, error) {
+	n, m := len(x), len(p0)
+	modelo := func(p []float64) ([]float64, error) {
+		res := make([]float64, n)
+		args := make([]float64, m+1)
+		copy(args[1:], p)
+		for i := range x {
+			args[0] = x[i]
+			v, err := f.LlamarReal(args...)
+			if err != nil { return nil, fmt.Errorf("❌ ERROR en '%s' al evaluar %s en x = %v: %w", nombre, f.Etiqueta(), x[i], err) }
+			if math.IsNaN(v) || math.IsInf(v, 0) { return nil, fmt.Errorf("❌ ERROR en '%s': %s no es finita en x = %v", nombre, f.Etiqueta(), x[i]) }
+			res[i] = v
+		}
+		return res, nil
+	}
+	sse := func(pred []float64) float64 {
+		var s float64
+		for i := range y { s += (y[i] - pred[i]) * (y[i] - pred[i]) }
+		return s
+	}
+	// Jacobiano por diferencias centrales
+	jacobiano := func(p []float64) ([][]float64, error) {
+		j := make([][]float64, n)
+		for i := range j { j[i] = make([]float64, m) }
+		for k := 0; k < m; k++ {
+			h := 1e-6 * math.Max(1, math.Abs(p[k]))
+			mas := append([]float64(nil), p...)
+			menos := append([]float64(nil), p...)
+			mas[k] += h
+			menos[k] -= h
+			fMas, err := modelo(mas); if err != nil { return nil, err }
+			fMenos, err := modelo(menos); if err != nil { return nil, err }
+			for i := range j { j[i][k] = (fMas[i] - fMenos[i]) / (2 * h) }
+		}
+		return j, nil
+	}
+	normales := func(j [][]float64) [][]float64 {
+		a := make([][]float64, m)
+		for r := range a {
+			a[r] = make([]float64, m)
+			for c := range a[r] {
+				for i := range j { a[r][c] += j[i][r] * j[i][c] }
+			}
+		}
+		return a
+	}
+
+	p := append([]float64(nil), p0...)
+	pred, err := modelo(p); if err != nil { return nil, 0, err }
+	actual := sse(pred)
+	lambda := 1e-3
+	iteraciones, convergido := 0, false
+	for iteraciones < 500 && !convergido {
+		iteraciones++
+		j, err := jacobiano(p); if err != nil { return nil, 0, err }
+		a := normales(j)
+		g := make([][]float64, m)
+		for k := range g {
+			g[k] = []float64{0}
+			for i := range j { g[k][0] += j[i][k] * (y[i] - pred[i]) }
+		}
+		mejoro := false
+		for intentos := 0; intentos < 30 && !mejoro; intentos++ {
+			amortiguada := copiarMatriz(a)
+			for k := range amortiguada { amortiguada[k][k] += lambda * math.Max(a[k][k], 1e-12) }
+			lu, err := descomponerLU(amortiguada)
+			if err != nil { lambda *= 10; continue }
+			delta := lu.resolver(g)
+			candidato := make([]float64, m)
+			for k := range p { candidato[k] = p[k] + delta[k][0] }
+			predNueva, err := modelo(candidato)
+			if err == nil {
+				if nuevo := sse(predNueva); nuevo <= actual {
+					var paso, escala float64
+					for k := range p { paso += delta[k][0] * delta[k][0]; escala += p[k] * p[k] }
+					convergido = math.Sqrt(paso) <= 1e-10*(math.Sqrt(escala)+1e-10) || actual-nuevo <= 1e-15*actual
+					p, pred, actual = candidato, predNueva, nuevo
+					lambda = math.Max(lambda/10, 1e-15)
+					mejoro = true
+					continue
+				}
+			}
+			lambda *= 10
+		}
+		if !mejoro { break }
+	}
+
+	j, err := jacobiano(p); if err != nil { return nil, 0, err }
+	lu, err := descomponerLU(normales(j))
+	if err != nil { return nil, 0, fmt.Errorf("❌ ERROR en '%s': los parámetros no son identificables con estos datos", nombre) }
+	a := &ajuste{coeficientes: p, y: y, predichos: pred, parametros: m}
+	a.errores = erroresEstandar(lu.resolver(matrizIdentidad(m)), actual, n, m)
+	return a, iteraciones, nil
+}
+
+// --- MATRIZ DE CORRELACIÓN ---
+
+func matrizCorrelacion(nombre string, v interface{}) (interface{}, error) {
+	m, err := convertirAMatriz(nombre, v); if err != nil { return nil, err }
+	filas, cols := len(m), len(m[0])
+	if filas < 2 { return nil, fmt.Errorf("❌ ERROR en '%s': se requieren al menos 2 observaciones (filas)", nombre) }
+	centradas := make([][]float64, cols)
+	normas := make([]float64, cols)
+	for j := 0; j < cols; j++ {
+		columna := make([]float64, filas)
+		for i := range m { columna[i] = m[i][j] }
+		media, _ := calcularMedia(columna)
+		for i := range columna {
+			columna[i] -= media
+			normas[j] += columna[i] * columna[i]
+		}
+		if normas[j] == 0 { return nil, fmt.Errorf("❌ ERROR en '%s': la columna %d es constante", nombre, j+1) }
+		centradas[j] = columna
+	}
+	res := make([][]float64, cols)
+	for a := range res {
+		res[a] = make([]float64, cols)
+		for b := range res[a] {
+			if a == b { res[a][b] = 1; continue }
+			var s float64
+			for i := 0; i < filas; i++ { s += centradas[a][i] * centradas[b][i] }
+			res[a][b] = s / math.Sqrt(normas[a]*normas[b])
+		}
+	}
+	return finalizarMatriz(nombre, res)
+}
+
+func inyectarRegresionGlobal() {
+
+	// --- 1. REGRESIÓN LINEAL MÚLTIPLE ---
+
+	// regresion_multiple(X, y, [intercepto]) -> X es una matriz (una columna por predictor);
+	// coeficientes = [b0, b1, ..., bk] con b0 el intercepto
+	evaluador.Funciones["regresion_multiple"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 || len(args) > 3 { return nil, fmt.Errorf("❌ ERROR: 'regresion_multiple' requiere: matriz X, lista y y [intercepto]") }
+		x, err := convertirAMatriz("regresion_multiple", args[0]); if err != nil { return nil, err }
+		y, err := evaluador.ConvertirAListaReal(args[1]); if err != nil { return nil, err }
+		intercepto := true
+		if len(args) == 3 {
+			b, ok := args[2].(bool)
+			if !ok { return nil, fmt.Errorf("❌ ERROR en 'regresion_multiple': intercepto debe ser verdadero o falso") }
+			intercepto = b
+		}
+		diseno := x
+		if intercepto {
+			diseno = make([][]float64, len(x))
+			for i, fila := range x { diseno[i] = append([]float64{1}, fila...) }
+		}
+		a, err := minimosCuadrados("regresion_multiple", diseno, y); if err != nil { return nil, err }
+		return a.diccionario(), nil
+	}
+
+	// regresion_lineal(X_matriz, y) -> mismo diccionario que regresion_multiple;
+	// con dos listas mantiene [pendiente, intercepto]
+	regresionSimple := evaluador.Funciones["regresion_lineal"]
+	evaluador.Funciones["regresion_lineal"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 2 && esMatrizDatos(args[0]) {
+			return evaluador.Funciones["regresion_multiple"](args...)
+		}
+		return regresionSimple(args...)
+	}
+
+	// --- 2. AJUSTE DE CURVAS ---
+
+	// ajuste_polinomial(x, y, grado) -> coeficientes ascendentes [c0, c1, ...] y el polinomio
+	evaluador.Funciones["ajuste_polinomial"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 3 { return nil, fmt.Errorf("❌ ERROR: 'ajuste_polinomial' requiere: x, y y grado") }
+		x, y, err := datosXY("ajuste_polinomial", args[0], args[1]); if err != nil { return nil, err }
+		g, err := validarEntero("ajuste_polinomial", args[2]); if err != nil { return nil, err }
+		if g.Sign() < 0 || !g.IsInt64() || g.Int64() >= int64(len(x)) {
+			return nil, fmt.Errorf("❌ ERROR en 'ajuste_polinomial': el grado debe estar entre 0 y %d (datos - 1)", len(x)-1)
+		}
+		grado := int(g.Int64())
+		diseno := make([][]float64, len(x))
+		for i := range x {
+			diseno[i] = make([]float64, grado+1)
+			potencia := 1.0
+			for j := range diseno[i] { diseno[i][j] = potencia; potencia *= x[i] }
+		}
+		a, err := minimosCuadrados("ajuste_polinomial", diseno, y); if err != nil { return nil, err }
+		res := a.diccionario()
+		res["polinomio"] = evaluador.NuevoPolinomio(a.coeficientes, "x")
+		return res, nil
+	}
+
+	// ajuste_exponencial(x, y) -> y = a·e^(b·x), coeficientes [a, b]; requiere y > 0
+	evaluador.Funciones["ajuste_exponencial"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'ajuste_exponencial' requiere: x e y") }
+		x, y, err := datosXY("ajuste_exponencial", args[0], args[1]); if err != nil { return nil, err }
+		return ajusteTransformado("ajuste_exponencial", x, y,
+			func(v float64) float64 { return v }, logPositivo,
+			func(c []float64) []float64 { return []float64{math.Exp(c[0]), c[1]} },
+			[]derivadaCoeficiente{func(c []float64) float64 { return c[0] }, func([]float64) float64 { return 1 }},
+			func(c []float64, v float64) float64 { return c[0] * math.Exp(c[1]*v) })
+	}
+
+	// ajuste_logaritmico(x, y) -> y = a + b·ln(x), coeficientes [a, b]; requiere x > 0
+	evaluador.Funciones["ajuste_logaritmico"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'ajuste_logaritmico' requiere: x e y") }
+		x, y, err := datosXY("ajuste_logaritmico", args[0], args[1]); if err != nil { return nil, err }
+		return ajusteTransformado("ajuste_logaritmico", x, y,
+			logPositivo, func(v float64) float64 { return v },
+			func(c []float64) []float64 { return append([]float64(nil), c...) },
+			[]derivadaCoeficiente{func([]float64) float64 { return 1 }, func([]float64) float64 { return 1 }},
+			func(c []float64, v float64) float64 { return c[0] + c[1]*math.Log(v) })
+	}
+
+	// ajuste_potencia(x, y) -> y = a·x^b, coeficientes [a, b]; requiere x > 0 e y > 0
+	evaluador.Funciones["ajuste_potencia"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 { return nil, fmt.Errorf("❌ ERROR: 'ajuste_potencia' requiere: x e y") }
+		x, y, err := datosXY("ajuste_potencia", args[0], args[1]); if err != nil { return nil, err }
+		return ajusteTransformado("ajuste_potencia", x, y,
+			logPositivo, logPositivo,
+			func(c []float64) []float64 { return []float64{math.Exp(c[0]), c[1]} },
+			[]derivadaCoeficiente{func(c []float64) float64 { return c[0] }, func([]float64) float64 { return 1 }},
+			func(c []float64, v float64) float64 { return c[0] * math.Pow(v, c[1]) })
+	}
+
+	// ajuste_no_lineal(f, x, y, [p0...]) -> mínimos cuadrados de f(x, p1, p2, ...)
+	// por Levenberg-Marquardt, partiendo de los parámetros iniciales p0
+	evaluador.Funciones["ajuste_no_lineal"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 4 { return nil, fmt.Errorf("❌ ERROR: 'ajuste_no_lineal' requiere: función f(x, p1, ...), x, y y parámetros iniciales") }
+		f, err := validarFuncion("ajuste_no_lineal", args[0]); if err != nil { return nil, err }
+		x, y, err := datosXY("ajuste_no_lineal", args[1], args[2]); if err != nil { return nil, err }
+		p0, err := evaluador.ConvertirAListaReal(args[3]); if err != nil { return nil, err }
+		if len(p0) == 0 { return nil, fmt.Errorf("❌ ERROR en 'ajuste_no_lineal': se requiere al menos un parámetro inicial") }
+		if f.Parametros != nil && len(f.Parametros) != len(p0)+1 {
+			return nil, fmt.Errorf("❌ ERROR en 'ajuste_no_lineal': %s recibe %d argumentos, se esperaban x y %d parámetros", f.Etiqueta(), len(f.Parametros), len(p0))
+		}
+		if len(x) < len(p0) { return nil, fmt.Errorf("❌ ERROR en 'ajuste_no_lineal': hay menos datos (%d) que parámetros (%d)", len(x), len(p0)) }
+		a, iteraciones, err := levenbergMarquardt("ajuste_no_lineal", f, x, y, p0); if err != nil { return nil, err }
+		res := a.diccionario()
+		res["iteraciones"] = iteraciones
+		return res, nil
+	}
+
+	// --- 3. CORRELACIÓN ---
+
+	// matriz_correlacion(M) -> correlaciones de Pearson entre las columnas de M
+	evaluador.Funciones["matriz_correlacion"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'matriz_correlacion' requiere una matriz (una columna por variable)") }
+		return matrizCorrelacion("matriz_correlacion", args[0])
+	}
+
+	// correlacion_pearson(M) -> matriz de correlación; con dos listas, el coeficiente
+	correlacionSimple := evaluador.Funciones["correlacion_pearson"]
+	evaluador.Funciones["correlacion_pearson"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) == 1 { return matrizCorrelacion("correlacion_pearson", args[0]) }
+		return correlacionSimple(args...)
+	}
+}
+
+func logPositivo(v float64) float64 {
+	if v <= 0 { return math.NaN() }
+	return math.Log(v)
+}
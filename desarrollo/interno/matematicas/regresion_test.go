@@ -0,0 +1,92 @@
+package matematicas
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func TestAjustes(t *testing.T) {
+	ctx := evaluador.PrepararContextoEvaluador()
+	ctx.Variables["mx"] = []interface{}{[]interface{}{1, 2}, []interface{}{2, 1}, []interface{}{3, 4}, []interface{}{4, 3}, []interface{}{5, 6}, []interface{}{6, 5.5}}
+	ctx.Variables["y"] = []interface{}{6.1, 5.9, 12.2, 11.8, 18.1, 17.9}
+	ctx.Variables["x"] = []interface{}{1, 2, 3, 4, 5, 6}
+	ctx.Variables["ye"] = []interface{}{2.7, 7.4, 20.1, 54.6, 148.4, 403.4}
+	ctx.Variables["yp"] = []interface{}{3, 12, 27, 48, 75, 108}
+	ctx.Variables["yl"] = []interface{}{1, 2.386, 3.197, 3.773, 4.218, 4.584}
+	ctx.Variables["p0"] = []interface{}{1, 0.5}
+	ctx.Variables["yc"] = []interface{}{1, 4, 9, 16, 25, 36}
+	ctx.Variables["mc"] = []interface{}{[]interface{}{1, 2, 3}, []interface{}{2, 4, 1}, []interface{}{3, 6.5, 2}, []interface{}{4, 8, 5}}
+
+	casos := []struct {
+		expresion string
+		esperado  []float64
+		r2        float64
+		tol       float64
+	}{
+		{"regresion_multiple(mx, y)", []float64{1.7473509933774787, 1.198344370860927, 1.6907284768211928}, 0.9981966370923421, 1e-9},
+		{"ajuste_polinomial(x, yc, 2)", []float64{0, 0, 1}, 1, 1e-9},
+		{"ajuste_exponencial(x, ye)", []float64{0.9964280669525797, 1.0007997806229838}, 0.9999976767056669, 1e-9},
+		{"ajuste_potencia(x, yp)", []float64{3, 2}, 1, 1e-9},
+		{"ajuste_logaritmico(x, yl)", []float64{0.9998549882498281, 2.000055879684326}, 0.9999998570103541, 1e-9},
+	}
+	for _, c := range casos {
+		r, err := evaluador.EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		res := r.(map[string]interface{})
+		coefs, err := convertirFila(res["coeficientes"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(coefs) != len(c.esperado) {
+			t.Errorf("%s: coeficientes %v, se esperaban %v", c.expresion, coefs, c.esperado)
+			continue
+		}
+		for i := range coefs {
+			if math.Abs(coefs[i]-c.esperado[i]) > c.tol {
+				t.Errorf("%s: coeficientes %v, se esperaban %v", c.expresion, coefs, c.esperado)
+				break
+			}
+		}
+		if r2, _ := evaluador.ConvertirAReal(res["r2"]); math.Abs(r2-c.r2) > 1e-9 {
+			t.Errorf("%s: r2 = %v, se esperaba %v", c.expresion, r2, c.r2)
+		}
+	}
+
+	// Levenberg-Marquardt: y ≈ e^x
+	r, err := evaluador.EvalConContexto("ajuste_no_lineal(funcion(x, a, b) => a*exp(b*x), x, ye, p0)", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coefs, _ := convertirFila(r.(map[string]interface{})["coeficientes"])
+	if math.Abs(coefs[0]-1) > 1e-3 || math.Abs(coefs[1]-1) > 1e-3 {
+		t.Errorf("ajuste_no_lineal: coeficientes %v, se esperaba [1 1]", coefs)
+	}
+
+	r, err = evaluador.EvalConContexto("correlacion_pearson(x, yc)", ctx)
+	if err != nil || math.Abs(r.(float64)-0.978917263677818) > 1e-12 {
+		t.Errorf("correlacion_pearson(x, yc) = %v, %v", r, err)
+	}
+	r, err = evaluador.EvalConContexto("matriz_correlacion(mc)", ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := fmt.Sprint(r); s != "[[1 0.9958616434923835 0.5291502622129182] [0.9958616434923835 1 0.48656626896227284] [0.5291502622129182 0.48656626896227284 1]]" {
+		t.Errorf("matriz_correlacion(mc) = %s", s)
+	}
+
+	for expresion, mensaje := range map[string]string{
+		"ajuste_polinomial(x, yc, 6)": "el grado debe estar entre 0 y 5",
+		"regresion_multiple(mc, y)":   "4 filas de predictores y 6 valores de y",
+	} {
+		if _, err := evaluador.EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package matematicas
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"nepa/desarrollo/interno/evaluador"
 )
 
@@ -61,6 +62,25 @@ func validar4(nombre string, args []interface{}) (float64, float64, float64, flo
 	return v1, v2, v3, v4, nil
 }
 
+// validarEntero exige un entero exacto (int, entero grande o real sin decimales)
+// y lo devuelve como *big.Int, sin pasar por float64.
+func validarEntero(nombre string, v interface{}) (*big.Int, error) {
+	b, ok := evaluador.ComoEnteroGrande(v)
+	if !ok {
+		return nil, fmt.Errorf("❌ ERROR: '%s' requiere números enteros, recibiste %v", nombre, v)
+	}
+	return b, nil
+}
+
+func validarEnteros2(nombre string, args []interface{}) (*big.Int, *big.Int, error) {
+	if len(args) != 2 {
+		return nil, nil, fmt.Errorf("❌ ERROR: '%s' requiere 2 argumentos", nombre)
+	}
+	a, err := validarEntero(nombre, args[0]); if err != nil { return nil, nil, err }
+	b, err := validarEntero(nombre, args[1]); if err != nil { return nil, nil, err }
+	return a, b, nil
+}
+
 // validarN ACTUALIZADO: Ahora soporta matrices y listas
 func validarN(nombre string, args []interface{}) ([]float64, error) {
 	if len(args) == 0 {
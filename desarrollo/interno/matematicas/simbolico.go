@@ -0,0 +1,127 @@
+package matematicas
+
+import (
+	"fmt"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// validarSimbolico acepta expresiones, texto ("x^2 + 1") y números.
+func validarSimbolico(v interface{}) (evaluador.Simbolico, error) {
+	return evaluador.ComoSimbolico(v)
+}
+
+// valoresSustitucion acepta un diccionario {"x": 2} o pares "x", 2, "y", 3.
+func valoresSustitucion(nombre string, args []interface{}) (map[string]interface{}, error) {
+	if len(args) == 1 {
+		if d, ok := args[0].(map[string]interface{}); ok { return d, nil }
+	}
+	if len(args) == 0 || len(args)%2 != 0 {
+		return nil, fmt.Errorf("❌ ERROR: '%s' requiere un diccionario {\"x\": valor} o pares \"x\", valor", nombre)
+	}
+	valores := make(map[string]interface{}, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		variable, ok := args[i].(string)
+		if !ok { return nil, fmt.Errorf("❌ ERROR en '%s': el nombre de la variable debe ser texto, recibiste %v", nombre, args[i]) }
+		valores[variable] = args[i+1]
+	}
+	return valores, nil
+}
+
+func inyectarSimbolicoGlobal() {
+
+	// --- 1. CREACIÓN ---
+
+	// simbolico("x^2*sin(x)") -> expresión sin evaluar
+	evaluador.Funciones["simbolico"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'simbolico' requiere una fórmula: simbolico(\"x^2 + 1\")") }
+		s, err := validarSimbolico(args[0]); if err != nil { return nil, err }
+		return s, nil
+	}
+
+	// --- 2. TRANSFORMACIONES ---
+
+	// simplificar(e) -> 2*x + x = 3*x, x*x = x^2, x/x = 1
+	evaluador.Funciones["simplificar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'simplificar' requiere una expresión") }
+		s, err := validarSimbolico(args[0]); if err != nil { return nil, err }
+		return s.Simplificar(), nil
+	}
+
+	// expandir(e) -> (x + 1)^2 = x^2 + 2*x + 1
+	evaluador.Funciones["expandir"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'expandir' requiere una expresión") }
+		s, err := validarSimbolico(args[0]); if err != nil { return nil, err }
+		return s.Expandir(), nil
+	}
+
+	// factorizar(e) -> x^2 - 5*x + 6 = (x - 3)*(x - 2); factor común y raíces racionales
+	evaluador.Funciones["factorizar"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 { return nil, fmt.Errorf("❌ ERROR: 'factorizar' requiere una expresión") }
+		s, err := validarSimbolico(args[0]); if err != nil { return nil, err }
+		return s.Factorizar(), nil
+	}
+
+	// derivar_simbolico(e, "x", [orden]) -> derivada exacta como expresión
+	evaluador.Funciones["derivar_simbolico"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 1 || len(args) > 3 { return nil, fmt.Errorf("❌ ERROR: 'derivar_simbolico' requiere: expresión, \"variable\" y [orden]") }
+		s, err := validarSimbolico(args[0]); if err != nil { return nil, err }
+		variable := ""
+		if len(args) >= 2 {
+			texto, ok := args[1].(string)
+			if !ok { return nil, fmt.Errorf("❌ ERROR en 'derivar_simbolico': la variable debe ser texto, p. ej. \"x\"") }
+			variable = texto
+		} else {
+			// Sin variable: la única libre de la expresión
+			libres := s.Variables()
+			if len(libres) != 1 { return nil, fmt.Errorf("❌ ERROR en 'derivar_simbolico': indica la variable, la expresión tiene %d", len(libres)) }
+			variable = libres[0]
+		}
+		orden := 1
+		if len(args) == 3 {
+			n, err := validarEntero("derivar_simbolico", args[2]); if err != nil { return nil, err }
+			if n.Sign() < 0 || !n.IsInt64() || n.Int64() > 20 { return nil, fmt.Errorf("❌ ERROR en 'derivar_simbolico': el orden debe estar entre 0 y 20") }
+			orden = int(n.Int64())
+		}
+		for i := 0; i < orden; i++ {
+			if s, err = s.Derivar(variable); err != nil { return nil, err }
+		}
+		return s, nil
+	}
+
+	// sustituir(e, {"x": 2}) o sustituir(e, "x", 2) -> número si no quedan variables
+	evaluador.Funciones["sustituir"] = func(args ...interface{}) (interface{}, error) {
+		if len(args) < 2 { return nil, fmt.Errorf("❌ ERROR: 'sustituir' requiere: expresión y {\"x\": valor}") }
+		s, err := validarSimbolico(args[0]); if err != nil { return nil, err }
+		valores, err := valoresSustitucion("sustituir", args[1:]); if err != nil { return nil, err }
+		res, err := s.Sustituir(valores); if err != nil { return nil, err }
+		if len(res.Variables()) == 0 {
+			return res.Evaluar(nil)
+		}
+		return res, nil
+	}
+
+	// --- 3. MÉTODOS: e.simplificar(), e.derivar("x"), e.sustituir({"x": 2})... ---
+	evaluador.Funciones["simbolico.simplificar"] = evaluador.Funciones["simplificar"]
+	evaluador.Funciones["simbolico.expandir"] = evaluador.Funciones["expandir"]
+	evaluador.Funciones["simbolico.factorizar"] = evaluador.Funciones["factorizar"]
+	evaluador.Funciones["simbolico.derivar"] = evaluador.Funciones["derivar_simbolico"]
+	evaluador.Funciones["simbolico.sustituir"] = evaluador.Funciones["sustituir"]
+
+	// e.variables() -> ["x", "y"]
+	evaluador.Funciones["simbolico.variables"] = func(args ...interface{}) (interface{}, error) {
+		s, err := validarSimbolico(args[0]); if err != nil { return nil, err }
+		res := []interface{}{}
+		for _, v := range s.Variables() { res = append(res, v) }
+		return res, nil
+	}
+
+	// e.evaluar({"x": 2}) -> valor numérico; exige todas las variables
+	evaluador.Funciones["simbolico.evaluar"] = func(args ...interface{}) (interface{}, error) {
+		s, err := validarSimbolico(args[0]); if err != nil { return nil, err }
+		valores := map[string]interface{}{}
+		if len(args) > 1 {
+			if valores, err = valoresSustitucion("evaluar", args[1:]); err != nil { return nil, err }
+		}
+		return s.Evaluar(valores)
+	}
+}
@@ -0,0 +1,74 @@
+package matematicas
+
+import (
+	"strings"
+	"testing"
+
+	"nepa/desarrollo/interno/evaluador"
+)
+
+func TestSimbolico(t *testing.T) {
+	ctx := evaluador.PrepararContextoEvaluador()
+	f, err := evaluador.ParsearSimbolico("x^2*sin(x)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.Variables["f"] = f
+
+	casos := []struct {
+		expresion string
+		esperado  string
+	}{
+		{"f", "x^2*seno(x)"},
+		{`derivar_simbolico(f, "x")`, "x^2*coseno(x) + 2*x*seno(x)"},
+		{`derivar_simbolico("x^3 - 2x + 1", "x")`, "3*x^2 - 2"},
+		{`derivar_simbolico("x^3", "x", 2)`, "6*x"},
+		{`derivar_simbolico("ln(x^2 + 1)", "x")`, "2*x/(x^2 + 1)"},
+		{`derivar_simbolico("seno(x)/x", "x")`, "(x*coseno(x) - seno(x))/x^2"},
+		{`derivar_simbolico("x*y^2", "y")`, "2*x*y"},
+		{`sustituir("x^2 + y", {"x": 3})`, "y + 9"},
+		{`sustituir("x^2 + y", "x", 3, "y", 1)`, "10"},
+		{`sustituir("x + 1", {"x": simbolico("t^2")})`, "t^2 + 1"},
+		{`simplificar("2*x + 3*y - x + 0*z")`, "x + 3*y"},
+		{`simplificar("x*x*x/x")`, "x^2"},
+		{`simplificar("x/3 + x/3")`, "2*x/3"},
+		{`simplificar("exp(log(x))")`, "x"},
+		{`simplificar("a/b/c")`, "a/(b*c)"},
+		{`simplificar("x - (y - x)")`, "2*x - y"},
+		{`simplificar("(-x)^3")`, "-x^3"},
+		{`expandir("(a + b)^3")`, "a^3 + 3*a^2*b + 3*a*b^2 + b^3"},
+		{`expandir("(x - 1)*(x + 1)")`, "x^2 - 1"},
+		{`factorizar("x^2 - 5x + 6")`, "(x - 2)*(x - 3)"},
+		{`factorizar("2x^3 - 2x")`, "2*x*(x + 1)*(x - 1)"},
+		{`factorizar("6x^2 + x - 1")`, "(2*x + 1)*(3*x - 1)"},
+		{`factorizar("x^3 - 1")`, "(x - 1)*(x^2 + x + 1)"},
+		{`factorizar("x^2 + 1")`, "x^2 + 1"},
+		{`simbolico("x") * 2 + simbolico("y")`, "2*x + y"},
+		{`simbolico("x + x") == simbolico("2*x")`, "verdadero"},
+		{`simbolico("3x(x+1)")`, "3*x*(x + 1)"},
+		{`f.variables()`, "[x]"},
+		{`simbolico("x*y").evaluar({"x": 2, "y": 5})`, "10"},
+		// una expresión de una variable se puede integrar numéricamente
+		{`integrar(simbolico("x^2"), 0, 3)`, "9"},
+	}
+	for _, c := range casos {
+		r, err := evaluador.EvalConContexto(c.expresion, ctx)
+		if err != nil {
+			t.Errorf("%s: %v", c.expresion, err)
+			continue
+		}
+		if s := evaluador.FormatearValor(r); s != c.esperado {
+			t.Errorf("%s = %s, se esperaba %s", c.expresion, s, c.esperado)
+		}
+	}
+
+	for expresion, mensaje := range map[string]string{
+		`derivar_simbolico("gamma(x)", "x")`: "no se sabe derivar la función 'gamma'",
+		`simbolico("x + ")`:                  "expresión simbólica inválida",
+		`simbolico("x == 1")`:                "operador '=='",
+	} {
+		if _, err := evaluador.EvalConContexto(expresion, ctx); err == nil || !strings.Contains(err.Error(), mensaje) {
+			t.Errorf("%s: %v", expresion, err)
+		}
+	}
+}
@@ -12,6 +12,13 @@ const (
     _INFO        = "ℹ️ INFO"
 )
 
+// Tipos exportados, para emitir mensajes desde fuera del núcleo
+const (
+    FATAL       = _FATAL
+    ADVERTENCIA = _ADVERTENCIA
+    INFO        = _INFO
+)
+
 // EMITIR ERROR
 func EmitirError(tipo string, archivo string, linea int, codigo int, args ...interface{}) {
     plantilla, ok := MENSAJES_ERROR[codigo]
@@ -11,6 +11,7 @@ type Nodo struct {
     Nombre string        // nombre de variable, función u operador
     Valor  interface{}   // valor literal, expresión o cuerpo de bloque ([]Nodo)
     Args   []interface{} // argumentos de llamadas, índices, operadores o parámetros de función
+    Extra  map[string]interface{} // datos propios de cada instrucción (ej. etapas de pipe en imprimir)
 }
 
 // Parse convierte líneas validadas en un AST
@@ -77,6 +78,12 @@ func Parse(lineas []string) []Nodo {
             continue
         }
 
+        // --- imprimir (antes que las llamadas por sus pipes: imprimir x | f()) ---
+        if esImprimir(linea) {
+            ast = append(ast, *parseImprimir(linea))
+            continue
+        }
+
         // --- Llamadas ---
         if nodo := parseLlamada(linea); nodo != nil {
             ast = append(ast, *nodo)
@@ -19,9 +19,11 @@ import (
 //   - Caracteres de control (\n, \t, \b, etc.)
 //   - Funciones internas/externas, llamadas anidadas
 //   - Matrices y punteros (ej. matriz[2][3], puntero->campo)
-//   - Modo pipe: imprimir <expr> | otraFuncion(...)
+//   - Modo pipe encadenable: imprimir <expr> | f(...) | g(...)
+//     (el valor de la izquierda entra como primer argumento de cada etapa)
 //
-// Devuelve un Nodo{Tipo:"imprimir", Args:[...], Extra:{ opcional: "pipe": Nodo{...}, "modo": "parentesis|inline" }}
+// Devuelve un Nodo{Tipo:"imprimir", Args:[...], Extra:{ "modo": "parentesis|inline",
+// "expresion": texto completo, "argumentos": [textos], opcional "etapas": [textos de cada pipe] }}
 func parseImprimir(linea string) *Nodo {
     if !strings.HasPrefix(strings.TrimSpace(linea), "imprimir") {
         return nil
@@ -34,7 +36,8 @@ func parseImprimir(linea string) *Nodo {
     }
 
     // Detectar modo pipe a nivel toplevel (no dentro de comillas ni paréntesis)
-    parte, pipe := splitTopLevelPipe(resto)
+    etapas := splitTopLevelPipes(resto)
+    parte := etapas[0]
 
     // Determinar modo: paréntesis o inline
     modo := "inline"
@@ -51,11 +54,13 @@ func parseImprimir(linea string) *Nodo {
 
     // Parsear cada argumento, soportando concatenación con . y +
     var args []interface{}
+    var textos []interface{}
     for _, raw := range argStrs {
         raw = strings.TrimSpace(raw)
         if raw == "" {
             continue
         }
+        textos = append(textos, raw)
         // Si el argumento contiene concatenación, tokenizar y convertir a lista de fragmentos
         frags := splitConcatenacionTopLevel(raw)
         if len(frags) == 1 {
@@ -75,17 +80,16 @@ func parseImprimir(linea string) *Nodo {
     n := &Nodo{
         Tipo:  "imprimir",
         Args:  args,
-        Extra: map[string]interface{}{"modo": modo},
+        Extra: map[string]interface{}{"modo": modo, "expresion": resto, "argumentos": textos},
     }
 
-    // Si hay pipe, parsearlo como llamada y guardarlo en Extra["pipe"]
-    if pipe != "" {
-        pipe = strings.TrimSpace(pipe)
-        if nodoCall := parseLlamada(pipe); nodoCall != nil {
-            n.Extra["pipe"] = *nodoCall
-        } else {
-            n.Extra["pipe"] = Nodo{Tipo: "expresion", Valor: pipe}
+    // Si hay pipes, guardar el texto de cada etapa en orden
+    if len(etapas) > 1 {
+        var pipes []interface{}
+        for _, e := range etapas[1:] {
+            pipes = append(pipes, e)
         }
+        n.Extra["etapas"] = pipes
     }
 
     return n
@@ -187,81 +191,63 @@ func splitConcatenacionTopLevel(s string) []string {
     return res
 }
 
-// splitTopLevelPipe: separa la parte principal y el pipe a nivel toplevel (respeta comillas y paréntesis)
-func splitTopLevelPipe(s string) (string, string) {
-    var left strings.Builder
-    var right strings.Builder
+// splitTopLevelPipes: separa la parte principal y cada etapa de pipe a nivel
+// toplevel (respeta comillas, paréntesis, corchetes y llaves; '||' no es pipe)
+func splitTopLevelPipes(s string) []string {
+    var res []string
+    var buf strings.Builder
     quote := rune(0)
-    par := 0
-    found := false
+    nivel := 0
+    runas := []rune(s)
 
-    for i, r := range s {
+    for i := 0; i < len(runas); i++ {
+        r := runas[i]
         switch {
         case quote != 0:
-            if !found {
-                left.WriteRune(r)
-            } else {
-                right.WriteRune(r)
-            }
-            if r == quote && (i == 0 || s[i-1] != '\\') {
+            if r == '\\' && i+1 < len(runas) {
+                buf.WriteRune(r)
+                i++
+                r = runas[i]
+            } else if r == quote {
                 quote = 0
             }
-        default:
-            switch r {
-            case '"', '\'':
-                quote = r
-                if !found {
-                    left.WriteRune(r)
-                } else {
-                    right.WriteRune(r)
-                }
-            case '(':
-                par++
-                if !found {
-                    left.WriteRune(r)
-                } else {
-                    right.WriteRune(r)
-                }
-            case ')':
-                if par > 0 {
-                    par--
-                }
-                if !found {
-                    left.WriteRune(r)
-                } else {
-                    right.WriteRune(r)
-                }
-            case '|':
-                if par == 0 && !found {
-                    found = true
-                } else {
-                    if !found {
-                        left.WriteRune(r)
-                    } else {
-                        right.WriteRune(r)
-                    }
-                }
-            default:
-                if !found {
-                    left.WriteRune(r)
-                } else {
-                    right.WriteRune(r)
-                }
+        case r == '"' || r == '\'' || r == '`':
+            quote = r
+        case r == '(' || r == '[' || r == '{':
+            nivel++
+        case r == ')' || r == ']' || r == '}':
+            if nivel > 0 {
+                nivel--
             }
+        case r == '|' && nivel == 0:
+            if i+1 < len(runas) && runas[i+1] == '|' {
+                buf.WriteString("||")
+                i++
+                continue
+            }
+            res = append(res, strings.TrimSpace(buf.String()))
+            buf.Reset()
+            continue
         }
+        buf.WriteRune(r)
     }
-    return strings.TrimSpace(left.String()), strings.TrimSpace(right.String())
+    return append(res, strings.TrimSpace(buf.String()))
+}
+
+// esImprimir: la línea empieza con la palabra imprimir (no "imprimirX")
+func esImprimir(trim string) bool {
+    if !strings.HasPrefix(trim, "imprimir") {
+        return false
+    }
+    return !(len(trim) > len("imprimir") && unicode.IsLetter(rune(trim[len("imprimir")])))
 }
 
 // --- Registro en Parsers ---
 func init() {
     Parsers["imprimir"] = func(linea string) *Nodo {
         trim := strings.TrimSpace(linea)
-        if !strings.HasPrefix(trim, "imprimir") {
-            return nil
-        }
         // Evitar confundir con otras palabras que comiencen igual (ej. "imprimirX")
-        if len(trim) > len("imprimir") && unicode.IsLetter(rune(trim[len("imprimir")])) {
+        if !esImprimir(trim) {
             return nil
         }
         return parseImprimir(trim)
@@ -6,6 +6,7 @@ import (
 
 // Tipos base disponibles según desarrollo/interno/variables/
 var TiposBase = map[string]bool{
+    "archivo":     true,
     "bit":         true,
     "booleano":    true,
     "cadena":      true,
@@ -13,14 +14,19 @@ var TiposBase = map[string]bool{
     "complejo":    true,
     "decimal":     true,
     "diccionario": true,
+    "duracion":    true,
     "entero":      true,
     "fecha":       true,
+    "funcion":     true,
     "hora":        true,
     "lista":       true,
     "matriz":      true,
     "objeto":      true,
+    "polinomio":   true,
     "puntero":     true,
+    "racional":    true,
     "real":        true,
+    "simbolico":   true,
     "texto":       true,
     "tiempo":      true,
 }
@@ -0,0 +1,45 @@
+// Package valores reúne las conversiones de valores que necesitan tanto el
+// evaluador como los paquetes de variables; no depende de ningún otro paquete
+// de nepa para que las variables puedan usarlo sin arrastrar al evaluador.
+package valores
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// FormatearComplejo muestra un complejo en forma binómica: 3+4i, 1.5-2i.
+func FormatearComplejo(c complex128) string {
+	re := strconv.FormatFloat(real(c), 'f', -1, 64)
+	im := strconv.FormatFloat(imag(c), 'f', -1, 64)
+	if imag(c) < 0 || (imag(c) == 0 && im[0] == '-') {
+		return re + im + "i"
+	}
+	return re + "+" + im + "i"
+}
+
+// ComoComplejo reconoce un complejo o un número real, que se promueve con
+// parte imaginaria cero. Los decimales entran por su método Float64.
+func ComoComplejo(v interface{}) (complex128, bool) {
+	switch x := v.(type) {
+	case complex128:
+		return x, true
+	case complex64:
+		return complex128(x), true
+	case int:
+		return complex(float64(x), 0), true
+	case int64:
+		return complex(float64(x), 0), true
+	case float64:
+		return complex(x, 0), true
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(x).Float64()
+		return complex(f, 0), true
+	case *big.Rat:
+		f, _ := x.Float64()
+		return complex(f, 0), true
+	case interface{ Float64() float64 }:
+		return complex(x.Float64(), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,83 @@
+package valores
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// formatosFechaTexto se prueban en orden; un nombre de zona IANA al final
+// ("2024-05-01 09:00:00 Europe/Madrid") fija la zona horaria.
+var formatosFechaTexto = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParsearFecha lee AAAA-MM-DD con hora y zona opcionales.
+func ParsearFecha(texto string) (time.Time, error) {
+	texto = strings.TrimSpace(texto)
+	loc := time.UTC
+	if i := strings.LastIndex(texto, " "); i > 0 {
+		if sufijo := texto[i+1:]; strings.Contains(sufijo, "/") || sufijo == "UTC" {
+			if zona, err := CargarZona(sufijo); err == nil {
+				loc, texto = zona, strings.TrimSpace(texto[:i])
+			}
+		}
+	}
+	for _, formato := range formatosFechaTexto {
+		if t, err := time.ParseInLocation(formato, texto, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("❌ ERROR FATAL: fecha inválida '%s' (use AAAA-MM-DD, AAAA-MM-DD HH:MM:SS o agregue una zona como America/Mexico_City)", texto)
+}
+
+// CargarZona acepta nombres IANA, "UTC" y "local".
+func CargarZona(nombre string) (*time.Location, error) {
+	nombre = strings.TrimSpace(nombre)
+	if strings.EqualFold(nombre, "local") {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(nombre)
+	if err != nil || nombre == "" {
+		return nil, fmt.Errorf("❌ ERROR FATAL: zona horaria desconocida '%s' (ejemplos: \"America/Mexico_City\", \"Europe/Madrid\", \"UTC\")", nombre)
+	}
+	return loc, nil
+}
+
+// FormatearFecha muestra AAAA-MM-DD, con la hora solo si la tiene; los
+// valores de tipo hora se muestran como HH:MM:SS. Fuera de UTC y de la zona
+// local se agrega el nombre de la zona.
+func FormatearFecha(t time.Time) string {
+	var texto string
+	switch {
+	case EsSoloHora(t):
+		return t.Format("15:04:05")
+	case EsSoloFecha(t):
+		texto = t.Format("2006-01-02")
+	default:
+		texto = t.Format("2006-01-02 15:04:05")
+	}
+	if loc := t.Location(); loc != time.UTC && loc != time.Local {
+		if nombre := loc.String(); nombre != "" {
+			texto += " " + nombre
+		} else {
+			texto += " " + t.Format("-07:00") // desfase fijo (RFC 3339)
+		}
+	}
+	return texto
+}
+
+// EsSoloFecha indica si t no tiene hora (medianoche exacta).
+func EsSoloFecha(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+// EsSoloHora reconoce los valores de convertir_hora (1 de enero del año 0).
+func EsSoloHora(t time.Time) bool {
+	return t.Year() == 0 && t.Month() == time.January && t.Day() == 1
+}
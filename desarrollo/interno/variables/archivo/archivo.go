@@ -0,0 +1,105 @@
+package archivo
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"nepa/desarrollo/interno/administrador"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// Archivo guarda un archivo abierto con abrir(); se cierra con cerrar() o al
+// terminar el bloque usar que lo abrió.
+type Archivo struct {
+	mu     sync.RWMutex
+	nombre string
+	valor  *evaluador.Archivo
+}
+
+func CrearArchivo(nombre string, v interface{}) (administrador.Variable, error) {
+	a := &Archivo{nombre: strings.TrimSpace(nombre)}
+	if v != nil {
+		if err := a.AsignarDesdeInterface(v); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+func (a *Archivo) Nombre() string { return a.nombre }
+func (a *Archivo) Tipo() string   { return "archivo" }
+
+func (a *Archivo) Mostrar() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.valor == nil {
+		return fmt.Sprintf("%s:%s=nulo", a.Tipo(), a.nombre)
+	}
+	return fmt.Sprintf("%s:%s=%s", a.Tipo(), a.nombre, a.valor)
+}
+
+func (a *Archivo) AsignarDesdeInterface(v interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch val := v.(type) {
+	case nil:
+		a.valor = nil
+	case *evaluador.Archivo:
+		a.valor = val
+	case string:
+		// Una ruta se abre para lectura: archivo datos = "datos.txt"
+		abierto, err := evaluador.AbrirArchivo(strings.Trim(val, `"`), "leer")
+		if err != nil {
+			return err
+		}
+		a.valor = abierto
+	default:
+		return fmt.Errorf("❌ valor no compatible con tipo archivo → %v", v)
+	}
+	return nil
+}
+
+func (a *Archivo) ValorComoInterface() interface{} {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.valor == nil {
+		return nil
+	}
+	return a.valor
+}
+
+func (a *Archivo) JSON() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.valor == nil {
+		return administrador.JSONVariable(a.Tipo(), a.nombre, nil)
+	}
+	return administrador.JSONVariable(a.Tipo(), a.nombre, map[string]interface{}{
+		"ruta": a.valor.Ruta(), "modo": a.valor.Modo(), "cerrado": a.valor.Cerrado(),
+	})
+}
+
+// Cerrar permite que el bloque usar cierre la variable directamente.
+func (a *Archivo) Cerrar() error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.valor == nil {
+		return nil
+	}
+	return a.valor.Cerrar()
+}
+
+func (a *Archivo) ABooleano() (bool, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.valor != nil && !a.valor.Cerrado(), nil
+}
+
+func (a *Archivo) AEntero() (int, error) {
+	return 0, fmt.Errorf("❌ un archivo no se puede convertir a entero")
+}
+
+func (a *Archivo) AReal() (float64, error) {
+	return 0, fmt.Errorf("❌ un archivo no se puede convertir a real")
+}
@@ -1,7 +1,6 @@
 package bit
 
 import (
-    "encoding/json"
     "errors"
     "fmt"
     "strconv"
@@ -67,13 +66,7 @@ func (b *Bit) Mostrar() string {
 func (b *Bit) JSON() string {
     b.mu.RLock()
     defer b.mu.RUnlock()
-    payload := map[string]interface{}{
-        "tipo":   b.Tipo(),
-        "nombre": b.nombre,
-        "valor":  b.valor,
-    }
-    data, _ := json.Marshal(payload)
-    return string(data)
+    return administrador.JSONVariable(b.Tipo(), b.nombre, int(b.valor))
 }
 
 func (b *Bit) AsignarDesdeInterface(v interface{}) error {
@@ -1,7 +1,6 @@
 package booleano
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -60,10 +59,7 @@ func (b *Booleano) ValorComoInterface() interface{} {
 func (b *Booleano) JSON() string {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
-	data, _ := json.Marshal(map[string]interface{}{
-		"tipo": b.Tipo(), "nombre": b.nombre, "valor": b.valor,
-	})
-	return string(data)
+	return administrador.JSONVariable(b.Tipo(), b.nombre, b.valor)
 }
 
 // --- Métodos de Interfaz Obligatorios ---
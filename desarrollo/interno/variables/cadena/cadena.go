@@ -1,7 +1,6 @@
 package cadena
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -62,10 +61,7 @@ func (c *Cadena) ValorComoInterface() interface{} {
 func (c *Cadena) JSON() string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	data, _ := json.Marshal(map[string]interface{}{
-		"tipo": c.Tipo(), "nombre": c.nombre, "valor": c.valor,
-	})
-	return string(data)
+	return administrador.JSONVariable(c.Tipo(), c.nombre, c.valor)
 }
 
 func (c *Cadena) ABooleano() (bool, error) { return len(c.valor) > 0, nil }
@@ -1,7 +1,6 @@
 package caracter
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -62,10 +61,7 @@ func (ca *Caracter) ValorComoInterface() interface{} {
 func (ca *Caracter) JSON() string {
 	ca.mu.RLock()
 	defer ca.mu.RUnlock()
-	data, _ := json.Marshal(map[string]interface{}{
-		"tipo": ca.Tipo(), "nombre": ca.nombre, "valor": string(ca.valor),
-	})
-	return string(data)
+	return administrador.JSONVariable(ca.Tipo(), ca.nombre, ca.valor)
 }
 
 func (ca *Caracter) ABooleano() (bool, error) { return ca.valor != 0, nil }
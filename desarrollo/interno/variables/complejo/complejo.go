@@ -2,10 +2,12 @@ package complejo
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
 	"nepa/desarrollo/interno/administrador"
+	"nepa/desarrollo/interno/valores"
 )
 
 type Complejo struct {
@@ -33,7 +35,7 @@ func (c *Complejo) Tipo() string   { return "complejo" }
 func (c *Complejo) Mostrar() string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return fmt.Sprintf("%s:%s=%v", c.Tipo(), c.nombre, c.valor)
+	return fmt.Sprintf("%s:%s=%s", c.Tipo(), c.nombre, valores.FormatearComplejo(c.valor))
 }
 
 func (c *Complejo) AsignarDesdeInterface(v interface{}) error {
@@ -43,7 +45,17 @@ func (c *Complejo) AsignarDesdeInterface(v interface{}) error {
 		c.valor = 0 + 0i
 		return nil
 	}
-	if val, ok := v.(complex128); ok {
+	// Texto en forma binómica: "3+4i"
+	if s, ok := v.(string); ok {
+		val, err := strconv.ParseComplex(strings.ReplaceAll(s, " ", ""), 128)
+		if err != nil {
+			return fmt.Errorf("❌ valor no compatible con tipo complejo → %q", s)
+		}
+		c.valor = val
+		return nil
+	}
+	// Complejos y reales (estos últimos con parte imaginaria cero)
+	if val, ok := valores.ComoComplejo(v); ok {
 		c.valor = val
 		return nil
 	}
@@ -59,7 +71,7 @@ func (c *Complejo) ValorComoInterface() interface{} {
 func (c *Complejo) JSON() string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return fmt.Sprintf(`{"tipo":"complejo","nombre":"%s","real":%g,"imag":%g}`, c.nombre, real(c.valor), imag(c.valor))
+	return administrador.JSONVariable(c.Tipo(), c.nombre, c.valor)
 }
 
 func (c *Complejo) ABooleano() (bool, error) { 
@@ -8,19 +8,22 @@ import (
 	"nepa/desarrollo/interno/evaluador"
 )
 
+// Decimal guarda un número exacto en base 10 (evaluador.Decimal), sin los
+// errores de redondeo binario de float64: 0.1 + 0.2 == 0.3.
 type Decimal struct {
 	mu     sync.RWMutex
 	nombre string
-	valor  float64
+	valor  evaluador.Decimal
 }
 
 func CrearDecimal(nombre string, v interface{}) (administrador.Variable, error) {
 	d := &Decimal{
 		nombre: strings.TrimSpace(nombre),
-		valor:  0.00,
 	}
 	if v != nil {
-		d.AsignarDesdeInterface(v)
+		if err := d.AsignarDesdeInterface(v); err != nil {
+			return nil, err
+		}
 	}
 	return d, nil
 }
@@ -31,18 +34,21 @@ func (d *Decimal) Tipo() string   { return "decimal" }
 func (d *Decimal) Mostrar() string {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
-	return fmt.Sprintf("%s:%s=%.2f", d.Tipo(), d.nombre, d.valor)
+	return fmt.Sprintf("%s:%s=%s", d.Tipo(), d.nombre, d.valor)
 }
 
 func (d *Decimal) AsignarDesdeInterface(v interface{}) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	if v == nil {
-		d.valor = 0.0
+		d.valor = evaluador.Decimal{}
 		return nil
 	}
-	val, err := evaluador.ConvertirAReal(v)
-	if err != nil { return err }
+	// Los reales entran por su representación decimal más corta (0.1 → 0.1)
+	val, ok := evaluador.ComoDecimal(v)
+	if !ok {
+		return fmt.Errorf("❌ valor no compatible con tipo decimal → %v", v)
+	}
 	d.valor = val
 	return nil
 }
@@ -54,9 +60,25 @@ func (d *Decimal) ValorComoInterface() interface{} {
 }
 
 func (d *Decimal) JSON() string {
-	return fmt.Sprintf(`{"tipo":"decimal","nombre":"%s","valor":%.2f}`, d.nombre, d.valor)
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return administrador.JSONVariable(d.Tipo(), d.nombre, d.valor)
+}
+
+func (d *Decimal) ABooleano() (bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return !d.valor.EsCero(), nil
 }
 
-func (d *Decimal) ABooleano() (bool, error) { return d.valor != 0, nil }
-func (d *Decimal) AEntero() (int, error)    { return int(d.valor), nil }
-func (d *Decimal) AReal() (float64, error)  { return d.valor, nil }
+func (d *Decimal) AEntero() (int, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return int(d.valor.ConEscala(0, evaluador.RedondeoTruncar).Float64()), nil
+}
+
+func (d *Decimal) AReal() (float64, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.valor.Float64(), nil
+}
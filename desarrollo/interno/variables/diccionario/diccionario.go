@@ -61,7 +61,7 @@ func (d *Diccionario) ValorComoInterface() interface{} {
 func (d *Diccionario) JSON() string {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
-	return fmt.Sprintf(`{"tipo":"diccionario","nombre":"%s","elementos":%d}`, d.nombre, len(d.valor))
+	return administrador.JSONVariable(d.Tipo(), d.nombre, d.valor)
 }
 
 // Métodos de Interfaz Obligatorios
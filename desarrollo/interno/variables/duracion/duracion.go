@@ -0,0 +1,97 @@
+package duracion
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"nepa/desarrollo/interno/administrador"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// Duracion guarda un lapso de tiempo (30 dias, 1 año 2 meses, 2 h 30 min).
+type Duracion struct {
+	mu     sync.RWMutex
+	nombre string
+	valor  evaluador.Duracion
+}
+
+func CrearDuracion(nombre string, v interface{}) (administrador.Variable, error) {
+	d := &Duracion{nombre: strings.TrimSpace(nombre)}
+	if v != nil {
+		if err := d.AsignarDesdeInterface(v); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (d *Duracion) Nombre() string { return d.nombre }
+func (d *Duracion) Tipo() string   { return "duracion" }
+
+func (d *Duracion) Mostrar() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return fmt.Sprintf("%s:%s=%s", d.Tipo(), d.nombre, d.valor)
+}
+
+func (d *Duracion) AsignarDesdeInterface(v interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// Acepta duraciones, time.Duration (convertir_tiempo) y texto ("30 dias", "2h30m")
+	switch val := v.(type) {
+	case nil:
+		d.valor = evaluador.Duracion{}
+	case evaluador.Duracion:
+		d.valor = val
+	case time.Duration:
+		d.valor = evaluador.Duracion{Exacta: val}
+	case string:
+		parsed, err := evaluador.ParsearDuracion(strings.Trim(val, `"`))
+		if err != nil {
+			return err
+		}
+		d.valor = parsed
+	default:
+		return fmt.Errorf("❌ valor no compatible con tipo duracion → %v", v)
+	}
+	return nil
+}
+
+func (d *Duracion) ValorComoInterface() interface{} {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.valor
+}
+
+func (d *Duracion) JSON() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return administrador.JSONVariable(d.Tipo(), d.nombre, d.valor)
+}
+
+func (d *Duracion) ABooleano() (bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.valor != evaluador.Duracion{}, nil
+}
+
+// AEntero devuelve la duración en segundos; las que tienen meses no tienen
+// un largo fijo.
+func (d *Duracion) AEntero() (int, error) {
+	n, err := d.enSegundos()
+	return int(n), err
+}
+
+func (d *Duracion) AReal() (float64, error) { return d.enSegundos() }
+
+func (d *Duracion) enSegundos() (float64, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	n, err := d.valor.EnNanosegundos()
+	if err != nil {
+		return 0, err
+	}
+	return n.Seconds(), nil
+}
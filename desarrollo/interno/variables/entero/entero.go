@@ -2,6 +2,8 @@ package entero
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"strings"
 	"sync"
 
@@ -9,16 +11,18 @@ import (
 	"nepa/desarrollo/interno/evaluador"
 )
 
+// Entero guarda un entero de tamaño arbitrario: se comporta como int64
+// mientras cabe y se promueve a entero grande cuando se desborda.
 type Entero struct {
 	mu     sync.RWMutex
 	nombre string
-	valor  int64
+	valor  *big.Int
 }
 
 func CrearEntero(nombre string, v interface{}) (administrador.Variable, error) {
 	e := &Entero{
 		nombre: strings.TrimSpace(nombre),
-		valor:  0,
+		valor:  new(big.Int),
 	}
 	if v != nil {
 		if err := e.AsignarDesdeInterface(v); err != nil {
@@ -34,50 +38,62 @@ func (e *Entero) Tipo() string   { return "entero" }
 func (e *Entero) Mostrar() string {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	return fmt.Sprintf("%s:%s=%d", e.Tipo(), e.nombre, e.valor)
+	return fmt.Sprintf("%s:%s=%s", e.Tipo(), e.nombre, e.valor)
 }
 
 func (e *Entero) AsignarDesdeInterface(v interface{}) error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	if v == nil {
-		e.valor = 0
+		e.valor = new(big.Int)
+		return nil
+	}
+	if b, ok := evaluador.ComoEnteroGrande(v); ok {
+		e.valor = new(big.Int).Set(b)
 		return nil
 	}
 	val, err := evaluador.ConvertirAReal(v)
-	if err != nil {
+	if err != nil || math.IsInf(val, 0) || math.IsNaN(val) {
 		return fmt.Errorf("❌ valor inválido para Entero: %v", v)
 	}
-	e.valor = int64(val)
+	e.valor, _ = big.NewFloat(val).Int(nil) // Se trunca la parte decimal
 	return nil
 }
 
 func (e *Entero) ValorComoInterface() interface{} {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	return e.valor
+	// int64 mientras cabe (compatibilidad), entero grande si no
+	if e.valor.IsInt64() {
+		return e.valor.Int64()
+	}
+	return new(big.Int).Set(e.valor)
 }
 
 func (e *Entero) JSON() string {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	return fmt.Sprintf(`{"tipo":"entero","nombre":"%s","valor":%d}`, e.nombre, e.valor)
+	return administrador.JSONVariable(e.Tipo(), e.nombre, e.valor)
 }
 
 func (e *Entero) ABooleano() (bool, error) {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	return e.valor != 0, nil
+	return e.valor.Sign() != 0, nil
 }
 
 func (e *Entero) AEntero() (int, error) {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	return int(e.valor), nil
+	if !e.valor.IsInt64() {
+		return 0, fmt.Errorf("❌ el entero %s no cabe en 64 bits", e.valor)
+	}
+	return int(e.valor.Int64()), nil
 }
 
 func (e *Entero) AReal() (float64, error) {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	return float64(e.valor), nil
+	f, _ := new(big.Float).SetInt(e.valor).Float64()
+	return f, nil
 }
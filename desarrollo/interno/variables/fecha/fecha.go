@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 	"nepa/desarrollo/interno/administrador"
+	"nepa/desarrollo/interno/valores"
 )
 
 type Fecha struct {
@@ -45,7 +46,7 @@ func (f *Fecha) AsignarDesdeInterface(v interface{}) error {
 		f.valor = t
 		return nil
 	}
-	parsed, err := time.Parse("2006-01-02", fmt.Sprint(v))
+	parsed, err := valores.ParsearFecha(fmt.Sprint(v))
 	if err != nil { return err }
 	f.valor = parsed
 	return nil
@@ -58,7 +59,7 @@ func (f *Fecha) ValorComoInterface() interface{} {
 }
 
 func (f *Fecha) JSON() string {
-	return fmt.Sprintf(`{"tipo":"fecha","nombre":"%s","valor":"%s"}`, f.nombre, f.valor.Format("2006-01-02"))
+	return administrador.JSONVariable(f.Tipo(), f.nombre, f.valor.Format("2006-01-02"))
 }
 
 func (f *Fecha) ABooleano() (bool, error) { return !f.valor.IsZero(), nil }
@@ -0,0 +1,77 @@
+package funcion
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"nepa/desarrollo/interno/administrador"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// Funcion guarda un valor invocable: una lambda (funcion(x) => x^2 + 1) o
+// una referencia a otra función (g := seno).
+type Funcion struct {
+	mu     sync.RWMutex
+	nombre string
+	valor  evaluador.Funcion
+}
+
+func CrearFuncion(nombre string, v interface{}) (administrador.Variable, error) {
+	f := &Funcion{nombre: strings.TrimSpace(nombre)}
+	if v != nil {
+		if err := f.AsignarDesdeInterface(v); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (f *Funcion) Nombre() string { return f.nombre }
+func (f *Funcion) Tipo() string   { return "funcion" }
+
+func (f *Funcion) Mostrar() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return fmt.Sprintf("%s:%s=%s", f.Tipo(), f.nombre, f.valor)
+}
+
+func (f *Funcion) AsignarDesdeInterface(v interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if v == nil {
+		f.valor = evaluador.Funcion{}
+		return nil
+	}
+	// Acepta valores función y nombres de funciones registradas ("seno")
+	val, ok := evaluador.ComoFuncion(v)
+	if !ok {
+		return fmt.Errorf("❌ valor no compatible con tipo funcion → %v", v)
+	}
+	f.valor = val
+	return nil
+}
+
+func (f *Funcion) ValorComoInterface() interface{} {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.valor
+}
+
+func (f *Funcion) JSON() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return administrador.JSONVariable(f.Tipo(), f.nombre, map[string]interface{}{"parametros": f.valor.Parametros})
+}
+
+func (f *Funcion) ABooleano() (bool, error) {
+	return true, nil
+}
+
+func (f *Funcion) AEntero() (int, error) {
+	return 0, fmt.Errorf("❌ una funcion no se puede convertir a entero")
+}
+
+func (f *Funcion) AReal() (float64, error) {
+	return 0, fmt.Errorf("❌ una funcion no se puede convertir a real")
+}
@@ -41,6 +41,10 @@ func (h *Hora) AsignarDesdeInterface(v interface{}) error {
 		h.valor = time.Now()
 		return nil
 	}
+	if t, ok := v.(time.Time); ok {
+		h.valor = time.Date(0, time.January, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+		return nil
+	}
 	s := fmt.Sprint(v)
 	parsed, err := time.Parse("15:04:05", s)
 	if err != nil {
@@ -57,7 +61,7 @@ func (h *Hora) ValorComoInterface() interface{} {
 }
 
 func (h *Hora) JSON() string {
-	return fmt.Sprintf(`{"tipo":"hora","nombre":"%s","valor":"%s"}`, h.nombre, h.valor.Format("15:04:05"))
+	return administrador.JSONVariable(h.Tipo(), h.nombre, h.valor.Format("15:04:05"))
 }
 
 func (h *Hora) ABooleano() (bool, error) { return true, nil }
@@ -71,7 +71,7 @@ func (l *Lista) ValorComoInterface() interface{} {
 func (l *Lista) JSON() string {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
-	return fmt.Sprintf(`{"tipo":"lista","nombre":"%s","longitud":%d}`, l.nombre, len(l.valor))
+	return administrador.JSONVariable(l.Tipo(), l.nombre, l.valor)
 }
 
 func (l *Lista) ABooleano() (bool, error) { 
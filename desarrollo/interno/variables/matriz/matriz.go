@@ -154,7 +154,7 @@ func (m *Matriz) ValorComoInterface() interface{} {
 func (m *Matriz) JSON() string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return fmt.Sprintf(`{"tipo":"matriz","nombre":"%s","datos":%v}`, m.nombre, m.valor)
+	return administrador.JSONVariable(m.Tipo(), m.nombre, m.valor)
 }
 
 func (m *Matriz) ABooleano() (bool, error) { return len(m.valor) > 0, nil }
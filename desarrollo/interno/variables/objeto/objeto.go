@@ -47,7 +47,9 @@ func (o *Objeto) ValorComoInterface() interface{} {
 }
 
 func (o *Objeto) JSON() string {
-	return fmt.Sprintf(`{"tipo":"objeto","nombre":"%s"}`, o.nombre)
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return administrador.JSONVariable(o.Tipo(), o.nombre, o.valor)
 }
 
 func (o *Objeto) ABooleano() (bool, error) { return o.valor != nil, nil }
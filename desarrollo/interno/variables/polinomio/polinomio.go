@@ -0,0 +1,89 @@
+package polinomio
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"nepa/desarrollo/interno/administrador"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// Polinomio guarda un polinomio de coeficientes reales (3x^2 - 2x + 1).
+type Polinomio struct {
+	mu     sync.RWMutex
+	nombre string
+	valor  evaluador.Polinomio
+}
+
+func CrearPolinomio(nombre string, v interface{}) (administrador.Variable, error) {
+	p := &Polinomio{
+		nombre: strings.TrimSpace(nombre),
+		valor:  evaluador.NuevoPolinomio(nil, "x"),
+	}
+	if v != nil {
+		if err := p.AsignarDesdeInterface(v); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (p *Polinomio) Nombre() string { return p.nombre }
+func (p *Polinomio) Tipo() string   { return "polinomio" }
+
+func (p *Polinomio) Mostrar() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return fmt.Sprintf("%s:%s=%s", p.Tipo(), p.nombre, p.valor)
+}
+
+func (p *Polinomio) AsignarDesdeInterface(v interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if v == nil {
+		p.valor = evaluador.NuevoPolinomio(nil, "x")
+		return nil
+	}
+	// Acepta polinomios, texto ("3x^2 - 2x + 1") y números (polinomio constante)
+	if texto, ok := v.(string); ok {
+		val, err := evaluador.ParsearPolinomio(strings.Trim(texto, `"`))
+		if err != nil {
+			return fmt.Errorf("❌ valor no compatible con tipo polinomio → %v", v)
+		}
+		p.valor = val
+		return nil
+	}
+	val, ok := evaluador.ComoPolinomio(v, "x")
+	if !ok {
+		return fmt.Errorf("❌ valor no compatible con tipo polinomio → %v", v)
+	}
+	p.valor = val
+	return nil
+}
+
+func (p *Polinomio) ValorComoInterface() interface{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.valor
+}
+
+func (p *Polinomio) JSON() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return administrador.JSONVariable(p.Tipo(), p.nombre, p.valor)
+}
+
+func (p *Polinomio) ABooleano() (bool, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.valor.Grado() >= 0, nil
+}
+
+func (p *Polinomio) AEntero() (int, error) {
+	return 0, fmt.Errorf("❌ un polinomio no se puede convertir a entero")
+}
+
+func (p *Polinomio) AReal() (float64, error) {
+	return 0, fmt.Errorf("❌ un polinomio no se puede convertir a real")
+}
@@ -57,7 +57,11 @@ func (p *Puntero) ValorComoInterface() interface{} {
 }
 
 func (p *Puntero) JSON() string {
-	return fmt.Sprintf(`{"tipo":"puntero","nombre":"%s"}`, p.nombre)
+	// El valor de un puntero es el nombre de la variable a la que apunta
+	if p.objetivo == nil {
+		return administrador.JSONVariable(p.Tipo(), p.nombre, nil)
+	}
+	return administrador.JSONVariable(p.Tipo(), p.nombre, p.objetivo.Nombre())
 }
 
 func (p *Puntero) ABooleano() (bool, error) { return p.objetivo != nil, nil }
@@ -0,0 +1,87 @@
+package racional
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"nepa/desarrollo/interno/administrador"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// Racional guarda una fracción exacta (numerador/denominador reducidos).
+type Racional struct {
+	mu     sync.RWMutex
+	nombre string
+	valor  *big.Rat
+}
+
+func CrearRacional(nombre string, v interface{}) (administrador.Variable, error) {
+	r := &Racional{
+		nombre: strings.TrimSpace(nombre),
+		valor:  new(big.Rat),
+	}
+	if v != nil {
+		if err := r.AsignarDesdeInterface(v); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *Racional) Nombre() string { return r.nombre }
+func (r *Racional) Tipo() string   { return "racional" }
+
+func (r *Racional) Mostrar() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return fmt.Sprintf("%s:%s=%s", r.Tipo(), r.nombre, evaluador.FormatearRacional(r.valor))
+}
+
+func (r *Racional) AsignarDesdeInterface(v interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v == nil {
+		r.valor = new(big.Rat)
+		return nil
+	}
+	// Acepta enteros, decimales, reales (0.25 → 1/4) y texto ("3/4")
+	val, ok := evaluador.ComoRacional(v)
+	if !ok {
+		return fmt.Errorf("❌ valor no compatible con tipo racional → %v", v)
+	}
+	r.valor = new(big.Rat).Set(val)
+	return nil
+}
+
+func (r *Racional) ValorComoInterface() interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return new(big.Rat).Set(r.valor)
+}
+
+func (r *Racional) JSON() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return administrador.JSONVariable(r.Tipo(), r.nombre, r.valor)
+}
+
+func (r *Racional) ABooleano() (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.valor.Sign() != 0, nil
+}
+
+func (r *Racional) AEntero() (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return int(new(big.Int).Quo(r.valor.Num(), r.valor.Denom()).Int64()), nil
+}
+
+func (r *Racional) AReal() (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	f, _ := r.valor.Float64()
+	return f, nil
+}
@@ -61,7 +61,7 @@ func (r *Real) ValorComoInterface() interface{} {
 func (r *Real) JSON() string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	return fmt.Sprintf(`{"tipo":"real","nombre":"%s","valor":%g}`, r.nombre, r.valor)
+	return administrador.JSONVariable(r.Tipo(), r.nombre, r.valor)
 }
 
 func (r *Real) ABooleano() (bool, error) {
@@ -0,0 +1,79 @@
+package simbolico
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"nepa/desarrollo/interno/administrador"
+	"nepa/desarrollo/interno/evaluador"
+)
+
+// Simbolico guarda una expresión sin evaluar (x^2*seno(x)).
+type Simbolico struct {
+	mu     sync.RWMutex
+	nombre string
+	valor  evaluador.Simbolico
+}
+
+func CrearSimbolico(nombre string, v interface{}) (administrador.Variable, error) {
+	s := &Simbolico{nombre: strings.TrimSpace(nombre)}
+	if v == nil {
+		v = 0
+	}
+	if err := s.AsignarDesdeInterface(v); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Simbolico) Nombre() string { return s.nombre }
+func (s *Simbolico) Tipo() string   { return "simbolico" }
+
+func (s *Simbolico) Mostrar() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fmt.Sprintf("%s:%s=%s", s.Tipo(), s.nombre, s.valor)
+}
+
+func (s *Simbolico) AsignarDesdeInterface(v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v == nil {
+		v = 0
+	}
+	// Acepta expresiones, texto ("x^2 + 1"), polinomios y números
+	if texto, ok := v.(string); ok {
+		v = strings.Trim(texto, `"`)
+	}
+	val, err := evaluador.ComoSimbolico(v)
+	if err != nil {
+		return fmt.Errorf("❌ valor no compatible con tipo simbolico → %v", v)
+	}
+	s.valor = val
+	return nil
+}
+
+func (s *Simbolico) ValorComoInterface() interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.valor
+}
+
+func (s *Simbolico) JSON() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return administrador.JSONVariable(s.Tipo(), s.nombre, s.valor)
+}
+
+func (s *Simbolico) ABooleano() (bool, error) {
+	return true, nil
+}
+
+func (s *Simbolico) AEntero() (int, error) {
+	return 0, fmt.Errorf("❌ una expresión simbólica no se puede convertir a entero")
+}
+
+func (s *Simbolico) AReal() (float64, error) {
+	return 0, fmt.Errorf("❌ una expresión simbólica no se puede convertir a real")
+}
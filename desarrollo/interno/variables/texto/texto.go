@@ -1,7 +1,6 @@
 package texto
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -60,10 +59,7 @@ func (t *Texto) ValorComoInterface() interface{} {
 func (t *Texto) JSON() string {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
-	data, _ := json.Marshal(map[string]interface{}{
-		"tipo": t.Tipo(), "nombre": t.nombre, "valor": t.valor,
-	})
-	return string(data)
+	return administrador.JSONVariable(t.Tipo(), t.nombre, t.valor)
 }
 
 func (t *Texto) ABooleano() (bool, error) { return len(t.valor) > 0, nil }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"nepa/desarrollo/interno/administrador"
+	"nepa/desarrollo/interno/valores"
 )
 
 type Tiempo struct {
@@ -34,7 +35,7 @@ func (t *Tiempo) Tipo() string   { return "tiempo" }
 func (t *Tiempo) Mostrar() string {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
-	return fmt.Sprintf("%s:%s=%s", t.Tipo(), t.nombre, t.valor.Format("2006-01-02 15:04:05"))
+	return fmt.Sprintf("%s:%s=%s", t.Tipo(), t.nombre, valores.FormatearFecha(t.valor))
 }
 
 func (t *Tiempo) AsignarDesdeInterface(v interface{}) error {
@@ -50,13 +51,10 @@ func (t *Tiempo) AsignarDesdeInterface(v interface{}) error {
 	case time.Time:
 		t.valor = val
 	case string:
-		// Intentamos parsear formatos comunes de ingeniería
-		parsed, err := time.Parse("2006-01-02", val)
+		// AAAA-MM-DD, AAAA-MM-DD HH:MM:SS, RFC 3339 o con zona IANA al final
+		parsed, err := valores.ParsearFecha(val)
 		if err != nil {
-			parsed, err = time.Parse("2006-01-02 15:04:05", val)
-		}
-		if err != nil {
-			return fmt.Errorf("❌ formato de tiempo inválido. Use AAAA-MM-DD o AAAA-MM-DD HH:MM:SS")
+			return fmt.Errorf("❌ formato de tiempo inválido. Use AAAA-MM-DD, AAAA-MM-DD HH:MM:SS o agregue la zona (2024-05-01 09:00:00 Europe/Madrid)")
 		}
 		t.valor = parsed
 	default:
@@ -72,7 +70,7 @@ func (t *Tiempo) ValorComoInterface() interface{} {
 }
 
 func (t *Tiempo) JSON() string {
-	return fmt.Sprintf(`{"tipo":"tiempo","nombre":"%s","valor":"%s"}`, t.nombre, t.valor.String())
+	return administrador.JSONVariable(t.Tipo(), t.nombre, valores.FormatearFecha(t.valor))
 }
 
 // Métodos de Interfaz Obligatorios